@@ -0,0 +1,125 @@
+// Command cexport builds the steam id conversions as a C shared library, for use by SourceMod
+// extensions and other languages through their C FFI. Build it with make cexport, which writes
+// libsteamid and its generated header to build/cexport.
+//
+// The exported functions are:
+//
+//	// Converts the input, in any format, to the format. Returns NULL when either is not valid,
+//	// otherwise the result must be released with steamid_free.
+//	char* steamid_convert(char* input, int format);
+//	// Returns the steam64 of the input in any format, or 0 when it is not valid.
+//	unsigned long long steamid_parse(char* input);
+//	// Returns the format of the input, or STEAMID_FORMAT_INVALID when it is not a valid id.
+//	int steamid_detect(char* input);
+//	// Releases a string returned by steamid_convert.
+//	void steamid_free(char* value);
+//
+// Friend codes are accepted as input by every function.
+package main
+
+/*
+#include <stdlib.h>
+
+enum {
+	STEAMID_FORMAT_INVALID = 0,
+	STEAMID_FORMAT_STEAM64 = 1,
+	STEAMID_FORMAT_STEAM = 2,
+	STEAMID_FORMAT_STEAM3 = 3,
+	STEAMID_FORMAT_STEAM32 = 4,
+	STEAMID_FORMAT_FRIEND_CODE = 5,
+};
+*/
+import "C"
+
+import (
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// parse returns the id of the input and its format.
+func parse(input *C.char) (steamid.SteamID, C.int) {
+	if input == nil {
+		return steamid.SteamID{}, C.STEAMID_FORMAT_INVALID
+	}
+
+	value := strings.TrimSpace(C.GoString(input))
+
+	sid := steamid.New(value)
+	if !sid.Valid() {
+		friend, errFriend := steamid.FromFriendCode(value)
+		if errFriend != nil {
+			return steamid.SteamID{}, C.STEAMID_FORMAT_INVALID
+		}
+
+		return friend, C.STEAMID_FORMAT_FRIEND_CODE
+	}
+
+	switch {
+	case strings.HasPrefix(value, "STEAM_"):
+		return sid, C.STEAMID_FORMAT_STEAM
+	case strings.HasPrefix(value, "["):
+		return sid, C.STEAMID_FORMAT_STEAM3
+	}
+
+	if number, errParse := strconv.ParseUint(value, 10, 64); errParse == nil && number < steamid.BaseSID {
+		return sid, C.STEAMID_FORMAT_STEAM32
+	}
+
+	return sid, C.STEAMID_FORMAT_STEAM64
+}
+
+//export steamid_convert
+func steamid_convert(input *C.char, format C.int) *C.char { //nolint:revive,stylecheck
+	sid, detected := parse(input)
+	if detected == C.STEAMID_FORMAT_INVALID {
+		return nil
+	}
+
+	var converted string
+
+	switch format {
+	case C.STEAMID_FORMAT_STEAM64:
+		converted = sid.String()
+	case C.STEAMID_FORMAT_STEAM:
+		converted = string(sid.Steam(false))
+	case C.STEAMID_FORMAT_STEAM3:
+		converted = string(sid.Steam3())
+	case C.STEAMID_FORMAT_STEAM32:
+		converted = strconv.FormatUint(uint64(sid.AccountID), 10)
+	case C.STEAMID_FORMAT_FRIEND_CODE:
+		converted = sid.FriendCode()
+	}
+
+	if converted == "" {
+		return nil
+	}
+
+	return C.CString(converted)
+}
+
+//export steamid_parse
+func steamid_parse(input *C.char) C.ulonglong { //nolint:revive,stylecheck
+	sid, detected := parse(input)
+	if detected == C.STEAMID_FORMAT_INVALID {
+		return 0
+	}
+
+	return C.ulonglong(sid.Uint64())
+}
+
+//export steamid_detect
+func steamid_detect(input *C.char) C.int { //nolint:revive,stylecheck
+	_, detected := parse(input)
+
+	return detected
+}
+
+//export steamid_free
+func steamid_free(value *C.char) { //nolint:revive,stylecheck
+	C.free(unsafe.Pointer(value))
+}
+
+func main() {}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// banRecord is a player ban state along with all the representations of its steam id.
+type banRecord struct {
+	conversion
+	CommunityBanned  bool   `json:"community_banned"`
+	VACBanned        bool   `json:"vac_banned"`
+	NumberOfVACBans  int    `json:"number_of_vac_bans"`
+	NumberOfGameBans int    `json:"number_of_game_bans"`
+	DaysSinceLastBan int    `json:"days_since_last_ban"`
+	EconomyBan       string `json:"economy_ban"`
+}
+
+func newBanRecord(input string, ban steamid.PlayerBanState) banRecord {
+	return banRecord{
+		conversion:       newConversion(input, ban.SteamID),
+		CommunityBanned:  ban.CommunityBanned,
+		VACBanned:        ban.VACBanned,
+		NumberOfVACBans:  ban.NumberOfVACBans,
+		NumberOfGameBans: ban.NumberOfGameBans,
+		DaysSinceLastBan: ban.DaysSinceLastBan,
+		EconomyBan:       ban.EconomyBan,
+	}
+}
+
+func (r banRecord) columns() []string {
+	return append(r.conversion.columns(), "community_banned", "vac_banned", "number_of_vac_bans",
+		"number_of_game_bans", "days_since_last_ban", "economy_ban")
+}
+
+func (r banRecord) values() []string {
+	return append(r.conversion.values(), strconv.FormatBool(r.CommunityBanned), strconv.FormatBool(r.VACBanned),
+		strconv.Itoa(r.NumberOfVACBans), strconv.Itoa(r.NumberOfGameBans), strconv.Itoa(r.DaysSinceLastBan), r.EconomyBan)
+}
+
+// bansCmd fetches the ban states of steam ids.
+var bansCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:     "bans",
+	Aliases: []string{"b"},
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Show the VAC, game and community ban states of steam ids",
+	Long: `Show the VAC, game and community ban states of steam ids.
+
+Requires a steam web api key to be set with the STEAM_TOKEN environment variable.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			steamIDs = make(steamid.Collection, 0, len(args))
+			inputs   = map[steamid.SteamID]string{}
+		)
+
+		for _, arg := range args {
+			sid := steamid.New(arg)
+			if !sid.Valid() {
+				fatalf(cmd, exitParse, "Failed to convert id: %s", arg)
+			}
+
+			steamIDs = append(steamIDs, sid)
+			inputs[sid] = arg
+		}
+
+		cache := mustOpenCache(cmd)
+
+		bans, err := cache.playerBans(cmd.Context(), steamIDs)
+		if err != nil {
+			fatalf(cmd, errorCode(err, exitNetwork), "Failed to fetch bans: %v", err)
+		}
+
+		saveCache(cache)
+
+		records := make([]banRecord, 0, len(bans))
+		for _, ban := range bans {
+			records = append(records, newBanRecord(inputs[ban.SteamID], ban))
+		}
+
+		if outputFormat(cmd) != outputText {
+			if errWrite := writeRecords(cmd, os.Stdout, records); errWrite != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", errWrite)
+			}
+
+			os.Exit(0)
+		}
+
+		for _, record := range records {
+			fmt.Printf(`Steam64:      %s
+Community:    %t
+VAC:          %t (%d)
+Game Bans:    %d
+Last Ban:     %d days
+Economy:      %s
+
+`, record.Steam64, record.CommunityBanned, record.VACBanned, record.NumberOfVACBans, record.NumberOfGameBans,
+				record.DaysSinceLastBan, record.EconomyBan) //nolint:forbidigo
+		}
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bansCmd)
+}
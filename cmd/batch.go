@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// progressInterval is how often progress lines are written when stderr is not a terminal.
+	progressInterval = time.Second * 10
+	progressBarWidth = 30
+)
+
+var errMaxRequests = errors.New("maximum number of web api requests reached, see --max-requests")
+
+// rateLimitTransport is a http.RoundTripper that limits the rate of requests made to the
+// steam web api. A nil limiter or budget applies no limit. Rejected, when set, is called for
+// requests abandoned while waiting.
+type rateLimitTransport struct {
+	next     http.RoundTripper
+	limiter  <-chan time.Time
+	budget   *atomic.Int64
+	rejected func()
+}
+
+func (t rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.budget != nil && t.budget.Add(-1) < 0 {
+		return nil, errMaxRequests
+	}
+
+	if t.limiter != nil {
+		select {
+		case <-t.limiter:
+		case <-req.Context().Done():
+			if t.rejected != nil {
+				t.rejected()
+			}
+
+			return nil, req.Context().Err() //nolint:wrapcheck
+		}
+	}
+
+	return t.next.RoundTrip(req) //nolint:wrapcheck
+}
+
+// clientLimits are the limits applied to the requests of a web api client.
+type clientLimits struct {
+	// rate is the maximum number of requests per second, 0 for no limit.
+	rate float64
+	// maxRequests is the total number of requests allowed, 0 for no limit.
+	maxRequests int64
+	// rejected is called for requests abandoned while waiting for the rate limiter, may be nil.
+	rejected func()
+}
+
+// newRateLimitedClient creates a web api client applying the limits, exiting on failure. The
+// returned function stops the rate limiter.
+func newRateLimitedClient(cmd *cobra.Command, limits clientLimits, opts ...steamid.ClientOption) (*steamid.Client, func()) {
+	if limits.rate < 0 || limits.maxRequests < 0 {
+		fatalf(cmd, exitConfig, "Rate and max requests must not be negative")
+	}
+
+	var (
+		transport = rateLimitTransport{next: http.DefaultTransport, rejected: limits.rejected}
+		stop      = func() {}
+	)
+
+	if limits.rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / limits.rate))
+		transport.limiter = ticker.C
+		stop = ticker.Stop
+	}
+
+	if limits.maxRequests > 0 {
+		transport.budget = &atomic.Int64{}
+		transport.budget.Store(limits.maxRequests)
+	}
+
+	opts = append(opts, steamid.WithHTTPClient(&http.Client{Timeout: time.Second * 10, Transport: transport}))
+
+	client, errClient := steamid.NewClient(apiKey(cmd), opts...)
+	if errClient != nil {
+		stop()
+		fatalf(cmd, exitConfig, "Failed to create client: %v", errClient)
+	}
+
+	return client, stop
+}
+
+// addBatchFlags adds the --rate, --max-requests and --no-progress flags of the long-running
+// batch commands.
+func addBatchFlags(cmd *cobra.Command, defaultRate float64) {
+	cmd.Flags().Float64("rate", defaultRate, "Maximum requests per second made to the steam web api, 0 for no limit")
+	cmd.Flags().Int64("max-requests", 0, "Stop making steam web api requests after this many, 0 for no limit")
+	cmd.Flags().Bool("no-progress", false, "Do not report progress on stderr")
+}
+
+// newBatchClient creates a web api client limited by the batch flags.
+func newBatchClient(cmd *cobra.Command, opts ...steamid.ClientOption) (*steamid.Client, func()) {
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	maxRequests, _ := cmd.Flags().GetInt64("max-requests")
+
+	return newRateLimitedClient(cmd, clientLimits{rate: rate, maxRequests: maxRequests}, opts...)
+}
+
+// progress reports the progress of a batch command on stderr. On a terminal a progress bar is
+// redrawn in place, otherwise a line is written every progressInterval. A nil progress reports
+// nothing, so it can be passed around when --no-progress is set.
+type progress struct {
+	mu       sync.Mutex
+	writer   io.Writer
+	label    string
+	unit     string
+	total    int
+	done     int
+	start    time.Time
+	last     time.Time
+	terminal bool
+}
+
+// newProgress returns the progress of total units of work, or nil with --no-progress. A total of
+// 0 reports only the amount of work done.
+func newProgress(cmd *cobra.Command, label string, unit string, total int) *progress {
+	if disabled, _ := cmd.Flags().GetBool("no-progress"); disabled {
+		return nil
+	}
+
+	now := time.Now()
+	stat, errStat := os.Stderr.Stat()
+
+	return &progress{
+		writer:   os.Stderr,
+		label:    label,
+		unit:     unit,
+		total:    total,
+		start:    now,
+		last:     now,
+		terminal: errStat == nil && stat.Mode()&os.ModeCharDevice != 0,
+	}
+}
+
+// add records n more units of work done.
+func (p *progress) add(n int) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done += n
+
+	now := time.Now()
+	if p.terminal || now.Sub(p.last) >= progressInterval {
+		p.last = now
+		p.report(now)
+	}
+}
+
+// finish writes the final progress.
+func (p *progress) finish() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.report(time.Now())
+
+	if p.terminal {
+		_, _ = fmt.Fprintln(p.writer)
+	}
+}
+
+func (p *progress) report(now time.Time) {
+	elapsed := now.Sub(p.start).Round(time.Second)
+
+	if p.total <= 0 {
+		p.write(fmt.Sprintf("%s: %d %s, %s elapsed", p.label, p.done, p.unit, elapsed))
+
+		return
+	}
+
+	var (
+		ratio = min(float64(p.done)/float64(p.total), 1)
+		eta   = "unknown"
+	)
+
+	if p.done > 0 {
+		eta = (time.Duration(float64(now.Sub(p.start))/ratio) - now.Sub(p.start)).Round(time.Second).String()
+	}
+
+	if p.terminal {
+		filled := int(ratio * progressBarWidth)
+		p.write(fmt.Sprintf("%s [%s%s] %d/%d %s %3.0f%% eta %s", p.label, strings.Repeat("=", filled),
+			strings.Repeat(" ", progressBarWidth-filled), p.done, p.total, p.unit, ratio*100, eta))
+
+		return
+	}
+
+	p.write(fmt.Sprintf("%s: %d/%d %s (%.0f%%), %s elapsed, eta %s", p.label, p.done, p.total, p.unit,
+		ratio*100, elapsed, eta))
+}
+
+func (p *progress) write(line string) {
+	if p.terminal {
+		// Clear the rest of the previous line, which may have been longer.
+		_, _ = fmt.Fprintf(p.writer, "\r%s\033[K", line)
+
+		return
+	}
+
+	_, _ = fmt.Fprintln(p.writer, line)
+}
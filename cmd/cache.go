@@ -0,0 +1,470 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra/store"
+	"github.com/leighmacdonald/steamid/v4/extra/store/sqlitestore"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cacheFileName = "cache.json"
+	// diskCacheTTL is how long results are kept in the on-disk cache.
+	diskCacheTTL = time.Hour * 24
+	// resolveChunkSize is the number of uncached queries resolved between progress updates.
+	resolveChunkSize = 100
+)
+
+type cacheEntry[T any] struct {
+	Value   T         `json:"value"`
+	Expires time.Time `json:"expires"`
+}
+
+func (e cacheEntry[T]) expired(now time.Time) bool {
+	return now.After(e.Expires)
+}
+
+// diskCache is the on-disk cache of web api results shared by the resolve, summary and
+// bans commands. It is stored as a single json file in the cache directory.
+type diskCache struct {
+	path string
+	// client fetches the results missing from the cache, the package level functions are used
+	// when it is nil.
+	client *steamid.Client
+	// progress is updated as results are found in the cache or fetched, may be nil.
+	progress *progress
+	// store records the resolved vanity names and persona names when --store is set, may be nil.
+	store     store.Store
+	Resolved  map[string]cacheEntry[steamid.SteamID]        `json:"resolved"`
+	Summaries map[string]cacheEntry[steamid.PlayerSummary]  `json:"summaries"`
+	Bans      map[string]cacheEntry[steamid.PlayerBanState] `json:"bans"`
+}
+
+// cacheDir returns the cache directory set with --cache-dir, defaulting to a steamid directory in
+// the users cache directory.
+func cacheDir(cmd *cobra.Command) string {
+	if dir := cmd.Flag("cache-dir").Value.String(); dir != "" {
+		return dir
+	}
+
+	userDir, errDir := os.UserCacheDir()
+	if errDir != nil {
+		return filepath.Join(os.TempDir(), "steamid")
+	}
+
+	return filepath.Join(userDir, "steamid")
+}
+
+// openStore opens the sqlite database set with --store, returning nil if it is not set.
+func openStore(cmd *cobra.Command) (store.Store, error) {
+	path := cmd.Flag("store").Value.String()
+	if path == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	if errDir := os.MkdirAll(filepath.Dir(path), 0o700); errDir != nil {
+		return nil, errDir //nolint:wrapcheck
+	}
+
+	return sqlitestore.Open(cmd.Context(), path) //nolint:wrapcheck
+}
+
+// openCache loads the cache, returning an empty cache if it does not exist yet.
+func openCache(cmd *cobra.Command) (*diskCache, error) {
+	cache := &diskCache{
+		path:      filepath.Join(cacheDir(cmd), cacheFileName),
+		Resolved:  map[string]cacheEntry[steamid.SteamID]{},
+		Summaries: map[string]cacheEntry[steamid.PlayerSummary]{},
+		Bans:      map[string]cacheEntry[steamid.PlayerBanState]{},
+	}
+
+	mappingStore, errStore := openStore(cmd)
+	if errStore != nil {
+		return nil, fmt.Errorf("failed to open store: %w", errStore)
+	}
+
+	cache.store = mappingStore
+
+	body, errRead := os.ReadFile(cache.path)
+	if errRead != nil {
+		if errors.Is(errRead, os.ErrNotExist) {
+			return cache, nil
+		}
+
+		return nil, errRead //nolint:wrapcheck
+	}
+
+	if errUnmarshal := json.Unmarshal(body, cache); errUnmarshal != nil {
+		return nil, fmt.Errorf("invalid cache file %s: %w", cache.path, errUnmarshal)
+	}
+
+	return cache, nil
+}
+
+// save writes the cache to disk, dropping expired entries, and closes the store.
+func (c *diskCache) save() error {
+	now := time.Now()
+
+	if c.store != nil {
+		errClose := c.store.Close()
+		c.store = nil
+
+		if errClose != nil {
+			return errClose //nolint:wrapcheck
+		}
+	}
+
+	for key, entry := range c.Resolved {
+		if entry.expired(now) {
+			delete(c.Resolved, key)
+		}
+	}
+
+	for key, entry := range c.Summaries {
+		if entry.expired(now) {
+			delete(c.Summaries, key)
+		}
+	}
+
+	for key, entry := range c.Bans {
+		if entry.expired(now) {
+			delete(c.Bans, key)
+		}
+	}
+
+	body, errMarshal := json.Marshal(c)
+	if errMarshal != nil {
+		return errMarshal //nolint:wrapcheck
+	}
+
+	if errDir := os.MkdirAll(filepath.Dir(c.path), 0o700); errDir != nil {
+		return errDir //nolint:wrapcheck
+	}
+
+	return os.WriteFile(c.path, body, 0o600) //nolint:wrapcheck
+}
+
+// resolveAll resolves the queries, only querying the api for the ones missing from the cache.
+func (c *diskCache) resolveAll(ctx context.Context, queries []string, concurrency int) []steamid.ResolveResult {
+	var (
+		now     = time.Now()
+		results = make([]steamid.ResolveResult, len(queries))
+		missing []string
+		indexes []int
+	)
+
+	for idx, query := range queries {
+		if entry, found := c.Resolved[query]; found && !entry.expired(now) {
+			results[idx] = steamid.ResolveResult{Query: query, SteamID: entry.Value}
+		} else {
+			missing = append(missing, query)
+			indexes = append(indexes, idx)
+		}
+	}
+
+	resolve := steamid.ResolveAll
+	if c.client != nil {
+		resolve = c.client.ResolveAll
+	}
+
+	c.progress.add(len(queries) - len(missing))
+
+	// Queries are resolved in chunks so progress can be reported as they complete.
+	chunkSize := max(resolveChunkSize, concurrency)
+
+	for start := 0; start < len(missing); start += chunkSize {
+		end := min(start+chunkSize, len(missing))
+
+		for idx, result := range resolve(ctx, missing[start:end], concurrency) {
+			results[indexes[start+idx]] = result
+
+			if result.Err == nil {
+				c.Resolved[result.Query] = cacheEntry[steamid.SteamID]{Value: result.SteamID, Expires: now.Add(diskCacheTTL)}
+			}
+		}
+
+		c.progress.add(end - start)
+	}
+
+	c.recordResolutions(ctx, results, now)
+
+	return results
+}
+
+// recordResolutions adds the successful results of vanity names and profile urls to the store.
+// Queries that are already steam ids are skipped. Failures are only logged since the results are
+// still valid.
+func (c *diskCache) recordResolutions(ctx context.Context, results []steamid.ResolveResult, now time.Time) {
+	if c.store == nil {
+		return
+	}
+
+	for _, result := range results {
+		if sid := steamid.New(result.Query); result.Err != nil || sid.Valid() {
+			continue
+		}
+
+		if errAdd := c.store.AddResolution(ctx, result.Query, result.SteamID, now); errAdd != nil {
+			log.Printf("Failed to record resolution: %v", errAdd)
+
+			return
+		}
+	}
+}
+
+// recordAliases adds the persona names of the summaries to the store. Failures are only logged
+// since the results are still valid.
+func (c *diskCache) recordAliases(ctx context.Context, summaries []steamid.PlayerSummary, now time.Time) {
+	if c.store == nil {
+		return
+	}
+
+	for _, summary := range summaries {
+		if errAdd := c.store.AddAlias(ctx, summary.SteamID, summary.PersonaName, now); errAdd != nil {
+			log.Printf("Failed to record alias: %v", errAdd)
+
+			return
+		}
+	}
+}
+
+// playerSummaries fetches the summaries, only querying the api for the ones missing from the cache.
+func (c *diskCache) playerSummaries(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerSummary, error) {
+	var (
+		now       = time.Now()
+		summaries []steamid.PlayerSummary
+		missing   steamid.Collection
+	)
+
+	for _, sid := range steamIDs {
+		if entry, found := c.Summaries[sid.String()]; found && !entry.expired(now) {
+			summaries = append(summaries, entry.Value)
+		} else {
+			missing = append(missing, sid)
+		}
+	}
+
+	if len(missing) == 0 {
+		return summaries, nil
+	}
+
+	fetch := steamid.PlayerSummaries
+	if c.client != nil {
+		fetch = c.client.PlayerSummaries
+	}
+
+	c.progress.add(len(steamIDs) - len(missing))
+
+	for start := 0; start < len(missing); start += steamid.MaxBatchIDs {
+		end := min(start+steamid.MaxBatchIDs, len(missing))
+
+		fetched, errFetch := fetch(ctx, missing[start:end])
+		if errFetch != nil {
+			return nil, errFetch //nolint:wrapcheck
+		}
+
+		for _, summary := range fetched {
+			c.Summaries[summary.SteamID.String()] = cacheEntry[steamid.PlayerSummary]{Value: summary, Expires: now.Add(diskCacheTTL)}
+		}
+
+		c.recordAliases(ctx, fetched, now)
+
+		summaries = append(summaries, fetched...)
+
+		c.progress.add(end - start)
+	}
+
+	return summaries, nil
+}
+
+// playerBans fetches the ban states, only querying the api for the ones missing from the cache.
+func (c *diskCache) playerBans(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerBanState, error) {
+	var (
+		now     = time.Now()
+		bans    []steamid.PlayerBanState
+		missing steamid.Collection
+	)
+
+	for _, sid := range steamIDs {
+		if entry, found := c.Bans[sid.String()]; found && !entry.expired(now) {
+			bans = append(bans, entry.Value)
+		} else {
+			missing = append(missing, sid)
+		}
+	}
+
+	if len(missing) == 0 {
+		return bans, nil
+	}
+
+	fetch := steamid.PlayerBans
+	if c.client != nil {
+		fetch = c.client.PlayerBans
+	}
+
+	c.progress.add(len(steamIDs) - len(missing))
+
+	for start := 0; start < len(missing); start += steamid.MaxBatchIDs {
+		end := min(start+steamid.MaxBatchIDs, len(missing))
+
+		fetched, errFetch := fetch(ctx, missing[start:end])
+		if errFetch != nil {
+			return nil, errFetch //nolint:wrapcheck
+		}
+
+		for _, ban := range fetched {
+			c.Bans[ban.SteamID.String()] = cacheEntry[steamid.PlayerBanState]{Value: ban, Expires: now.Add(diskCacheTTL)}
+		}
+
+		bans = append(bans, fetched...)
+
+		c.progress.add(end - start)
+	}
+
+	return bans, nil
+}
+
+// mustOpenCache opens the cache, exiting on failure.
+func mustOpenCache(cmd *cobra.Command) *diskCache {
+	cache, errCache := openCache(cmd)
+	if errCache != nil {
+		fatalf(cmd, exitFailure, "Failed to open cache: %v", errCache)
+	}
+
+	return cache
+}
+
+// saveCache writes the cache, only warning on failure since the results are still valid.
+func saveCache(cache *diskCache) {
+	if errSave := cache.save(); errSave != nil {
+		log.Printf("Failed to save cache: %v", errSave)
+	}
+}
+
+// cacheCmd groups the cache management commands.
+var cacheCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "cache",
+	Short: "Manage the on-disk cache of web api results",
+	Long: `Manage the on-disk cache of web api results.
+
+Results of the resolve, summary and bans commands are cached for 24 hours. The cache is
+stored in the directory set with --cache-dir, defaulting to the users cache directory.
+
+Separately, when --store is set to the path of a sqlite database, every vanity name and
+persona name seen is kept there permanently. See the history command.`,
+}
+
+var cachePathCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "path",
+	Args:  cobra.NoArgs,
+	Short: "Print the path of the cache file",
+	Run: func(cmd *cobra.Command, _ []string) {
+		fmt.Println(filepath.Join(cacheDir(cmd), cacheFileName)) //nolint:forbidigo
+	},
+}
+
+var cacheStatsCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "stats",
+	Args:  cobra.NoArgs,
+	Short: "Show the number of cached results",
+	Run: func(cmd *cobra.Command, _ []string) {
+		cache := mustOpenCache(cmd)
+
+		// The cache is only read, so the store opened with it is closed here rather than by save.
+		if cache.store != nil {
+			defer func() {
+				_ = cache.store.Close()
+			}()
+		}
+
+		var size int64
+		if stat, errStat := os.Stat(cache.path); errStat == nil {
+			size = stat.Size()
+		}
+
+		fmt.Printf(`Path:         %s
+Size:         %d bytes
+Resolved:     %d
+Summaries:    %d
+Bans:         %d
+`, cache.path, size, len(cache.Resolved), len(cache.Summaries), len(cache.Bans)) //nolint:forbidigo
+	},
+}
+
+var cacheClearCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "clear",
+	Args:  cobra.NoArgs,
+	Short: "Remove all cached results",
+	Run: func(cmd *cobra.Command, _ []string) {
+		path := filepath.Join(cacheDir(cmd), cacheFileName)
+		if errRemove := os.Remove(path); errRemove != nil && !errors.Is(errRemove, os.ErrNotExist) {
+			fatalf(cmd, exitFailure, "Failed to clear cache: %v", errRemove)
+		}
+	},
+}
+
+var cacheWarmCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "warm <file>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Resolve and cache the vanity names or profile urls in a file, one per line",
+	Run: func(cmd *cobra.Command, args []string) {
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		file, errOpen := os.Open(args[0])
+		if errOpen != nil {
+			fatalf(cmd, exitFailure, "Failed to open input file (%s): %v", args[0], errOpen)
+		}
+
+		var (
+			queries []string
+			scanner = bufio.NewScanner(file)
+		)
+
+		for scanner.Scan() {
+			if query := strings.TrimSpace(scanner.Text()); query != "" {
+				queries = append(queries, query)
+			}
+		}
+
+		_ = file.Close()
+
+		if errScan := scanner.Err(); errScan != nil {
+			fatalf(cmd, exitFailure, "Failed to read input file: %v", errScan)
+		}
+
+		cache := mustOpenCache(cmd)
+
+		var failed int
+
+		for _, result := range cache.resolveAll(cmd.Context(), queries, concurrency) {
+			if result.Err != nil {
+				failed++
+
+				log.Printf("Failed to resolve %s: %v", result.Query, result.Err)
+			}
+		}
+
+		saveCache(cache)
+
+		fmt.Printf("Cached %d of %d queries\n", len(queries)-failed, len(queries)) //nolint:forbidigo
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().String("cache-dir", "", "Directory of the web api result cache")
+	rootCmd.PersistentFlags().String("store", "", "SQLite database recording resolved vanity names and persona names")
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cachePathCmd, cacheStatsCmd, cacheClearCmd, cacheWarmCmd)
+	cacheWarmCmd.Flags().IntP("concurrency", "c", 4, "Number of queries resolved concurrently")
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// completeFlag registers the values offered by shell completion for a flag of the command.
+func completeFlag(cmd *cobra.Command, flag string, values ...string) {
+	cobra.CheckErr(cmd.RegisterFlagCompletionFunc(flag, cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp)))
+}
+
+// completionCmd writes the shell completion scripts.
+var completionCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:       "completion {bash|zsh|fish|powershell}",
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	Short:     "Generate the shell completion script",
+	Long: `Generate the shell completion script.
+
+Commands, flags and the values of enumerated flags such as --type and --format are completed.
+To load the completions in the current shell:
+
+  bash:        source <(steamid completion bash)
+  zsh:         source <(steamid completion zsh)
+  fish:        steamid completion fish | source
+  powershell:  steamid completion powershell | Out-String | Invoke-Expression
+
+Packages should install the output in the completion directory of each shell, e.g.
+/usr/share/bash-completion/completions/steamid.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+
+		switch args[0] {
+		case "bash":
+			err = rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			err = rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			err = rootCmd.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			err = rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+
+		if err != nil {
+			fatalf(cmd, exitFailure, "Failed to write completion script: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// config is the optional configuration file. Each value is only used when it is not set by the
+// matching flag or environment variable.
+type config struct {
+	APIKey   string `yaml:"api_key"`
+	Output   string `yaml:"output"`
+	CacheDir string `yaml:"cache_dir"`
+	Store    string `yaml:"store"`
+	Serve    struct {
+		Listen   string       `yaml:"listen"`
+		CacheTTL string       `yaml:"cache_ttl"`
+		Rate     float64      `yaml:"rate"`
+		Tokens   []serveToken `yaml:"tokens"`
+	} `yaml:"serve"`
+}
+
+// configPath returns the default location of the configuration file.
+func configPath() string {
+	dir, errDir := os.UserConfigDir()
+	if errDir != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "steamid", "config.yaml")
+}
+
+// loadConfig reads the configuration file set with --config, or the default configuration file
+// if it exists.
+func loadConfig(cmd *cobra.Command) (config, error) {
+	var cfg config
+
+	path := cmd.Flag("config").Value.String()
+	explicit := path != ""
+
+	if !explicit {
+		path = configPath()
+	}
+
+	if path == "" {
+		return cfg, nil
+	}
+
+	body, errRead := os.ReadFile(path)
+	if errRead != nil {
+		if !explicit && errors.Is(errRead, os.ErrNotExist) {
+			return cfg, nil
+		}
+
+		return cfg, errRead //nolint:wrapcheck
+	}
+
+	if errUnmarshal := yaml.Unmarshal(body, &cfg); errUnmarshal != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %w", path, errUnmarshal)
+	}
+
+	return cfg, nil
+}
+
+// applyConfig sets the api key and any flags not set on the command line using, in order of
+// precedence, the environment and the configuration file.
+func applyConfig(cmd *cobra.Command, _ []string) error {
+	cfg, errConfig := loadConfig(cmd)
+	if errConfig != nil {
+		return errConfig
+	}
+
+	rate := ""
+	if cfg.Serve.Rate > 0 {
+		rate = fmt.Sprintf("%f", cfg.Serve.Rate)
+	}
+
+	for _, setting := range []struct {
+		flag  string
+		env   string
+		value string
+	}{
+		{flag: "api-key", env: "STEAM_TOKEN", value: cfg.APIKey},
+		{flag: "cache-dir", env: "STEAMID_CACHE_DIR", value: cfg.CacheDir},
+		{flag: "store", env: "STEAMID_STORE", value: cfg.Store},
+		{flag: "listen", env: "STEAMID_LISTEN", value: cfg.Serve.Listen},
+		{flag: "cache-ttl", env: "STEAMID_CACHE_TTL", value: cfg.Serve.CacheTTL},
+		{flag: "rate", env: "STEAMID_RATE", value: rate},
+		{flag: "password", env: "STEAMID_RCON_PASSWORD"},
+	} {
+		flag := cmd.Flags().Lookup(setting.flag)
+		if flag == nil || flag.Changed {
+			continue
+		}
+
+		value := setting.value
+		if envValue, found := os.LookupEnv(setting.env); found && envValue != "" {
+			value = envValue
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if errSet := cmd.Flags().Set(setting.flag, value); errSet != nil {
+			return fmt.Errorf("invalid %s value: %w", setting.flag, errSet)
+		}
+	}
+
+	if outputFormat(cmd) == outputText {
+		output := cfg.Output
+		if envOutput := os.Getenv("STEAMID_OUTPUT"); envOutput != "" {
+			output = envOutput
+		}
+
+		switch output {
+		case outputText:
+		case outputJSON, outputCSV, outputTSV:
+			if errSet := cmd.Flags().Set(output, "true"); errSet != nil {
+				return errSet //nolint:wrapcheck
+			}
+		default:
+			return fmt.Errorf("invalid output format: %s", output)
+		}
+	}
+
+	if errKey := steamid.SetKey(apiKey(cmd)); errKey != nil {
+		return errKey //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// apiKey returns the steam web api key set by the --api-key flag, environment or configuration file.
+func apiKey(cmd *cobra.Command) string {
+	return cmd.Flag("api-key").Value.String()
+}
+
+func init() {
+	rootCmd.PersistentFlags().String("config", "", "Configuration file (default ~/.config/steamid/config.yaml)")
+	rootCmd.PersistentFlags().String("api-key", "", "Steam web api key (env STEAM_TOKEN)")
+	rootCmd.PersistentPreRunE = applyConfig
+}
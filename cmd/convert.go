@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -23,21 +25,105 @@ Steam64:      %d
 %s`, sid.Steam(false), sid.Steam3(), sid.AccountID, sid.Int64(), suffix) //nolint:forbidigo
 }
 
+// writeConversionLines writes each conversion as a json object on its own line, for convert
+// --format json. The objects are the same as the elements of the --json array.
+func writeConversionLines(writer io.Writer, conversions []conversion) error {
+	encoder := json.NewEncoder(writer)
+
+	for _, converted := range conversions {
+		if errEncode := encoder.Encode(converted); errEncode != nil {
+			return errEncode //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// convertInputs converts the inputs to steam ids, in the same order. With --resolve, the inputs
+// that are not steam ids are resolved as vanity names or profile urls, otherwise they are left invalid.
+func convertInputs(cmd *cobra.Command, inputs []string) []steamid.SteamID {
+	var (
+		sids    = make([]steamid.SteamID, len(inputs))
+		missing []string
+		indexes []int
+	)
+
+	for idx, input := range inputs {
+		sids[idx] = steamid.New(input)
+		if !sids[idx].Valid() {
+			missing = append(missing, input)
+			indexes = append(indexes, idx)
+		}
+	}
+
+	if len(missing) == 0 || !cmd.Flag("resolve").Changed {
+		return sids
+	}
+
+	cache := mustOpenCache(cmd)
+	results := cache.resolveAll(cmd.Context(), missing, 4)
+
+	saveCache(cache)
+
+	for idx, result := range results {
+		if result.Err != nil {
+			fatalf(cmd, errorCode(result.Err, exitResolve), "Failed to resolve %s: %v", result.Query, result.Err)
+		}
+
+		sids[indexes[idx]] = result.SteamID
+	}
+
+	return sids
+}
+
 // convertCmd parses and prints out the steam id formats for the input steamid.
 var convertCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
-	Use:     "convert",
+	Use:     "convert [id...]",
 	Aliases: []string{"c"},
-	Args:    cobra.MinimumNArgs(1),
 	Short:   "Show steamid conversions",
 	Long: `Show steamid conversions.
 
-All formats are parsed from the file and duplicates are removed`,
+All formats are parsed from the file and duplicates are removed. When no ids are given, or the
+only argument is -, one id is read per line from stdin. With --format json, each id is
+written on its own line as the same json object as the elements of the --json array.
+
+With --resolve, inputs that are not steam ids are resolved as vanity names or profile urls,
+which requires a steam web api key for vanity names.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		for _, arg := range args {
-			sid := steamid.New(arg)
+		if len(args) == 0 || (len(args) == 1 && args[0] == "-") {
+			lines, errRead := readQueries()
+			if errRead != nil {
+				fatalf(cmd, exitFailure, "Failed to read stdin: %v", errRead)
+			}
+
+			args = lines
+		}
+
+		sids := convertInputs(cmd, args)
+
+		if outputFormat(cmd) != outputText {
+			conversions := make([]conversion, 0, len(args))
+
+			for idx, arg := range args {
+				sid := sids[idx]
+				if !sid.Valid() {
+					fatalf(cmd, exitParse, "Failed to convert id, use --resolve for vanity names and profile urls: %s", arg)
+				}
+
+				conversions = append(conversions, newConversion(arg, sid))
+			}
+
+			if err := writeRecords(cmd, os.Stdout, conversions); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+
+			os.Exit(0)
+		}
+
+		for idx, arg := range args {
+			sid := sids[idx]
 			if !sid.Valid() {
-				fmt.Printf("Failed to convert id: %s\n", arg) //nolint:forbidigo
-				os.Exit(1)
+				fatalf(cmd, exitParse, "Failed to convert id, use --resolve for vanity names and profile urls: %s", arg)
 			}
 
 			verbose := false
@@ -64,9 +150,12 @@ All formats are parsed from the file and duplicates are removed`,
 				fmt.Printf("%d\n", sid.AccountID) //nolint:forbidigo
 			case "steam64":
 				fmt.Printf("%d\n", sid.Int64()) //nolint:forbidigo
+			case "json":
+				if errWrite := writeConversionLines(os.Stdout, []conversion{newConversion(arg, sid)}); errWrite != nil {
+					fatalf(cmd, exitFailure, "Failed to write output: %v", errWrite)
+				}
 			default:
-				fmt.Printf("Unknown forma, must be one of steam, steam3, steam32, steam64: %s", idType) //nolint:forbidigo
-				os.Exit(1)
+				fatalf(cmd, exitConfig, "Unknown format, must be one of steam, steam3, steam32, steam64, json: %s", idType)
 			}
 
 		}
@@ -77,6 +166,8 @@ All formats are parsed from the file and duplicates are removed`,
 func init() {
 	rootCmd.AddCommand(convertCmd)
 	convertCmd.Flags().BoolP("verbose", "v", false, "Show verbose steam details")
+	convertCmd.Flags().BoolP("resolve", "r", false, "Resolve inputs that are not steam ids as vanity names or profile urls")
 	convertCmd.Flags().StringP("format", "f", "",
-		"Output format to use. Applied to each ID. (steam, steam3, steam32, steam64)")
+		"Output format to use. Applied to each ID. (steam, steam3, steam32, steam64, json)")
+	completeFlag(convertCmd, "format", append(idTypes, "json")...)
 }
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals
+var update = flag.Bool("update", false, "Update the golden files in testdata")
+
+// requireGolden compares the output with testdata/name, rewriting the file instead with -update.
+func requireGolden(t *testing.T, name string, output []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, output, 0o600))
+	}
+
+	golden, errRead := os.ReadFile(path)
+	require.NoError(t, errRead)
+	require.Equal(t, string(golden), string(output))
+}
+
+func TestConvertJSON(t *testing.T) {
+	t.Parallel()
+
+	var conversions []conversion
+
+	for _, input := range []string{"76561197960287930", "[U:1:22202]", "STEAM_0:1:4491990", "[g:1:4]"} {
+		conversions = append(conversions, newConversion(input, steamid.New(input)))
+	}
+
+	cmd := &cobra.Command{} //nolint:exhaustruct
+	cmd.Flags().Bool(outputJSON, false, "")
+	require.NoError(t, cmd.Flags().Set(outputJSON, "true"))
+
+	var array bytes.Buffer
+
+	require.NoError(t, writeRecords(cmd, &array, conversions))
+	requireGolden(t, "convert_json.golden", array.Bytes())
+
+	var lines bytes.Buffer
+
+	require.NoError(t, writeConversionLines(&lines, conversions))
+	requireGolden(t, "convert_format_json.golden", lines.Bytes())
+
+	// Both outputs must decode to the same objects, with the same keys.
+	var arrayObjects []map[string]any
+
+	require.NoError(t, json.Unmarshal(array.Bytes(), &arrayObjects))
+
+	decoder := json.NewDecoder(&lines)
+
+	for _, expected := range arrayObjects {
+		var object map[string]any
+
+		require.NoError(t, decoder.Decode(&object))
+		require.Equal(t, expected, object)
+	}
+
+	require.False(t, decoder.More())
+}
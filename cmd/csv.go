@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// idTypes are the steam id formats accepted by the --type style flags.
+var idTypes = []string{"steam", "steam3", "steam32", "steam64"} //nolint:gochecknoglobals
+
+// csvColumnIndex returns the 0-indexed column from a 1-indexed column number or a header name.
+func csvColumnIndex(column string, header []string) (int, bool) {
+	if number, errNumber := strconv.Atoi(column); errNumber == nil {
+		return number - 1, number > 0
+	}
+
+	for idx, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return idx, true
+		}
+	}
+
+	return 0, false
+}
+
+// csvCmd normalizes the id column of a csv file and appends the other id formats.
+var csvCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "csv",
+	Args:  cobra.NoArgs,
+	Short: "Normalize the steam id column of a csv file and add columns of other id formats",
+	Long: `Normalize the steam id column of a csv file and add columns of other id formats.
+
+The id column is given by its 1-indexed number or its header name. Ids in any format are rewritten
+in the --normalize format and a column is appended for each of the --add-columns formats. Rows
+with ids that can not be parsed are written unchanged with empty added columns.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		var (
+			inPath              = cmd.Flag("in").Value.String()
+			outPath             = cmd.Flag("out").Value.String()
+			column              = cmd.Flag("id-column").Value.String()
+			normalize           = strings.ToLower(cmd.Flag("normalize").Value.String())
+			noHeader            = cmd.Flag("no-header").Changed
+			reader    io.Reader = os.Stdin
+			writer    io.Writer = os.Stdout
+		)
+
+		addColumns, _ := cmd.Flags().GetStringSlice("add-columns")
+
+		for _, idType := range append(slices.Clone(addColumns), normalize) {
+			if idType != "" && !slices.Contains(idTypes, idType) {
+				fatalf(cmd, exitConfig, "Unknown type, must be one of steam, steam3, steam32, steam64: %s", idType)
+			}
+		}
+
+		if inPath != "" && inPath != "-" {
+			inFile, errOpen := os.Open(inPath)
+			if errOpen != nil {
+				fatalf(cmd, exitFailure, "Failed to open input file (%s): %v", inPath, errOpen)
+			}
+
+			defer func() {
+				_ = inFile.Close()
+			}()
+
+			reader = inFile
+		}
+
+		if outPath != "" && outPath != "-" {
+			outFile, errCreate := os.Create(outPath)
+			if errCreate != nil {
+				fatalf(cmd, exitFailure, "Failed to create output file (%s): %v", outPath, errCreate)
+			}
+
+			defer func() {
+				if err := outFile.Close(); err != nil {
+					log.Printf("Failed to close output file")
+				}
+			}()
+
+			writer = outFile
+		}
+
+		var (
+			csvReader = csv.NewReader(reader)
+			csvWriter = csv.NewWriter(writer)
+			index     = -1
+			invalid   int
+		)
+
+		csvReader.FieldsPerRecord = -1
+
+		for row := 0; ; row++ {
+			record, errRead := csvReader.Read()
+			if errors.Is(errRead, io.EOF) {
+				break
+			}
+
+			if errRead != nil {
+				fatalf(cmd, exitParse, "Failed to read csv: %v", errRead)
+			}
+
+			if index < 0 {
+				found := false
+				if index, found = csvColumnIndex(column, record); !found {
+					fatalf(cmd, exitConfig, "Unknown id column: %s", column)
+				}
+
+				if !noHeader {
+					for _, idType := range addColumns {
+						record = append(record, idType)
+					}
+
+					if errWrite := csvWriter.Write(record); errWrite != nil {
+						fatalf(cmd, exitFailure, "Failed to write output: %v", errWrite)
+					}
+
+					continue
+				}
+			}
+
+			var sid steamid.SteamID
+			if index < len(record) {
+				sid = steamid.New(strings.TrimSpace(record[index]))
+			}
+
+			for _, idType := range addColumns {
+				value := ""
+				if sid.Valid() {
+					value = formatID(sid, idType)
+				}
+
+				record = append(record, value)
+			}
+
+			if !sid.Valid() {
+				invalid++
+
+				log.Printf("Invalid steam id on row %d", row+1)
+			} else if normalize != "" {
+				record[index] = formatID(sid, normalize)
+			}
+
+			if errWrite := csvWriter.Write(record); errWrite != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", errWrite)
+			}
+		}
+
+		csvWriter.Flush()
+
+		if errFlush := csvWriter.Error(); errFlush != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", errFlush)
+		}
+
+		if invalid > 0 {
+			log.Printf("Left %d rows with invalid steam ids unchanged", invalid)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(csvCmd)
+	csvCmd.Flags().String("in", "", "Input csv file. Uses stdin if not specified.")
+	csvCmd.Flags().String("out", "", "Output csv file. Uses stdout if not specified.")
+	csvCmd.Flags().String("id-column", "1", "Column of the steam ids, as a 1-indexed number or header name")
+	csvCmd.Flags().StringSlice("add-columns", nil, "Id formats to append as new columns (steam, steam3, steam32, steam64)")
+	csvCmd.Flags().String("normalize", "steam64", "Format to rewrite the id column in, empty to keep the original value")
+	csvCmd.Flags().Bool("no-header", false, "The csv file has no header row")
+	completeFlag(csvCmd, "add-columns", idTypes...)
+	completeFlag(csvCmd, "normalize", idTypes...)
+}
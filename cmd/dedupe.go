@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// dedupeRun is a sorted, de-duplicated run of steam64 ids written to a temp file.
+type dedupeRun struct {
+	file   *os.File
+	reader *bufio.Reader
+	head   uint64
+}
+
+// next reads the next id of the run, returning io.EOF once the run is exhausted.
+func (r *dedupeRun) next() error {
+	var buf [8]byte
+	if _, errRead := io.ReadFull(r.reader, buf[:]); errRead != nil {
+		if errors.Is(errRead, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("truncated temp file %s: %w", r.file.Name(), errRead)
+		}
+
+		return errRead //nolint:wrapcheck
+	}
+
+	r.head = binary.LittleEndian.Uint64(buf[:])
+
+	return nil
+}
+
+// dedupeHeap orders the runs by their current id.
+type dedupeHeap []*dedupeRun
+
+func (h dedupeHeap) Len() int           { return len(h) }
+func (h dedupeHeap) Less(i, j int) bool { return h[i].head < h[j].head }
+func (h dedupeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *dedupeHeap) Push(value any) {
+	*h = append(*h, value.(*dedupeRun)) //nolint:forcetypeassert
+}
+
+func (h *dedupeHeap) Pop() any {
+	old := *h
+	run := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return run
+}
+
+// sortUnique sorts the ids and removes duplicates in place.
+func sortUnique(ids []uint64) []uint64 {
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+// writeRun writes the sorted ids to a new temp file in dir.
+func writeRun(dir string, ids []uint64) (*dedupeRun, error) {
+	file, errCreate := os.CreateTemp(dir, "steamid-dedupe-*")
+	if errCreate != nil {
+		return nil, errCreate //nolint:wrapcheck
+	}
+
+	writer := bufio.NewWriter(file)
+
+	var buf [8]byte
+
+	for _, sid := range ids {
+		binary.LittleEndian.PutUint64(buf[:], sid)
+
+		if _, errWrite := writer.Write(buf[:]); errWrite != nil {
+			return &dedupeRun{file: file}, errWrite //nolint:wrapcheck
+		}
+	}
+
+	if errFlush := writer.Flush(); errFlush != nil {
+		return &dedupeRun{file: file}, errFlush //nolint:wrapcheck
+	}
+
+	if _, errSeek := file.Seek(0, io.SeekStart); errSeek != nil {
+		return &dedupeRun{file: file}, errSeek //nolint:wrapcheck
+	}
+
+	return &dedupeRun{file: file, reader: bufio.NewReader(file)}, nil
+}
+
+// mergeRuns merges the sorted runs, calling emit once for each unique id in ascending order.
+func mergeRuns(runs []*dedupeRun, emit func(uint64) error) error {
+	var queue dedupeHeap
+
+	for _, run := range runs {
+		errNext := run.next()
+		if errors.Is(errNext, io.EOF) {
+			continue
+		} else if errNext != nil {
+			return errNext
+		}
+
+		queue = append(queue, run)
+	}
+
+	heap.Init(&queue)
+
+	var (
+		last    uint64
+		emitted bool
+	)
+
+	for queue.Len() > 0 {
+		run := queue[0]
+
+		if !emitted || run.head != last {
+			if errEmit := emit(run.head); errEmit != nil {
+				return errEmit
+			}
+
+			last, emitted = run.head, true
+		}
+
+		errNext := run.next()
+		if errors.Is(errNext, io.EOF) {
+			heap.Pop(&queue)
+
+			continue
+		} else if errNext != nil {
+			return errNext
+		}
+
+		heap.Fix(&queue, 0)
+	}
+
+	return nil
+}
+
+// dedupeIDs reads one steam id per line from the reader and writes the unique ids to the writer in
+// ascending order, in the idType format. Ids are sorted in chunks of chunkSize, which are written to
+// temp files in tmpDir and merged once there is more than one. The temp files are removed before
+// returning. The number of lines skipped as they are not steam ids is returned.
+func dedupeIDs(reader io.Reader, writer io.Writer, idType string, chunkSize int, tmpDir string) (int, error) {
+	var (
+		scanner = bufio.NewScanner(reader)
+		chunk   = make([]uint64, 0, min(chunkSize, 1<<20))
+		runs    []*dedupeRun
+		invalid int
+	)
+
+	defer func() {
+		for _, run := range runs {
+			_ = run.file.Close()
+			_ = os.Remove(run.file.Name())
+		}
+	}()
+
+	flushChunk := func() error {
+		run, errRun := writeRun(tmpDir, sortUnique(chunk))
+		if run != nil {
+			runs = append(runs, run)
+		}
+
+		chunk = chunk[:0]
+
+		if errRun != nil {
+			return fmt.Errorf("failed to write temp file: %w", errRun)
+		}
+
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		sid := steamid.New(line)
+		if !sid.Valid() {
+			invalid++
+
+			continue
+		}
+
+		chunk = append(chunk, sid.Uint64())
+
+		if len(chunk) >= chunkSize {
+			if errFlush := flushChunk(); errFlush != nil {
+				return invalid, errFlush
+			}
+		}
+	}
+
+	if errScan := scanner.Err(); errScan != nil {
+		return invalid, fmt.Errorf("failed to read input: %w", errScan)
+	}
+
+	buffered := bufio.NewWriter(writer)
+	emit := func(value uint64) error {
+		_, errWrite := fmt.Fprintln(buffered, formatID(steamid.New(value), idType))
+
+		return errWrite //nolint:wrapcheck
+	}
+
+	var errMerge error
+
+	if len(runs) == 0 {
+		// Small inputs that fit in a single chunk are never written to disk.
+		for _, value := range sortUnique(chunk) {
+			if errMerge = emit(value); errMerge != nil {
+				break
+			}
+		}
+	} else {
+		if len(chunk) > 0 {
+			if errFlush := flushChunk(); errFlush != nil {
+				return invalid, errFlush
+			}
+		}
+
+		errMerge = mergeRuns(runs, emit)
+	}
+
+	if errMerge == nil {
+		errMerge = buffered.Flush()
+	}
+
+	if errMerge != nil {
+		return invalid, fmt.Errorf("failed to write output: %w", errMerge)
+	}
+
+	return invalid, nil
+}
+
+// dedupeCmd sorts and de-duplicates large steam id lists using an external merge sort.
+var dedupeCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "dedupe <file | ->",
+	Args:  cobra.ExactArgs(1),
+	Short: "Sort and de-duplicate a list of steam ids of any size",
+	Long: `Sort and de-duplicate a list of steam ids of any size.
+
+The input has one steam id per line, in any format. Ids are sorted in chunks of --chunk-size,
+which are written to temp files and merged, so memory use does not depend on the size of the
+input. The unique ids are written in ascending order in the --type format. Lines that are not
+steam ids are skipped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			idType            = strings.ToLower(cmd.Flag("type").Value.String())
+			outPath           = cmd.Flag("output").Value.String()
+			tmpDir            = cmd.Flag("tmp-dir").Value.String()
+			reader  io.Reader = os.Stdin
+			writer  io.Writer = os.Stdout
+		)
+
+		chunkSize, _ := cmd.Flags().GetInt("chunk-size")
+
+		if !slices.Contains(idTypes, idType) {
+			fatalf(cmd, exitConfig, "Unknown type, must be one of steam, steam3, steam32, steam64: %s", idType)
+		}
+
+		if chunkSize <= 0 {
+			fatalf(cmd, exitConfig, "Chunk size must be greater than 0")
+		}
+
+		if args[0] != "-" {
+			inFile, errOpen := os.Open(args[0])
+			if errOpen != nil {
+				fatalf(cmd, exitFailure, "Failed to open input file (%s): %v", args[0], errOpen)
+			}
+
+			defer func() {
+				_ = inFile.Close()
+			}()
+
+			reader = inFile
+		}
+
+		if outPath != "" {
+			outFile, errCreate := os.Create(outPath)
+			if errCreate != nil {
+				fatalf(cmd, exitFailure, "Failed to create output file (%s): %v", outPath, errCreate)
+			}
+
+			defer func() {
+				if err := outFile.Close(); err != nil {
+					log.Printf("Failed to close output file")
+				}
+			}()
+
+			writer = outFile
+		}
+
+		invalid, errDedupe := dedupeIDs(reader, writer, idType, chunkSize, tmpDir)
+		if errDedupe != nil {
+			fatalf(cmd, exitFailure, "Failed to dedupe ids: %v", errDedupe)
+		}
+
+		if invalid > 0 {
+			log.Printf("Skipped %d lines that are not steam ids", invalid)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dedupeCmd)
+	dedupeCmd.Flags().StringP("type", "t", "steam64", "Output format for steam ids (steam64, steam, steam3, steam32)")
+	completeFlag(dedupeCmd, "type", idTypes...)
+	dedupeCmd.Flags().StringP("output", "o", "", "Output file. Uses stdout if not specified.")
+	dedupeCmd.Flags().Int("chunk-size", 4_000_000, "Number of ids sorted in memory at a time")
+	dedupeCmd.Flags().String("tmp-dir", "", "Directory for the temp files. Uses the system temp dir if not specified.")
+}
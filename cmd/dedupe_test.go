@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireEmptyDir checks that every temp file written to dir was removed.
+func requireEmptyDir(t *testing.T, dir string) {
+	t.Helper()
+
+	entries, errRead := os.ReadDir(dir)
+	require.NoError(t, errRead)
+	require.Empty(t, entries)
+}
+
+func TestMergeRuns(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir  = t.TempDir()
+		runs []*dedupeRun
+	)
+
+	for _, ids := range [][]uint64{{5, 1, 3, 3}, {2, 3, 9}, {}, {9, 1, 7}} {
+		run, errRun := writeRun(dir, sortUnique(ids))
+		require.NoError(t, errRun)
+
+		runs = append(runs, run)
+	}
+
+	entries, errRead := os.ReadDir(dir)
+	require.NoError(t, errRead)
+	require.Len(t, entries, 4)
+
+	var merged []uint64
+
+	require.NoError(t, mergeRuns(runs, func(value uint64) error {
+		merged = append(merged, value)
+
+		return nil
+	}))
+	require.Equal(t, []uint64{1, 2, 3, 5, 7, 9}, merged)
+
+	for _, run := range runs {
+		require.NoError(t, run.file.Close())
+	}
+
+	// A truncated run is reported rather than merged.
+	truncated, errTruncated := os.CreateTemp(dir, "truncated-*")
+	require.NoError(t, errTruncated)
+
+	t.Cleanup(func() { _ = truncated.Close() })
+
+	_, errWrite := truncated.Write([]byte{1, 2, 3})
+	require.NoError(t, errWrite)
+
+	_, errSeek := truncated.Seek(0, io.SeekStart)
+	require.NoError(t, errSeek)
+
+	broken := &dedupeRun{file: truncated, reader: bufio.NewReader(truncated)}
+	require.ErrorContains(t, mergeRuns([]*dedupeRun{broken}, func(uint64) error { return nil }), "truncated temp file")
+}
+
+func TestDedupeIDs(t *testing.T) {
+	t.Parallel()
+
+	// The duplicates of each id are spread across different chunks.
+	input := strings.Join([]string{
+		"76561197960287930", "[U:1:8983981]", "not an id",
+		"", "STEAM_0:0:11101", "76561197969249709", "[U:1:1]",
+		"[U:1:22202]", "STEAM_0:1:4491990", "76561197960265729", "[U:1:2]",
+	}, "\n")
+	expected := "76561197960265729\n76561197960265730\n76561197960287930\n76561197969249709\n"
+
+	for _, chunkSize := range []int{1, 2, 3, 100} {
+		var (
+			dir    = t.TempDir()
+			output bytes.Buffer
+		)
+
+		invalid, errDedupe := dedupeIDs(strings.NewReader(input), &output, "steam64", chunkSize, dir)
+		require.NoError(t, errDedupe, chunkSize)
+		require.Equal(t, 1, invalid)
+		require.Equal(t, expected, output.String(), chunkSize)
+		requireEmptyDir(t, dir)
+	}
+
+	var output bytes.Buffer
+
+	_, errDedupe := dedupeIDs(strings.NewReader(input), &output, "steam3", 2, t.TempDir())
+	require.NoError(t, errDedupe)
+	require.Equal(t, "[U:1:1]\n[U:1:2]\n[U:1:22202]\n[U:1:8983981]\n", output.String())
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full") //nolint:err113
+}
+
+func TestDedupeIDsRemovesTempFilesOnError(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	input := strings.Repeat("76561197960287930\n[U:1:1]\n[U:1:2]\n", 10)
+
+	_, errDedupe := dedupeIDs(strings.NewReader(input), failingWriter{}, "steam64", 2, dir)
+	require.ErrorContains(t, errDedupe, "disk full")
+	requireEmptyDir(t, dir)
+
+	_, errMissing := dedupeIDs(strings.NewReader(input), &bytes.Buffer{}, "steam64", 2, dir+"/missing")
+	require.ErrorContains(t, errMissing, "failed to write temp file")
+	requireEmptyDir(t, dir)
+}
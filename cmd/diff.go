@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// diffRecord is a steam id added or removed between two id lists.
+type diffRecord struct {
+	conversion
+	// Change is either added or removed.
+	Change string `json:"change"`
+}
+
+func (r diffRecord) columns() []string {
+	return append([]string{"change"}, r.conversion.columns()...)
+}
+
+func (r diffRecord) values() []string {
+	return append([]string{r.Change}, r.conversion.values()...)
+}
+
+// diffOutput is the json output of the diff command.
+type diffOutput struct {
+	Added   []conversion `json:"added"`
+	Removed []conversion `json:"removed"`
+}
+
+// idList is the unique steam ids found in an id list, along with the text each was first written as.
+type idList struct {
+	ids   steamid.Collection
+	texts map[uint64]string
+}
+
+// scanIDList finds the steam ids in any format in the reader.
+func scanIDList(reader io.Reader) (idList, error) {
+	matches, errScan := extra.FindReaderSteamIDMatches(reader)
+
+	list := idList{texts: map[uint64]string{}}
+
+	for _, match := range matches {
+		if _, found := list.texts[match.SteamID.Uint64()]; found {
+			continue
+		}
+
+		list.texts[match.SteamID.Uint64()] = match.Text
+		list.ids = append(list.ids, match.SteamID)
+	}
+
+	return list, errScan //nolint:wrapcheck
+}
+
+// conversion returns the conversion of an id of the list, with the text it was written as for input.
+func (l idList) conversion(sid steamid.SteamID) conversion {
+	return newConversion(l.texts[sid.Uint64()], sid)
+}
+
+// readIDList reads the unique steam ids in any format from the file.
+func readIDList(cmd *cobra.Command, path string) idList {
+	file, errOpen := os.Open(path)
+	if errOpen != nil {
+		fatalf(cmd, exitFailure, "Failed to open input file (%s): %v", path, errOpen)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	list, errScan := scanIDList(file)
+	if errScan != nil {
+		fatalf(cmd, exitFailure, "Failed to read input file (%s): %v", path, errScan)
+	}
+
+	return list
+}
+
+// diffCmd compares two id lists.
+var diffCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "diff <old> <new>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Show the steam ids added and removed between two id lists",
+	Long: `Show the steam ids added and removed between two id lists.
+
+Ids are found in any format in both files, so an id changing format is not reported. Added ids
+are prefixed with + and removed ids with -, followed by a summary on stderr. With --json, --csv,
+--tsv or --template, the input of each id is the text it was first written as, taken from the new
+list for added ids and from the old list for removed ids.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		idType := strings.ToLower(cmd.Flag("type").Value.String())
+		if !slices.Contains(idTypes, idType) {
+			fatalf(cmd, exitConfig, "Unknown type, must be one of steam, steam3, steam32, steam64: %s", idType)
+		}
+
+		var (
+			oldList        = readIDList(cmd, args[0])
+			newList        = readIDList(cmd, args[1])
+			added, removed = oldList.ids.Diff(newList.ids)
+		)
+
+		switch outputFormat(cmd) {
+		case outputText:
+			writer := bufio.NewWriter(os.Stdout)
+
+			for _, sid := range added {
+				_, _ = fmt.Fprintf(writer, "+ %s\n", formatID(sid, idType))
+			}
+
+			for _, sid := range removed {
+				_, _ = fmt.Fprintf(writer, "- %s\n", formatID(sid, idType))
+			}
+
+			if err := writer.Flush(); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+
+			log.Printf("%d added, %d removed", len(added), len(removed))
+		case outputJSON:
+			output := diffOutput{Added: []conversion{}, Removed: []conversion{}}
+
+			for _, sid := range added {
+				output.Added = append(output.Added, newList.conversion(sid))
+			}
+
+			for _, sid := range removed {
+				output.Removed = append(output.Removed, oldList.conversion(sid))
+			}
+
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+
+			if err := encoder.Encode(output); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+		default:
+			records := make([]diffRecord, 0, len(added)+len(removed))
+
+			for _, sid := range added {
+				records = append(records, diffRecord{conversion: newList.conversion(sid), Change: "added"})
+			}
+
+			for _, sid := range removed {
+				records = append(records, diffRecord{conversion: oldList.conversion(sid), Change: "removed"})
+			}
+
+			if err := writeRecords(cmd, os.Stdout, records); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringP("type", "t", "steam64", "Output format for steam ids (steam64, steam, steam3, steam32)")
+	completeFlag(diffCmd, "type", idTypes...)
+}
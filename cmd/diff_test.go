@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffInput(t *testing.T) {
+	t.Parallel()
+
+	oldList, errOld := scanIDList(strings.NewReader("STEAM_0:0:11101\n[U:1:1] 76561197960265729\n"))
+	require.NoError(t, errOld)
+
+	newList, errNew := scanIDList(strings.NewReader("banned 76561197960287930\n[U:1:2]\n"))
+	require.NoError(t, errNew)
+
+	added, removed := oldList.ids.Diff(newList.ids)
+	require.Equal(t, steamid.Collection{steamid.New("[U:1:2]")}, added)
+	require.Equal(t, steamid.Collection{steamid.New("[U:1:1]")}, removed)
+
+	// The input is the text the id was first written as in its list.
+	require.Equal(t, newConversion("[U:1:2]", added[0]), newList.conversion(added[0]))
+	require.Equal(t, newConversion("[U:1:1]", removed[0]), oldList.conversion(removed[0]))
+	require.Equal(t, "STEAM_0:0:11101", oldList.conversion(steamid.New(76561197960287930)).Input)
+	require.Equal(t, "76561197960287930", newList.conversion(steamid.New(76561197960287930)).Input)
+}
@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// enrichField is a column of the enrich report. Value receives the zero summary or ban state
+// when the api returned none for the id.
+type enrichField struct {
+	name  string
+	bans  bool
+	value func(summary steamid.PlayerSummary, ban steamid.PlayerBanState) any
+}
+
+// enrichFields are the columns available to --fields.
+var enrichFields = []enrichField{ //nolint:gochecknoglobals
+	{name: "persona", value: func(s steamid.PlayerSummary, _ steamid.PlayerBanState) any { return s.PersonaName }},
+	{name: "realname", value: func(s steamid.PlayerSummary, _ steamid.PlayerBanState) any { return s.RealName }},
+	{name: "profile", value: func(s steamid.PlayerSummary, _ steamid.PlayerBanState) any { return s.ProfileURL }},
+	{name: "avatar", value: func(s steamid.PlayerSummary, _ steamid.PlayerBanState) any { return s.AvatarFull }},
+	{name: "country", value: func(s steamid.PlayerSummary, _ steamid.PlayerBanState) any { return s.LocCountryCode }},
+	{name: "visibility", value: func(s steamid.PlayerSummary, _ steamid.PlayerBanState) any {
+		return s.CommunityVisibilityState
+	}},
+	{name: "created", value: func(s steamid.PlayerSummary, _ steamid.PlayerBanState) any {
+		if created := s.Created(); !created.IsZero() {
+			return created.UTC().Format(time.RFC3339)
+		}
+
+		return ""
+	}},
+	{name: "vac", bans: true, value: func(_ steamid.PlayerSummary, b steamid.PlayerBanState) any { return b.VACBanned }},
+	{name: "vac_bans", bans: true, value: func(_ steamid.PlayerSummary, b steamid.PlayerBanState) any {
+		return b.NumberOfVACBans
+	}},
+	{name: "game_bans", bans: true, value: func(_ steamid.PlayerSummary, b steamid.PlayerBanState) any {
+		return b.NumberOfGameBans
+	}},
+	{name: "days_since_ban", bans: true, value: func(_ steamid.PlayerSummary, b steamid.PlayerBanState) any {
+		return b.DaysSinceLastBan
+	}},
+	{name: "community", bans: true, value: func(_ steamid.PlayerSummary, b steamid.PlayerBanState) any {
+		return b.CommunityBanned
+	}},
+	{name: "economy", bans: true, value: func(_ steamid.PlayerSummary, b steamid.PlayerBanState) any { return b.EconomyBan }},
+}
+
+// enrichFieldNames returns the names of all the enrich fields.
+func enrichFieldNames() []string {
+	names := make([]string, 0, len(enrichFields))
+	for _, field := range enrichFields {
+		names = append(names, field.name)
+	}
+
+	return names
+}
+
+// selectEnrichFields returns the enrich fields in the order they were named, exiting on unknown names.
+func selectEnrichFields(cmd *cobra.Command, names []string) []enrichField {
+	selected := make([]enrichField, 0, len(names))
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		idx := slices.IndexFunc(enrichFields, func(field enrichField) bool { return field.name == name })
+		if idx < 0 {
+			fatalf(cmd, exitConfig, "Unknown field, must be one of %s: %s", strings.Join(enrichFieldNames(), ", "), name)
+		}
+
+		selected = append(selected, enrichFields[idx])
+	}
+
+	return selected
+}
+
+// readEnrichInput reads the ids from the --in file, or stdin when it is empty or -.
+func readEnrichInput(cmd *cobra.Command) steamid.Collection {
+	inPath := cmd.Flag("in").Value.String()
+	if inPath != "" && inPath != "-" {
+		return readIDList(cmd, inPath).ids
+	}
+
+	ids, errScan := extra.ScanReaderSteamIDs(os.Stdin)
+	if errScan != nil {
+		fatalf(cmd, exitFailure, "Failed to read input: %v", errScan)
+	}
+
+	return ids
+}
+
+// writeEnrichReport writes one row per id with its steam64 followed by the selected fields. JSON
+// output is an array of objects, otherwise csv or, with --tsv, tab separated values.
+func writeEnrichReport(cmd *cobra.Command, writer io.Writer, ids steamid.Collection, fields []enrichField,
+	summaries map[steamid.SteamID]steamid.PlayerSummary, bans map[steamid.SteamID]steamid.PlayerBanState,
+) error {
+	if outputFormat(cmd) == outputJSON {
+		rows := make([]map[string]any, 0, len(ids))
+
+		for _, sid := range ids {
+			row := map[string]any{"steam64": sid.String()}
+			for _, field := range fields {
+				row[field.name] = field.value(summaries[sid], bans[sid])
+			}
+
+			rows = append(rows, row)
+		}
+
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(rows) //nolint:wrapcheck
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	if outputFormat(cmd) == outputTSV {
+		csvWriter.Comma = '\t'
+	}
+
+	header := []string{"steam64"}
+	for _, field := range fields {
+		header = append(header, field.name)
+	}
+
+	if errWrite := csvWriter.Write(header); errWrite != nil {
+		return errWrite //nolint:wrapcheck
+	}
+
+	for _, sid := range ids {
+		row := []string{sid.String()}
+		for _, field := range fields {
+			row = append(row, fmt.Sprint(field.value(summaries[sid], bans[sid])))
+		}
+
+		if errWrite := csvWriter.Write(row); errWrite != nil {
+			return errWrite //nolint:wrapcheck
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error() //nolint:wrapcheck
+}
+
+// enrichCmd builds a report of profile and ban details for a list of steam ids.
+var enrichCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "enrich",
+	Args:  cobra.NoArgs,
+	Short: "Build a report of profile and ban details for a list of steam ids",
+	Long: `Build a report of profile and ban details for a list of steam ids.
+
+Ids are found in any format in the --in file, or stdin, and each unique individual account
+becomes a row, in input order, holding its steam64 followed by the --fields columns. Results
+are fetched in batches of 100, cached on disk and requests are limited to --rate per second
+and --max-requests in total. Progress is reported on stderr unless --no-progress is set.
+
+The report is written as csv unless --json or --tsv is set, to stdout or the --output file.
+With --output sqlite:path, the ids, summaries and bans tables of a sqlite database are
+written instead, keyed by steam64. A steam web api key must be set using the STEAM_TOKEN
+environment variable.
+
+Fields: persona, realname, profile, avatar, country, visibility, created, vac, vac_bans,
+game_bans, days_since_ban, community, economy`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		names, _ := cmd.Flags().GetStringSlice("fields")
+		fields := selectEnrichFields(cmd, names)
+
+		var ids steamid.Collection
+
+		for _, sid := range readEnrichInput(cmd) {
+			if sid.AccountType == steamid.AccountTypeIndividual {
+				ids = append(ids, sid)
+			}
+		}
+
+		client, stop := newBatchClient(cmd)
+		defer stop()
+
+		dbPath, isSQLite := sqliteOutputPath(cmd)
+
+		var (
+			// The sqlite output always holds both the summaries and bans tables.
+			needSummaries = isSQLite || slices.ContainsFunc(fields, func(field enrichField) bool { return !field.bans })
+			needBans      = isSQLite || slices.ContainsFunc(fields, func(field enrichField) bool { return field.bans })
+			lookups       = 0
+			summaryList   []steamid.PlayerSummary
+			banList       []steamid.PlayerBanState
+			errFetch      error
+			summaries     = map[steamid.SteamID]steamid.PlayerSummary{}
+			bans          = map[steamid.SteamID]steamid.PlayerBanState{}
+		)
+
+		for _, needed := range []bool{needSummaries, needBans} {
+			if needed {
+				lookups += len(ids)
+			}
+		}
+
+		cache := mustOpenCache(cmd)
+		cache.client = client
+		cache.progress = newProgress(cmd, "enrich", "lookups", lookups)
+
+		if needSummaries {
+			summaryList, errFetch = cache.playerSummaries(cmd.Context(), ids)
+			if errFetch != nil {
+				saveCache(cache)
+				fatalf(cmd, errorCode(errFetch, exitNetwork), "Failed to fetch summaries: %v", errFetch)
+			}
+
+			for _, summary := range summaryList {
+				summaries[summary.SteamID] = summary
+			}
+		}
+
+		if needBans {
+			banList, errFetch = cache.playerBans(cmd.Context(), ids)
+			if errFetch != nil {
+				saveCache(cache)
+				fatalf(cmd, errorCode(errFetch, exitNetwork), "Failed to fetch bans: %v", errFetch)
+			}
+
+			for _, ban := range banList {
+				bans[ban.SteamID] = ban
+			}
+		}
+
+		cache.progress.finish()
+		saveCache(cache)
+
+		if isSQLite {
+			writeSQLite(cmd, dbPath, func(out *sqliteOutput) error {
+				conversions := make([]conversion, 0, len(ids))
+				for _, sid := range ids {
+					conversions = append(conversions, newConversion(sid.String(), sid))
+				}
+
+				return errors.Join(out.writeIDs(conversions), out.writeSummaries(summaryList), out.writeBans(banList))
+			})
+
+			return
+		}
+
+		output, closeOutput := createOutput(cmd)
+		defer closeOutput()
+
+		if err := writeEnrichReport(cmd, output, ids, fields, summaries, bans); err != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(enrichCmd)
+	enrichCmd.Flags().String("in", "", "File of steam ids to enrich. Uses stdin if not specified or -.")
+	enrichCmd.Flags().StringSlice("fields", []string{"persona", "vac", "created", "country"},
+		"Comma separated report columns")
+	enrichCmd.Flags().StringP("output", "o", "",
+		"Output file, or sqlite:path to write a sqlite database. Uses stdout if not specified.")
+	addBatchFlags(enrichCmd, 5)
+	completeFlag(enrichCmd, "fields", enrichFieldNames()...)
+}
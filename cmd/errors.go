@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes used by every command, so scripts can tell failures apart without matching the
+// error text.
+const (
+	exitOK = 0
+	// exitFailure is used for any other failure, such as reading or writing files.
+	exitFailure = 1
+	// exitParse is used when the input can not be parsed as a steam id, friend code or status dump.
+	exitParse = 2
+	// exitResolve is used when a vanity name, profile url or group could not be resolved.
+	exitResolve = 3
+	// exitNetwork is used when the steam web api, community site or a server could not be reached.
+	exitNetwork = 4
+	// exitConfig is used for invalid flags, configuration files or a missing api key.
+	exitConfig = 5
+)
+
+// errorOutput is the json error written to stderr when --json is set.
+type errorOutput struct {
+	Error string `json:"error"`
+	Kind  string `json:"kind"`
+	Code  int    `json:"code"`
+}
+
+func exitKind(code int) string {
+	switch code {
+	case exitOK:
+		return "ok"
+	case exitParse:
+		return "parse"
+	case exitResolve:
+		return "resolve"
+	case exitNetwork:
+		return "network"
+	case exitConfig:
+		return "config"
+	default:
+		return "error"
+	}
+}
+
+// errorCode returns the exit code for the known kinds of errors, or fallback for any other error.
+func errorCode(err error, fallback int) int {
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, steamid.ErrNoAPIKey), errors.Is(err, steamid.ErrInvalidKey),
+		errors.Is(err, steamid.ErrMalformedKey), errors.Is(err, extra.ErrRCONAuth):
+		return exitConfig
+	case errors.Is(err, steamid.ErrResponsePerform), errors.Is(err, extra.ErrRCONDial),
+		errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return exitNetwork
+	case errors.Is(err, steamid.ErrParse):
+		return exitParse
+	case errors.Is(err, steamid.ErrResolve), errors.Is(err, steamid.ErrInvalidSID),
+		errors.Is(err, steamid.ErrInvalidGID):
+		return exitResolve
+	default:
+		return fallback
+	}
+}
+
+// fatalf writes the error to stderr and exits with the code. With --json, the error is written as
+// a json object instead.
+func fatalf(cmd *cobra.Command, code int, format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+
+	if cmd != nil && outputFormat(cmd) == outputJSON {
+		body, errMarshal := json.Marshal(errorOutput{Error: message, Kind: exitKind(code), Code: code})
+		if errMarshal == nil {
+			_, _ = fmt.Fprintln(os.Stderr, string(body))
+			os.Exit(code)
+		}
+	}
+
+	log.Print(message)
+	os.Exit(code)
+}
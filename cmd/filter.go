@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// filterCmd prints the lines of a log that contain any of the ids in an id list.
+var filterCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "filter --ids <file> [logfile | -]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Print the lines of a log containing any of the steam ids in a list",
+	Long: `Print the lines of a log containing any of the steam ids in a list.
+
+The id list can be any file containing steam ids, such as banned_user.cfg or a playerlist.json.
+Ids match in any format, so a line containing [U:1:22202] matches STEAM_0:0:11101 in the list.
+The log is streamed from the file, or stdin when no file is given. With --invert, only the lines
+that do not contain any of the ids are printed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			idsPath           = cmd.Flag("ids").Value.String()
+			invert            = cmd.Flag("invert").Changed
+			reader  io.Reader = os.Stdin
+		)
+
+		idsFile, errOpen := os.Open(idsPath)
+		if errOpen != nil {
+			fatalf(cmd, exitFailure, "Failed to open id list (%s): %v", idsPath, errOpen)
+		}
+
+		idList, errScan := extra.ScanReaderSteamIDs(idsFile)
+
+		_ = idsFile.Close()
+
+		if errScan != nil {
+			fatalf(cmd, exitFailure, "Failed to read id list: %v", errScan)
+		}
+
+		ids := steamid.NewSet(idList...)
+
+		if len(args) == 1 && args[0] != "-" {
+			logFile, errLog := os.Open(args[0])
+			if errLog != nil {
+				fatalf(cmd, exitFailure, "Failed to open input file (%s): %v", args[0], errLog)
+			}
+
+			defer func() {
+				_ = logFile.Close()
+			}()
+
+			reader = logFile
+		}
+
+		var (
+			lines  = bufio.NewReader(reader)
+			writer = bufio.NewWriter(os.Stdout)
+		)
+
+		for {
+			line, errLine := lines.ReadString('\n')
+
+			matched := false
+
+			for _, sid := range extra.FindSteamIDs(line) {
+				if ids.Contains(sid) {
+					matched = true
+
+					break
+				}
+			}
+
+			if line != "" && matched != invert {
+				if _, errWrite := writer.WriteString(line); errWrite != nil {
+					fatalf(cmd, exitFailure, "Failed to write output: %v", errWrite)
+				}
+			}
+
+			if errLine != nil {
+				if !errors.Is(errLine, io.EOF) {
+					fatalf(cmd, exitFailure, "Failed to read input: %v", errLine)
+				}
+
+				break
+			}
+		}
+
+		if errFlush := writer.Flush(); errFlush != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", errFlush)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(filterCmd)
+	filterCmd.Flags().String("ids", "", "File containing the steam ids to match")
+	filterCmd.Flags().BoolP("invert", "v", false, "Print the lines that do not contain any of the ids")
+	_ = filterCmd.MarkFlagRequired("ids")
+}
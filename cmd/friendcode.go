@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// friendCodeRecord is a steam id along with its friend code.
+type friendCodeRecord struct {
+	conversion
+	FriendCode string `json:"friend_code"`
+}
+
+func (r friendCodeRecord) columns() []string {
+	return append(r.conversion.columns(), "friend_code")
+}
+
+func (r friendCodeRecord) values() []string {
+	return append(r.conversion.values(), r.FriendCode)
+}
+
+// writeFriendCodes writes the records in the selected machine-readable format, exiting on failure.
+func writeFriendCodes(cmd *cobra.Command, records []friendCodeRecord) {
+	if err := writeRecords(cmd, os.Stdout, records); err != nil {
+		fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+	}
+}
+
+// friendCodeCmd groups the friend code conversion commands.
+var friendCodeCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:     "friendcode",
+	Aliases: []string{"fc"},
+	Short:   "Convert between steam ids and CS2 friend codes",
+	Long: `Convert between steam ids and CS2 friend codes.
+
+Friend codes are the short codes such as SUCVS-FADA shown in the CS2 and CS:GO friends list.`,
+}
+
+var friendCodeEncodeCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "encode <id>...",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Convert steam ids to friend codes",
+	Run: func(cmd *cobra.Command, args []string) {
+		records := make([]friendCodeRecord, 0, len(args))
+
+		for _, arg := range args {
+			sid := steamid.New(arg)
+			if !sid.Valid() || sid.AccountType != steamid.AccountTypeIndividual {
+				fatalf(cmd, exitParse, "Failed to convert id: %s", arg)
+			}
+
+			records = append(records, friendCodeRecord{conversion: newConversion(arg, sid), FriendCode: sid.FriendCode()})
+		}
+
+		if outputFormat(cmd) != outputText {
+			writeFriendCodes(cmd, records)
+			os.Exit(0)
+		}
+
+		for _, record := range records {
+			fmt.Println(record.FriendCode) //nolint:forbidigo
+		}
+
+		os.Exit(0)
+	},
+}
+
+var friendCodeDecodeCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "decode <code>...",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Convert friend codes to steam ids",
+	Long: `Convert friend codes to steam ids.
+
+Codes are accepted with or without the AAAA- prefix and in any case. Mistyped codes are
+rejected since the code contains a checksum of the id.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		records := make([]friendCodeRecord, 0, len(args))
+
+		for _, arg := range args {
+			sid, errDecode := steamid.FromFriendCode(arg)
+			if errDecode != nil {
+				fatalf(cmd, exitParse, "Failed to decode friend code: %v", errDecode)
+			}
+
+			records = append(records, friendCodeRecord{conversion: newConversion(arg, sid), FriendCode: sid.FriendCode()})
+		}
+
+		if outputFormat(cmd) != outputText {
+			writeFriendCodes(cmd, records)
+			os.Exit(0)
+		}
+
+		for _, record := range records {
+			printAllConversions(steamid.New(record.Steam64), false)
+		}
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(friendCodeCmd)
+	friendCodeCmd.AddCommand(friendCodeEncodeCmd, friendCodeDecodeCmd)
+}
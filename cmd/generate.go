@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// generateAccountTypes maps the --type values of the generate command to their account type.
+var generateAccountTypes = map[string]steamid.AccountType{ //nolint:gochecknoglobals
+	"individual":     steamid.AccountTypeIndividual,
+	"clan":           steamid.AccountTypeClan,
+	"gameserver":     steamid.AccountTypeGameServer,
+	"anongameserver": steamid.AccountTypeAnonGameServer,
+}
+
+// generateCmd generates random valid steam ids.
+var generateCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "generate",
+	Args:  cobra.NoArgs,
+	Short: "Generate random valid steam ids for testing",
+	Long: `Generate random valid steam ids for testing.
+
+Ids of the --type account type are written one per line in the --format id format. Setting
+--seed generates the same ids on every run.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		var (
+			typeName = strings.ToLower(cmd.Flag("type").Value.String())
+			idType   = strings.ToLower(cmd.Flag("format").Value.String())
+			rng      *rand.Rand
+		)
+
+		count, _ := cmd.Flags().GetInt("count")
+
+		accountType, found := generateAccountTypes[typeName]
+		if !found {
+			fatalf(cmd, exitConfig, "Unknown type, must be one of individual, clan, gameserver, anongameserver: %s", typeName)
+		}
+
+		if !slices.Contains(idTypes, idType) {
+			fatalf(cmd, exitConfig, "Unknown format, must be one of steam, steam3, steam32, steam64: %s", idType)
+		}
+
+		if idType == "steam" && accountType != steamid.AccountTypeIndividual {
+			fatalf(cmd, exitConfig, "The steam format is only available for individual ids")
+		}
+
+		if cmd.Flag("seed").Changed {
+			seed, _ := cmd.Flags().GetUint64("seed")
+			rng = rand.New(rand.NewPCG(seed, seed)) //nolint:gosec
+		}
+
+		sids := make([]steamid.SteamID, 0, count)
+
+		for range count {
+			sid, errRandom := steamid.RandomSteamID(rng, accountType)
+			if errRandom != nil {
+				fatalf(cmd, exitConfig, "Failed to generate id: %v", errRandom)
+			}
+
+			sids = append(sids, sid)
+		}
+
+		if outputFormat(cmd) != outputText {
+			conversions := make([]conversion, 0, len(sids))
+			for _, sid := range sids {
+				conversions = append(conversions, newConversion(sid.String(), sid))
+			}
+
+			if err := writeRecords(cmd, os.Stdout, conversions); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+
+			return
+		}
+
+		writer := bufio.NewWriter(os.Stdout)
+		for _, sid := range sids {
+			_, _ = fmt.Fprintln(writer, formatID(sid, idType))
+		}
+
+		if err := writer.Flush(); err != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+	generateCmd.Flags().IntP("count", "n", 10, "Number of ids to generate")
+	generateCmd.Flags().String("type", "individual", "Account type of the ids (individual, clan, gameserver, anongameserver)")
+	generateCmd.Flags().StringP("format", "f", "steam64", "Output format for the ids (steam64, steam, steam3, steam32)")
+	generateCmd.Flags().Uint64("seed", 0, "Seed for repeatable output")
+	completeFlag(generateCmd, "type", "individual", "clan", "gameserver", "anongameserver")
+	completeFlag(generateCmd, "format", idTypes...)
+}
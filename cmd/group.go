@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// groupRecord is a group summary along with all the representations of its group id.
+type groupRecord struct {
+	conversion
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	Headline      string `json:"headline"`
+	MemberCount   int    `json:"member_count"`
+	MembersOnline int    `json:"members_online"`
+	MembersInGame int    `json:"members_in_game"`
+}
+
+func (r groupRecord) columns() []string {
+	return append(r.conversion.columns(), "name", "url", "headline", "member_count", "members_online", "members_in_game")
+}
+
+func (r groupRecord) values() []string {
+	return append(r.conversion.values(), r.Name, r.URL, r.Headline, strconv.Itoa(r.MemberCount),
+		strconv.Itoa(r.MembersOnline), strconv.Itoa(r.MembersInGame))
+}
+
+// writeMembers writes the group members to the --output file, or stdout.
+func writeMembers(cmd *cobra.Command, query string) {
+	var (
+		writer  io.Writer = os.Stdout
+		idType            = strings.ToLower(cmd.Flag("type").Value.String())
+		outPath           = cmd.Flag("output").Value.String()
+	)
+
+	switch idType {
+	case "steam", "steam3", "steam32", "steam64":
+	default:
+		fatalf(cmd, exitConfig, "Unknown type, must be one of steam, steam3, steam32, steam64: %s", idType)
+	}
+
+	pages := newProgress(cmd, "group", "pages", 0)
+
+	client, stop := newBatchClient(cmd, steamid.WithRequestHook(func(steamid.RequestInfo) {
+		pages.add(1)
+	}))
+	defer stop()
+
+	members, errMembers := client.GroupMembers(cmd.Context(), query)
+	pages.finish()
+
+	if errMembers != nil {
+		fatalf(cmd, errorCode(errMembers, exitNetwork), "Failed to fetch group members: %v", errMembers)
+	}
+
+	if outPath != "" {
+		outFile, errCreate := os.Create(outPath)
+		if errCreate != nil {
+			fatalf(cmd, exitFailure, "Failed to create output file (%s): %v", outPath, errCreate)
+		}
+
+		defer func() {
+			if err := outFile.Close(); err != nil {
+				log.Printf("Failed to close output file")
+			}
+		}()
+
+		writer = outFile
+	}
+
+	if outputFormat(cmd) != outputText {
+		conversions := make([]conversion, 0, len(members))
+		for _, sid := range members {
+			conversions = append(conversions, newConversion(sid.String(), sid))
+		}
+
+		if err := writeRecords(cmd, writer, conversions); err != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+		}
+
+		return
+	}
+
+	buffered := bufio.NewWriter(writer)
+	for _, sid := range members {
+		_, _ = fmt.Fprintln(buffered, formatID(sid, idType))
+	}
+
+	if err := buffered.Flush(); err != nil {
+		fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+	}
+}
+
+// groupCmd shows a steam group summary and optionally its members.
+var groupCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:     "group <vanity | gid | url>",
+	Aliases: []string{"g"},
+	Args:    cobra.ExactArgs(1),
+	Short:   "Show a steam group summary or its members",
+	Long: `Show a steam group summary or its members.
+
+The group can be given as its vanity name, any format of group id or its community url. With
+--members, the full member list is fetched page by page and written in the --type format,
+reporting progress on stderr. Page requests are limited to --rate per second and
+--max-requests in total.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if cmd.Flag("members").Changed {
+			writeMembers(cmd, args[0])
+
+			return
+		}
+
+		group, errGroup := steamid.GroupDetails(cmd.Context(), args[0])
+		if errGroup != nil {
+			fatalf(cmd, errorCode(errGroup, exitNetwork), "Failed to fetch group: %v", errGroup)
+		}
+
+		record := groupRecord{
+			conversion:    newConversion(args[0], group.GroupID),
+			Name:          group.Name,
+			URL:           group.URL,
+			Headline:      group.Headline,
+			MemberCount:   group.MemberCount,
+			MembersOnline: group.MembersOnline,
+			MembersInGame: group.MembersInGame,
+		}
+
+		if outputFormat(cmd) != outputText {
+			if err := writeRecords(cmd, os.Stdout, []groupRecord{record}); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+
+			return
+		}
+
+		fmt.Printf(`GID64:        %s
+Steam3:       %s
+Name:         %s
+URL:          %s
+Members:      %d
+Online:       %d
+In Game:      %d
+`, record.Steam64, record.Steam3, record.Name, record.URL, record.MemberCount, record.MembersOnline,
+			record.MembersInGame) //nolint:forbidigo
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(groupCmd)
+	groupCmd.Flags().Bool("members", false, "Fetch and output the ids of all group members")
+	groupCmd.Flags().StringP("type", "t", "steam64", "Output format for member ids (steam64, steam, steam3, steam32)")
+	groupCmd.Flags().StringP("output", "o", "", "Output members to a file. Uses stdout if not specified.")
+	addBatchFlags(groupCmd, 0)
+	completeFlag(groupCmd, "type", idTypes...)
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra/store"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// historyRecord is a single vanity name or persona name recorded for a steam id.
+type historyRecord struct {
+	conversion
+	// Kind is either vanity, for resolved vanity names and profile urls, or alias for persona names.
+	Kind      string    `json:"kind"`
+	Value     string    `json:"value"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+func (r historyRecord) columns() []string {
+	return append(r.conversion.columns(), "kind", "value", "first_seen", "last_seen")
+}
+
+func (r historyRecord) values() []string {
+	return append(r.conversion.values(), r.Kind, r.Value, r.FirstSeen.Format(time.RFC3339), r.LastSeen.Format(time.RFC3339))
+}
+
+// historyCmd shows the vanity names and persona names recorded in the store.
+var historyCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "history <id | vanity>...",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Show the vanity names and persona names recorded for steam ids",
+	Long: `Show the vanity names and persona names recorded for steam ids.
+
+Names are recorded in the sqlite database set with --store by the resolve, summary,
+enrich and other commands that fetch them. Vanity names and profile urls that were
+resolved before can be used in place of a steam id. No web api requests are made.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		mappingStore, errStore := openStore(cmd)
+		if errStore != nil {
+			fatalf(cmd, exitFailure, "Failed to open store: %v", errStore)
+		}
+
+		if mappingStore == nil {
+			fatalf(cmd, exitConfig, "A store must be set with --store")
+		}
+
+		defer func() {
+			_ = mappingStore.Close()
+		}()
+
+		var (
+			ctx     = cmd.Context()
+			records []historyRecord
+		)
+
+		for _, arg := range args {
+			sid := steamid.New(arg)
+			if !sid.Valid() {
+				resolution, errResolution := mappingStore.Resolution(ctx, arg)
+				if errors.Is(errResolution, store.ErrNotFound) {
+					fatalf(cmd, exitResolve, "No history of %s", arg)
+				} else if errResolution != nil {
+					fatalf(cmd, exitFailure, "Failed to read store: %v", errResolution)
+				}
+
+				sid = resolution.SteamID
+			}
+
+			resolutions, errResolutions := mappingStore.Resolutions(ctx, sid)
+			if errResolutions != nil {
+				fatalf(cmd, exitFailure, "Failed to read store: %v", errResolutions)
+			}
+
+			aliases, errAliases := mappingStore.Aliases(ctx, sid)
+			if errAliases != nil {
+				fatalf(cmd, exitFailure, "Failed to read store: %v", errAliases)
+			}
+
+			for _, resolution := range resolutions {
+				records = append(records, historyRecord{
+					conversion: newConversion(arg, sid), Kind: "vanity", Value: resolution.Query,
+					FirstSeen: resolution.FirstSeen, LastSeen: resolution.LastSeen,
+				})
+			}
+
+			for _, alias := range aliases {
+				records = append(records, historyRecord{
+					conversion: newConversion(arg, sid), Kind: "alias", Value: alias.Name,
+					FirstSeen: alias.FirstSeen, LastSeen: alias.LastSeen,
+				})
+			}
+		}
+
+		if outputFormat(cmd) != outputText {
+			if errWrite := writeRecords(cmd, os.Stdout, records); errWrite != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", errWrite)
+			}
+
+			os.Exit(0)
+		}
+
+		for _, record := range records {
+			fmt.Printf("%s\t%-6s\t%s\t%s\t%s\n", record.Steam64, record.Kind, record.FirstSeen.Format(time.DateOnly),
+				record.LastSeen.Format(time.DateOnly), record.Value) //nolint:forbidigo
+		}
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+)
+
+// unixScheme is the prefix of --listen addresses naming a unix domain socket.
+const unixScheme = "unix://"
+
+var errSocketInUse = errors.New("socket is in use by another process")
+
+// openListener opens the listener of a --listen address, either a tcp address such as :8080 or a
+// unix domain socket such as unix:///var/run/steamid.sock. A socket file left behind by a process
+// that exited without closing it is replaced, and the socket file is removed again when the
+// listener is closed.
+func openListener(ctx context.Context, address string) (net.Listener, error) {
+	var config net.ListenConfig
+
+	path, isUnix := strings.CutPrefix(address, unixScheme)
+	if !isUnix {
+		return config.Listen(ctx, "tcp", address) //nolint:wrapcheck
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("missing socket path: %s", address)
+	}
+
+	if errStale := removeStaleSocket(ctx, path); errStale != nil {
+		return nil, errStale
+	}
+
+	return config.Listen(ctx, "unix", path) //nolint:wrapcheck
+}
+
+// removeStaleSocket removes the socket file at path when no process is accepting connections on
+// it. Other kinds of files are left for net.Listen to fail on.
+func removeStaleSocket(ctx context.Context, path string) error {
+	info, errStat := os.Stat(path)
+	if errStat != nil || info.Mode().Type() != fs.ModeSocket {
+		return nil
+	}
+
+	var dialer net.Dialer
+
+	if conn, errDial := dialer.DialContext(ctx, "unix", path); errDial == nil {
+		_ = conn.Close()
+
+		return fmt.Errorf("%w: %s", errSocketInUse, path)
+	}
+
+	if errRemove := os.Remove(path); errRemove != nil {
+		return fmt.Errorf("failed to remove stale socket: %w", errRemove)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	steamidv1 "github.com/leighmacdonald/steamid/v4/proto/steamid/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// socketPath returns the path of a socket in a new temp dir. t.TempDir is not used as its paths can
+// exceed the length limit of socket paths.
+func socketPath(t *testing.T) string {
+	t.Helper()
+
+	dir, errDir := os.MkdirTemp("", "steamid")
+	require.NoError(t, errDir)
+
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return filepath.Join(dir, "steamid.sock")
+}
+
+func TestOpenListenerRemovesSocket(t *testing.T) {
+	t.Parallel()
+
+	path := socketPath(t)
+
+	listener, errListen := openListener(context.Background(), unixScheme+path)
+	require.NoError(t, errListen)
+	require.FileExists(t, path)
+
+	server := &http.Server{ //nolint:exhaustruct
+		Handler:           newTestAPI(t, nil, 10).handler(),
+		ReadHeaderTimeout: time.Second,
+	}
+
+	served := make(chan error, 1)
+
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	client := &http.Client{Transport: &http.Transport{ //nolint:exhaustruct
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+
+			return dialer.DialContext(ctx, "unix", path)
+		},
+	}}
+
+	resp, errGet := client.Get("http://steamid/healthz")
+	require.NoError(t, errGet)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, server.Shutdown(context.Background()))
+	require.ErrorIs(t, <-served, http.ErrServerClosed)
+	require.NoFileExists(t, path)
+}
+
+func TestOpenListenerRemovesGRPCSocket(t *testing.T) {
+	t.Parallel()
+
+	path := socketPath(t)
+
+	listener, errListen := openListener(context.Background(), unixScheme+path)
+	require.NoError(t, errListen)
+
+	server := newGRPCServer(newTestAPI(t, nil, 10))
+	served := make(chan error, 1)
+
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	conn, errConn := grpc.NewClient("unix://"+path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, errConn)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	conversion, errConvert := steamidv1.NewSteamIDServiceClient(conn).Convert(context.Background(),
+		&steamidv1.ConvertRequest{Id: "[U:1:22202]"})
+	require.NoError(t, errConvert)
+	require.Equal(t, uint32(22202), conversion.GetSteam32())
+
+	require.NoError(t, conn.Close())
+	stopGRPC(context.Background(), server)
+	require.NoError(t, <-served)
+	require.NoFileExists(t, path)
+}
+
+func TestOpenListenerStaleSocket(t *testing.T) {
+	t.Parallel()
+
+	path := socketPath(t)
+
+	// Leave the socket file behind, as a process that was killed would.
+	stale, errStale := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, errStale)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	require.FileExists(t, path)
+
+	listener, errListen := openListener(context.Background(), unixScheme+path)
+	require.NoError(t, errListen)
+
+	// A socket that is still accepting connections must not be replaced.
+	_, errInUse := openListener(context.Background(), unixScheme+path)
+	require.ErrorIs(t, errInUse, errSocketInUse)
+
+	require.NoError(t, listener.Close())
+	require.NoFileExists(t, path)
+
+	// Other files are left in place.
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	_, errFile := openListener(context.Background(), unixScheme+path)
+	require.Error(t, errFile)
+	require.False(t, errors.Is(errFile, errSocketInUse))
+	require.FileExists(t, path)
+
+	_, errEmpty := openListener(context.Background(), unixScheme)
+	require.Error(t, errEmpty)
+}
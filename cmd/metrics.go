@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// latencyBuckets are the upper bounds, in seconds, of the steam web api latency histogram.
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10} //nolint:gochecknoglobals
+
+// histogram is a cumulative prometheus histogram using latencyBuckets.
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func (h *histogram) observe(value float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(latencyBuckets))
+	}
+
+	for idx, bound := range latencyBuckets {
+		if value <= bound {
+			h.counts[idx]++
+		}
+	}
+
+	h.count++
+	h.sum += value
+}
+
+// serveMetrics collects the metrics of the serve command and writes them in the prometheus text
+// exposition format.
+type serveMetrics struct {
+	mu sync.Mutex
+	// requests counts the served requests by their route and code labels.
+	requests map[string]uint64
+	// apiRequests counts the steam web api requests by their path and code labels.
+	apiRequests map[string]uint64
+	apiLatency  map[string]*histogram
+	// resolveHits and resolveMisses count the lookups of the resolve cache. The player cache keeps
+	// its own counts, see extra.PlayerDataCache.Stats.
+	resolveHits   uint64
+	resolveMisses uint64
+	// rateLimited counts the api requests abandoned while waiting for the rate limiter.
+	rateLimited uint64
+}
+
+func newServeMetrics() *serveMetrics {
+	return &serveMetrics{
+		requests:    map[string]uint64{},
+		apiRequests: map[string]uint64{},
+		apiLatency:  map[string]*histogram{},
+	}
+}
+
+func (m *serveMetrics) observeRequest(route string, status int) {
+	m.mu.Lock()
+	m.requests[fmt.Sprintf("route=%q,code=\"%d\"", route, status)]++
+	m.mu.Unlock()
+}
+
+// observeAPIRequest is the steamid.WithRequestHook of the serve client.
+func (m *serveMetrics) observeAPIRequest(info steamid.RequestInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.apiRequests[fmt.Sprintf("path=%q,code=\"%d\"", info.Path, info.StatusCode)]++
+
+	latency, found := m.apiLatency[info.Path]
+	if !found {
+		latency = &histogram{}
+		m.apiLatency[info.Path] = latency
+	}
+
+	latency.observe(info.Duration.Seconds())
+}
+
+func (m *serveMetrics) observeResolve(hit bool) {
+	m.mu.Lock()
+	if hit {
+		m.resolveHits++
+	} else {
+		m.resolveMisses++
+	}
+	m.mu.Unlock()
+}
+
+func (m *serveMetrics) observeRateLimited() {
+	m.mu.Lock()
+	m.rateLimited++
+	m.mu.Unlock()
+}
+
+// sortedKeys returns the keys of the map in a stable order for the metric output.
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// write writes all the metrics, including the player cache counts.
+func (m *serveMetrics) write(writer io.Writer, playerHits int, playerMisses int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, _ = fmt.Fprintln(writer, "# HELP steamid_http_requests_total Requests served by route and status code.")
+	_, _ = fmt.Fprintln(writer, "# TYPE steamid_http_requests_total counter")
+
+	for _, labels := range sortedKeys(m.requests) {
+		_, _ = fmt.Fprintf(writer, "steamid_http_requests_total{%s} %d\n", labels, m.requests[labels])
+	}
+
+	_, _ = fmt.Fprintln(writer, "# HELP steamid_cache_hits_total Lookups answered from the cache.")
+	_, _ = fmt.Fprintln(writer, "# TYPE steamid_cache_hits_total counter")
+	_, _ = fmt.Fprintf(writer, "steamid_cache_hits_total{cache=\"resolve\"} %d\n", m.resolveHits)
+	_, _ = fmt.Fprintf(writer, "steamid_cache_hits_total{cache=\"player\"} %d\n", playerHits)
+	_, _ = fmt.Fprintln(writer, "# HELP steamid_cache_misses_total Lookups missing from the cache.")
+	_, _ = fmt.Fprintln(writer, "# TYPE steamid_cache_misses_total counter")
+	_, _ = fmt.Fprintf(writer, "steamid_cache_misses_total{cache=\"resolve\"} %d\n", m.resolveMisses)
+	_, _ = fmt.Fprintf(writer, "steamid_cache_misses_total{cache=\"player\"} %d\n", playerMisses)
+
+	_, _ = fmt.Fprintln(writer, "# HELP steamid_api_requests_total Steam web api requests by path and status code, 0 when no response was received.")
+	_, _ = fmt.Fprintln(writer, "# TYPE steamid_api_requests_total counter")
+
+	for _, labels := range sortedKeys(m.apiRequests) {
+		_, _ = fmt.Fprintf(writer, "steamid_api_requests_total{%s} %d\n", labels, m.apiRequests[labels])
+	}
+
+	_, _ = fmt.Fprintln(writer, "# HELP steamid_api_request_duration_seconds Steam web api response latency by path.")
+	_, _ = fmt.Fprintln(writer, "# TYPE steamid_api_request_duration_seconds histogram")
+
+	for _, path := range sortedKeys(m.apiLatency) {
+		latency := m.apiLatency[path]
+		for idx, bound := range latencyBuckets {
+			_, _ = fmt.Fprintf(writer, "steamid_api_request_duration_seconds_bucket{path=%q,le=%q} %d\n",
+				path, formatFloat(bound), latency.counts[idx])
+		}
+
+		_, _ = fmt.Fprintf(writer, "steamid_api_request_duration_seconds_bucket{path=%q,le=\"+Inf\"} %d\n", path, latency.count)
+		_, _ = fmt.Fprintf(writer, "steamid_api_request_duration_seconds_sum{path=%q} %s\n", path, formatFloat(latency.sum))
+		_, _ = fmt.Fprintf(writer, "steamid_api_request_duration_seconds_count{path=%q} %d\n", path, latency.count)
+	}
+
+	_, _ = fmt.Fprintln(writer, "# HELP steamid_rate_limit_rejections_total Steam web api requests abandoned while waiting for the rate limiter.")
+	_, _ = fmt.Fprintln(writer, "# TYPE steamid_rate_limit_rejections_total counter")
+	_, _ = fmt.Fprintf(writer, "steamid_rate_limit_rejections_total %d\n", m.rateLimited)
+}
+
+// statusRecorder captures the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// instrument wraps the handler, counting its requests under the route name.
+func (m *serveMetrics) instrument(route string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next(recorder, r)
+
+		m.observeRequest(route, recorder.status)
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputText = ""
+	outputJSON = "json"
+	outputCSV  = "csv"
+	outputTSV  = "tsv"
+	// outputTemplate formats each record using the go template set with --template.
+	outputTemplate = "template"
+)
+
+// outputFormat returns the machine-readable output format selected with the global --json, --csv,
+// --tsv and --template flags, or outputText when none are set.
+func outputFormat(cmd *cobra.Command) string {
+	for _, format := range []string{outputJSON, outputCSV, outputTSV, outputTemplate} {
+		if flag := cmd.Flag(format); flag != nil && flag.Changed {
+			return format
+		}
+	}
+
+	return outputText
+}
+
+// outputRecord is a single row of machine-readable command output.
+type outputRecord interface {
+	columns() []string
+	values() []string
+}
+
+// writeTemplate executes the template once per record, each followed by a newline.
+func writeTemplate[T outputRecord](writer io.Writer, text string, records []T) error {
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\\n", "\n"), "\\t", "\t")
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	tmpl, errParse := template.New("output").Parse(text)
+	if errParse != nil {
+		return fmt.Errorf("invalid template: %w", errParse)
+	}
+
+	for _, record := range records {
+		if errExec := tmpl.Execute(writer, record); errExec != nil {
+			return errExec //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// writeRecords writes the records to the writer in the format selected by the command flags. JSON
+// output is a single array of objects, csv and tsv output start with a header row.
+func writeRecords[T outputRecord](cmd *cobra.Command, writer io.Writer, records []T) error {
+	format := outputFormat(cmd)
+
+	if format == outputTemplate {
+		return writeTemplate(writer, cmd.Flag(outputTemplate).Value.String(), records)
+	}
+
+	if format == outputJSON {
+		if records == nil {
+			records = []T{}
+		}
+
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(records) //nolint:wrapcheck
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	if format == outputTSV {
+		csvWriter.Comma = '\t'
+	}
+
+	var zero T
+	if errWrite := csvWriter.Write(zero.columns()); errWrite != nil {
+		return errWrite //nolint:wrapcheck
+	}
+
+	for _, record := range records {
+		if errWrite := csvWriter.Write(record.values()); errWrite != nil {
+			return errWrite //nolint:wrapcheck
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error() //nolint:wrapcheck
+}
+
+// conversion holds every representation of a steam id along with the input it was created from. Its
+// fields, and those of the records embedding it, are available to --template.
+type conversion struct {
+	// Input is the value given on the command line, read from stdin or found in the parsed text.
+	Input string `json:"input"`
+	// Steam is the steam2 format, STEAM_0:0:11101.
+	Steam string `json:"steam2"`
+	// Steam3 is the steam3 format, [U:1:22202].
+	Steam3 string `json:"steam3"`
+	// Steam32 is the account id, 22202.
+	Steam32 uint32 `json:"steam32"`
+	// Steam64 is the 64bit format, 76561197960287930.
+	Steam64 string `json:"steam64"`
+	// AccountType, Instance and Universe are the names of the parts of the id, e.g. Individual,
+	// Desktop and Public.
+	AccountType string `json:"account_type"`
+	Instance    string `json:"instance"`
+	Universe    string `json:"universe"`
+}
+
+func newConversion(input string, sid steamid.SteamID) conversion {
+	return conversion{
+		Input:       input,
+		Steam:       string(sid.Steam(false)),
+		Steam3:      string(sid.Steam3()),
+		Steam32:     uint32(sid.AccountID),
+		Steam64:     sid.String(),
+		AccountType: sid.AccountType.String(),
+		Instance:    sid.Instance.String(),
+		Universe:    sid.Universe.String(),
+	}
+}
+
+func (c conversion) columns() []string {
+	return []string{"input", "steam2", "steam3", "steam32", "steam64", "account_type", "instance", "universe"}
+}
+
+func (c conversion) values() []string {
+	return []string{
+		c.Input, c.Steam, c.Steam3, strconv.FormatUint(uint64(c.Steam32), 10), c.Steam64,
+		c.AccountType, c.Instance, c.Universe,
+	}
+}
+
+// createOutput creates the --output file, returning stdout when it is not set. The returned
+// function closes the file.
+func createOutput(cmd *cobra.Command) (io.Writer, func()) {
+	outPath := cmd.Flag("output").Value.String()
+	if outPath == "" {
+		return os.Stdout, func() {}
+	}
+
+	outFile, errCreate := os.Create(outPath)
+	if errCreate != nil {
+		fatalf(cmd, exitFailure, "Failed to create output file (%s): %v", outPath, errCreate)
+	}
+
+	return outFile, func() {
+		if err := outFile.Close(); err != nil {
+			log.Printf("Failed to close output file: %v", err)
+		}
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().Bool(outputJSON, false, "Output results as JSON")
+	rootCmd.PersistentFlags().Bool(outputCSV, false, "Output results as CSV")
+	rootCmd.PersistentFlags().Bool(outputTSV, false, "Output results as tab separated values")
+	rootCmd.PersistentFlags().String(outputTemplate, "",
+		"Format each result with a go template, eg: '{{.Steam64}} {{.Steam3}} {{.PersonaName}}'")
+	rootCmd.MarkFlagsMutuallyExclusive(outputJSON, outputCSV, outputTSV, outputTemplate)
+}
@@ -1,22 +1,43 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
 	"github.com/spf13/cobra"
 )
 
 // parseCmd represents the parse command.
 var parseCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
-	Use:   "parse",
+	Use:   "parse [files...]",
 	Short: "Parse steam id's from an input file",
 	Long: `Parse steam id's from an input file. 
 
-All formats are parsed from the file and duplicates are removed`,
+All formats are parsed from the file and duplicates are removed unless --no-dedupe is set.
+
+Many files, such as a directory of rotated logs, can be given as arguments. They are parsed
+concurrently by up to --workers goroutines and the results merged in the order of the files.
+Multiple files only support the text output, without --html, --stats or --verbose.
+
+With --mmap, input files are mapped into memory and scanned in place, which avoids copying
+multi-gigabyte files line by line. Platforms without mmap support read the files instead.
+
+With --html, tags and entities are stripped before parsing so saved forum threads, SourceBans
+pages and steam group pages can be used directly. Ids in attributes such as profile links are
+still found.
+
+Text that looks like a steam id but is not a valid one, such as [U:1:0], is
+skipped, as are lines without ids. With --verbose, each skipped id is reported on stderr with
+its line and column, followed by the number of skipped ids and lines without ids.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		var (
 			reader io.Reader
@@ -28,10 +49,27 @@ All formats are parsed from the file and duplicates are removed`,
 			strings.ReplaceAll(cmd.Flag("format").Value.String(), "\\n", "\n"),
 			"\\r", "\r")
 		idType := strings.ToLower(cmd.Flag("type").Value.String())
+		_, isSQLite := sqliteOutputPath(cmd)
+
+		inputs := args
 		if inputFile != "" {
+			inputs = append([]string{inputFile}, args...)
+		}
+
+		if len(inputs) == 1 {
+			inputFile = inputs[0]
+		} else if len(inputs) > 1 && (isSQLite || outputFormat(cmd) != outputText || cmd.Flag("html").Changed ||
+			cmd.Flag("stats").Changed || cmd.Flag("verbose").Changed) {
+			fatalf(cmd, exitConfig, "Multiple input files only support text output without --html, --stats or --verbose")
+		}
+
+		switch {
+		case len(inputs) > 1:
+			// The files are opened by extra.ParseFiles.
+		case inputFile != "":
 			openedInputFile, errOpen := os.Open(inputFile)
 			if errOpen != nil {
-				log.Fatalf("Failed to open input file (%s): %v", inputFile, errOpen)
+				fatalf(cmd, exitFailure, "Failed to open input file (%s): %v", inputFile, errOpen)
 			}
 			defer func() {
 				if err := openedInputFile.Close(); err != nil {
@@ -39,13 +77,27 @@ All formats are parsed from the file and duplicates are removed`,
 				}
 			}()
 			reader = openedInputFile
-		} else {
+		default:
 			reader = os.Stdin
 		}
+
+		if cmd.Flag("html").Changed {
+			body, errRead := io.ReadAll(reader)
+			if errRead != nil {
+				fatalf(cmd, exitFailure, "Failed to read input: %v", errRead)
+			}
+
+			reader = strings.NewReader(extra.StripHTML(string(body)))
+		}
+		if dbPath, isSQLite := sqliteOutputPath(cmd); isSQLite {
+			writeMatchesSQLite(cmd, reader, dbPath)
+			os.Exit(0)
+		}
+
 		if outputFilePath != "" {
 			outFile, err := os.Create(outputFilePath)
 			if err != nil {
-				log.Fatalf("Failed to create output file (%s): %v", outputFilePath, err)
+				fatalf(cmd, exitFailure, "Failed to create output file (%s): %v", outputFilePath, err)
 			}
 			defer func() {
 				if err := outFile.Close(); err != nil {
@@ -57,22 +109,255 @@ All formats are parsed from the file and duplicates are removed`,
 			writer = os.Stdout
 		}
 
-		if err := extra.ParseReader(reader, writer, format, idType); err != nil {
-			log.Fatalf(err.Error())
+		var scanOpts []extra.ScanOption
+
+		if cmd.Flag("no-dedupe").Changed {
+			scanOpts = append(scanOpts, extra.WithAllOccurrences())
+		}
+
+		if cmd.Flag("unique-by-account").Changed {
+			scanOpts = append(scanOpts, extra.WithUniqueByAccount())
+		}
+
+		useMmap := cmd.Flag("mmap").Changed
+		if useMmap {
+			scanOpts = append(scanOpts, extra.WithMmap())
+		}
+
+		if outputFormat(cmd) != outputText {
+			writeMatches(cmd, reader, writer, scanOpts)
+			os.Exit(0)
+		}
+
+		scanOpts = verboseScanOpts(cmd, scanOpts)
+
+		switch idType {
+		case "steam", "steam3", "steam32", "steam64":
+		default:
+			fatalf(cmd, exitConfig, "Unknown type, must be one of steam, steam3, steam32, steam64: %s", idType)
+		}
+
+		var (
+			found   []steamid.SteamID
+			stats   extra.ScanStats
+			errScan error
+		)
+
+		switch {
+		case len(inputs) > 1:
+			workers, _ := cmd.Flags().GetInt("workers")
+			found, errScan = extra.ParseFiles(cmd.Context(), inputs, workers, scanOpts...)
+		case useMmap && inputFile != "" && !cmd.Flag("html").Changed:
+			found, stats, errScan = extra.ScanFileSteamIDStats(inputFile, scanOpts...)
+		default:
+			found, stats, errScan = extra.ScanReaderSteamIDStats(reader, scanOpts...)
+		}
+
+		if errScan != nil {
+			fatalf(cmd, exitFailure, "Failed to read input: %v", errScan)
+		}
+
+		buffered := bufio.NewWriter(writer)
+		for _, sid := range found {
+			_, _ = fmt.Fprintf(buffered, format, formatID(sid, idType))
 		}
+
+		if err := buffered.Flush(); err != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+		}
+
+		reportScan(cmd, stats)
+
 		os.Exit(0)
 	},
 }
 
+// matchRecord is a steam id found by the parse command along with where it was found.
+type matchRecord struct {
+	conversion
+	// Format is the format the id was written in: steam, steam3 or steam64.
+	Format string `json:"format"`
+	// Line and Column are the 1-indexed position of the match.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	// Offset is the byte offset of the match from the start of the input.
+	Offset int64 `json:"offset"`
+	// Occurrences is the total number of times the id was found.
+	Occurrences int `json:"occurrences"`
+}
+
+func (r matchRecord) columns() []string {
+	return append(r.conversion.columns(), "format", "line", "column", "offset", "occurrences")
+}
+
+func (r matchRecord) values() []string {
+	return append(r.conversion.values(), r.Format, strconv.Itoa(r.Line), strconv.Itoa(r.Column),
+		strconv.FormatInt(r.Offset, 10), strconv.Itoa(r.Occurrences))
+}
+
+// writeMatches writes a record for each id found in the reader, including the original text, format
+// and position it was found at. Only the first occurrence of each id is written unless --no-dedupe is set.
+func writeMatches(cmd *cobra.Command, reader io.Reader, writer io.Writer, scanOpts []extra.ScanOption) {
+	// The input is read twice when statistics are requested, so it has to be buffered.
+	body, errRead := io.ReadAll(reader)
+	if errRead != nil {
+		fatalf(cmd, exitFailure, "Failed to read input: %v", errRead)
+	}
+
+	matches, errMatches := extra.FindReaderSteamIDMatches(bytes.NewReader(body))
+	if errMatches != nil {
+		fatalf(cmd, exitFailure, "Failed to read input: %v", errMatches)
+	}
+
+	var (
+		noDedupe  = cmd.Flag("no-dedupe").Changed
+		byAccount = cmd.Flag("unique-by-account").Changed
+		counts    = map[uint64]int{}
+		records   []matchRecord
+	)
+
+	key := func(sid steamid.SteamID) uint64 {
+		if byAccount {
+			return uint64(sid.AccountID)
+		}
+
+		return sid.Uint64()
+	}
+
+	for _, match := range matches {
+		counts[key(match.SteamID)]++
+	}
+
+	seen := map[uint64]bool{}
+
+	for _, match := range matches {
+		if !noDedupe && seen[key(match.SteamID)] {
+			continue
+		}
+
+		seen[key(match.SteamID)] = true
+
+		records = append(records, matchRecord{
+			conversion:  newConversion(match.Text, match.SteamID),
+			Format:      string(match.Format),
+			Line:        match.Line,
+			Column:      match.Column,
+			Offset:      match.Offset,
+			Occurrences: counts[key(match.SteamID)],
+		})
+	}
+
+	if err := writeRecords(cmd, writer, records); err != nil {
+		fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+	}
+
+	rescan(cmd, body, scanOpts)
+}
+
+// rescan scans the body again for the statistics of --stats and --verbose, which are not collected
+// when finding the matches.
+func rescan(cmd *cobra.Command, body []byte, scanOpts []extra.ScanOption) {
+	if !cmd.Flag("stats").Changed && !cmd.Flag("verbose").Changed {
+		return
+	}
+
+	_, stats, errScan := extra.ScanReaderSteamIDStats(bytes.NewReader(body), verboseScanOpts(cmd, scanOpts)...)
+	if errScan != nil {
+		fatalf(cmd, exitFailure, "Failed to read input: %v", errScan)
+	}
+
+	reportScan(cmd, stats)
+}
+
+// verboseScanOpts adds a hook reporting each candidate that is not a valid id to the scan options
+// when --verbose is set.
+func verboseScanOpts(cmd *cobra.Command, scanOpts []extra.ScanOption) []extra.ScanOption {
+	if !cmd.Flag("verbose").Changed {
+		return scanOpts
+	}
+
+	return append(slices.Clip(scanOpts), extra.WithInvalidHook(func(match extra.Match) {
+		log.Printf("Skipped invalid %s id at line %d, column %d: %s", match.Format, match.Line, match.Column, match.Text)
+	}))
+}
+
+// reportScan writes the statistics of the scan to stderr with --stats, and the number of skipped
+// candidates and lines with --verbose.
+func reportScan(cmd *cobra.Command, stats extra.ScanStats) {
+	if cmd.Flag("stats").Changed {
+		printScanStats(os.Stderr, stats)
+	}
+
+	if cmd.Flag("verbose").Changed {
+		log.Printf("Skipped %d invalid ids and %d lines without ids", stats.Invalid, stats.LinesWithoutIDs)
+	}
+}
+
+// writeMatchesSQLite writes the first occurrence of each id found in the reader to the ids table
+// of the sqlite database.
+func writeMatchesSQLite(cmd *cobra.Command, reader io.Reader, dbPath string) {
+	body, errRead := io.ReadAll(reader)
+	if errRead != nil {
+		fatalf(cmd, exitFailure, "Failed to read input: %v", errRead)
+	}
+
+	matches, errMatches := extra.FindReaderSteamIDMatches(bytes.NewReader(body))
+	if errMatches != nil {
+		fatalf(cmd, exitFailure, "Failed to read input: %v", errMatches)
+	}
+
+	var (
+		seen        = steamid.Set{}
+		conversions []conversion
+	)
+
+	for _, match := range matches {
+		if !seen.Contains(match.SteamID) {
+			seen.Add(match.SteamID)
+			conversions = append(conversions, newConversion(match.Text, match.SteamID))
+		}
+	}
+
+	writeSQLite(cmd, dbPath, func(out *sqliteOutput) error {
+		return out.writeIDs(conversions)
+	})
+
+	rescan(cmd, body, nil)
+}
+
+// printScanStats writes a summary of the candidates found while parsing.
+func printScanStats(writer io.Writer, stats extra.ScanStats) {
+	_, _ = fmt.Fprintf(writer, `Steam:        %d
+Steam3:       %d
+Steam64:      %d
+Invalid:      %d
+Duplicates:   %d
+Unique:       %d
+`, stats.Formats[extra.FormatSteam], stats.Formats[extra.FormatSteam3], stats.Formats[extra.FormatSteam64],
+		stats.Invalid, stats.Duplicates, stats.Unique)
+}
+
 func init() {
 	rootCmd.AddCommand(parseCmd)
 
 	parseCmd.Flags().StringP("input", "i", "",
 		"Input text file to parse. Uses stdin if not specified.")
 	parseCmd.Flags().StringP("output", "o", "",
-		"Output results to a file.  Uses stdout if not specified.")
+		"Output results to a file, or sqlite:path to write the ids to a sqlite database. Uses stdout if not specified.")
 	parseCmd.Flags().StringP("format", "f", "%s\n",
 		"Output format to use. Applied to each ID.")
 	parseCmd.Flags().StringP("type", "t", "steam64",
-		"Output format for steam ids found (steam64, steam, steam3)")
+		"Output format for steam ids found (steam64, steam, steam3, steam32)")
+	parseCmd.Flags().Bool("stats", false,
+		"Print counts of the formats found, invalid candidates and duplicates to stderr")
+	parseCmd.Flags().BoolP("verbose", "v", false,
+		"Report the invalid ids and the number of lines without ids skipped on stderr")
+	parseCmd.Flags().Bool("html", false, "Strip html tags and decode entities before parsing")
+	parseCmd.Flags().Bool("no-dedupe", false, "Output every occurrence of each id instead of removing duplicates")
+	parseCmd.Flags().Bool("unique-by-account", false,
+		"Treat ids with the same account id but a different type, instance or universe as duplicates")
+	parseCmd.Flags().Int("workers", 0, "Number of files parsed concurrently, 0 for one per cpu")
+	parseCmd.Flags().Bool("mmap", false, "Map input files into memory instead of reading them, faster for very large files")
+	parseCmd.MarkFlagsMutuallyExclusive("no-dedupe", "unique-by-account")
+	completeFlag(parseCmd, "type", idTypes...)
 }
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/spf13/cobra"
+)
+
+// queryPlayerRecord is a player from an A2S_PLAYER query.
+type queryPlayerRecord struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+	// Duration is the time connected in seconds.
+	Duration int `json:"duration"`
+}
+
+func (r queryPlayerRecord) columns() []string {
+	return []string{"index", "name", "score", "duration"}
+}
+
+func (r queryPlayerRecord) values() []string {
+	return []string{strconv.Itoa(r.Index), r.Name, strconv.Itoa(r.Score), strconv.Itoa(r.Duration)}
+}
+
+// queryOutput is the json output of the query command.
+type queryOutput struct {
+	Name       string              `json:"name"`
+	Map        string              `json:"map"`
+	Game       string              `json:"game"`
+	AppID      int                 `json:"app_id"`
+	Players    int                 `json:"players"`
+	MaxPlayers int                 `json:"max_players"`
+	Bots       int                 `json:"bots"`
+	VAC        bool                `json:"vac"`
+	Password   bool                `json:"password"`
+	Version    string              `json:"version"`
+	Keywords   []string            `json:"keywords"`
+	SteamID    *conversion         `json:"steam_id"`
+	PlayerList []queryPlayerRecord `json:"player_list"`
+}
+
+// queryCmd queries a game server with A2S_INFO and A2S_PLAYER.
+var queryCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "query <ip:port>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Query a game server for its info and players without rcon",
+	Long: `Query a game server for its info and players without rcon.
+
+The server is queried with A2S_INFO and A2S_PLAYER over udp. The steam id of the game server
+is shown when the server reports it. A2S_PLAYER does not include the steam ids of the players,
+use the rcon command to see them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		info, errInfo := extra.QueryInfo(ctx, args[0])
+		if errInfo != nil {
+			fatalf(cmd, errorCode(errInfo, exitNetwork), "Failed to query server info: %v", errInfo)
+		}
+
+		players, errPlayers := extra.QueryPlayers(ctx, args[0])
+		if errPlayers != nil {
+			fatalf(cmd, errorCode(errPlayers, exitNetwork), "Failed to query server players: %v", errPlayers)
+		}
+
+		records := make([]queryPlayerRecord, 0, len(players))
+		for _, player := range players {
+			records = append(records, queryPlayerRecord{
+				Index:    player.Index,
+				Name:     player.Name,
+				Score:    player.Score,
+				Duration: int(player.Duration.Seconds()),
+			})
+		}
+
+		switch outputFormat(cmd) {
+		case outputText:
+		case outputJSON:
+			output := queryOutput{
+				Name:       info.Name,
+				Map:        info.Map,
+				Game:       info.Game,
+				AppID:      int(info.AppID),
+				Players:    info.Players,
+				MaxPlayers: info.MaxPlayers,
+				Bots:       info.Bots,
+				VAC:        info.VAC,
+				Password:   info.Password,
+				Version:    info.Version,
+				Keywords:   info.Keywords,
+				PlayerList: records,
+			}
+
+			if info.SteamID.Valid() {
+				serverID := newConversion(info.SteamID.String(), info.SteamID)
+				output.SteamID = &serverID
+			}
+
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+
+			if err := encoder.Encode(output); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+
+			os.Exit(0)
+		default:
+			if err := writeRecords(cmd, os.Stdout, records); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+
+			os.Exit(0)
+		}
+
+		serverID := ""
+		if info.SteamID.Valid() {
+			serverID = fmt.Sprintf("%s %s", info.SteamID.String(), info.SteamID.Steam3())
+		}
+
+		fmt.Printf(`Name:         %s
+Map:          %s
+Game:         %s (%d)
+Players:      %d/%d (%d bots)
+VAC:          %t
+Password:     %t
+Version:      %s
+Tags:         %s
+Server ID:    %s
+
+`, info.Name, info.Map, info.Game, info.AppID, info.Players, info.MaxPlayers, info.Bots, info.VAC, info.Password,
+			info.Version, strings.Join(info.Keywords, ","), serverID) //nolint:forbidigo
+
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(table, "NAME\tSCORE\tCONNECTED")
+
+		for _, player := range players {
+			_, _ = fmt.Fprintf(table, "%s\t%d\t%s\n", player.Name, player.Score, player.Duration.Truncate(time.Second))
+		}
+
+		if err := table.Flush(); err != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+		}
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(queryCmd)
+	queryCmd.Flags().Duration("timeout", time.Second*5, "Timeout of the queries")
+}
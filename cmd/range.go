@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// maxRangeRecords is the largest range written with --json, --csv, --tsv or --template, which are
+// buffered before they are written. Text output is streamed, so it has no limit.
+const maxRangeRecords = 1_000_000
+
+// parseRangeBound parses an account id, or any steam id format of an individual account, as a
+// bound of the range command.
+func parseRangeBound(value string) (uint32, bool) {
+	if accountID, errParse := strconv.ParseUint(value, 10, 32); errParse == nil {
+		return uint32(accountID), true
+	}
+
+	sid := steamid.New(value)
+	if !sid.Valid() {
+		return 0, false
+	}
+
+	return uint32(sid.AccountID), true
+}
+
+// rangeCmd writes every valid steam id of a range of account ids.
+var rangeCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "range <start> <end>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Write the steam ids of a range of account ids",
+	Long: `Write the steam ids of a range of account ids.
+
+Every valid id of the --type account type with an account id from start to end, inclusive,
+is written one per line in the --format id format. The bounds are account ids, or steam ids
+in any format whose account id is used. Pass the ids to the summary or bans commands,
+e.g. with xargs, to scan a range of accounts with the batch apis.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			typeName = strings.ToLower(cmd.Flag("type").Value.String())
+			idType   = strings.ToLower(cmd.Flag("format").Value.String())
+		)
+
+		start, validStart := parseRangeBound(args[0])
+		end, validEnd := parseRangeBound(args[1])
+
+		if !validStart || !validEnd {
+			fatalf(cmd, exitParse, "Invalid range, the bounds must be account ids or steam ids: %s %s", args[0], args[1])
+		}
+
+		if start > end {
+			fatalf(cmd, exitConfig, "The start of the range must not be greater than the end")
+		}
+
+		accountType, found := generateAccountTypes[typeName]
+		if !found {
+			fatalf(cmd, exitConfig, "Unknown type, must be one of individual, clan, gameserver, anongameserver: %s", typeName)
+		}
+
+		if !slices.Contains(idTypes, idType) {
+			fatalf(cmd, exitConfig, "Unknown format, must be one of steam, steam3, steam32, steam64: %s", idType)
+		}
+
+		if idType == "steam" && accountType != steamid.AccountTypeIndividual {
+			fatalf(cmd, exitConfig, "The steam format is only available for individual ids")
+		}
+
+		sids := steamid.Range(start, end, steamid.WithRangeAccountType(accountType))
+
+		if outputFormat(cmd) != outputText {
+			if uint64(end)-uint64(start) >= maxRangeRecords {
+				fatalf(cmd, exitConfig, "Ranges of more than %d ids can only be written as text", maxRangeRecords)
+			}
+
+			var conversions []conversion
+
+			sids(func(sid steamid.SteamID) bool {
+				conversions = append(conversions, newConversion(sid.String(), sid))
+
+				return true
+			})
+
+			if err := writeRecords(cmd, os.Stdout, conversions); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+
+			return
+		}
+
+		var (
+			writer   = bufio.NewWriter(os.Stdout)
+			errWrite error
+		)
+
+		sids(func(sid steamid.SteamID) bool {
+			_, errWrite = fmt.Fprintln(writer, formatID(sid, idType))
+
+			return errWrite == nil
+		})
+
+		if errWrite == nil {
+			errWrite = writer.Flush()
+		}
+
+		if errWrite != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", errWrite)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rangeCmd)
+	rangeCmd.Flags().String("type", "individual", "Account type of the ids (individual, clan, gameserver, anongameserver)")
+	rangeCmd.Flags().StringP("format", "f", "steam64", "Output format for the ids (steam64, steam, steam3, steam32)")
+	completeFlag(rangeCmd, "type", "individual", "clan", "gameserver", "anongameserver")
+	completeFlag(rangeCmd, "format", idTypes...)
+}
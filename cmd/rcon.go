@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// cacheProvider adapts the disk cache to the extra.PlayerDataProvider interface.
+type cacheProvider struct {
+	cache *diskCache
+}
+
+func (p cacheProvider) PlayerSummaries(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerSummary, error) {
+	return p.cache.playerSummaries(ctx, steamIDs)
+}
+
+func (p cacheProvider) PlayerBans(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerBanState, error) {
+	return p.cache.playerBans(ctx, steamIDs)
+}
+
+// enrichedPlayerRecord is a status player row joined with their profile summary and ban state.
+type enrichedPlayerRecord struct {
+	statusPlayerRecord
+	PersonaName     string `json:"persona_name"`
+	VACBans         int    `json:"vac_bans"`
+	GameBans        int    `json:"game_bans"`
+	CommunityBanned bool   `json:"community_banned"`
+	// Created is the RFC3339 account creation time, empty when the profile is private.
+	Created string `json:"created"`
+}
+
+func (r enrichedPlayerRecord) columns() []string {
+	return append(r.statusPlayerRecord.columns(), "persona_name", "vac_bans", "game_bans", "community_banned", "created")
+}
+
+func (r enrichedPlayerRecord) values() []string {
+	return append(r.statusPlayerRecord.values(), r.PersonaName, strconv.Itoa(r.VACBans), strconv.Itoa(r.GameBans),
+		strconv.FormatBool(r.CommunityBanned), r.Created)
+}
+
+func newEnrichedPlayerRecord(player extra.EnrichedPlayer) enrichedPlayerRecord {
+	record := enrichedPlayerRecord{
+		statusPlayerRecord: newStatusPlayerRecord(player.Player),
+		PersonaName:        player.PersonaName,
+		VACBans:            player.NumberOfVACBans,
+		GameBans:           player.NumberOfGameBans,
+		CommunityBanned:    player.CommunityBanned,
+	}
+
+	if !player.AccountCreated.IsZero() {
+		record.Created = player.AccountCreated.Format(time.RFC3339)
+	}
+
+	return record
+}
+
+// writeEnrichedPlayers writes the players joined with their summaries and bans in the selected
+// output format.
+func writeEnrichedPlayers(cmd *cobra.Command, players []extra.EnrichedPlayer) {
+	records := make([]enrichedPlayerRecord, 0, len(players))
+	for _, player := range players {
+		records = append(records, newEnrichedPlayerRecord(player))
+	}
+
+	if outputFormat(cmd) != outputText {
+		if err := writeRecords(cmd, os.Stdout, records); err != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+		}
+
+		return
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "USERID\tNAME\tSTEAM64\tPERSONA\tVAC\tGAME\tCOMMUNITY\tCREATED\tADDRESS")
+
+	for _, record := range records {
+		_, _ = fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%d\t%d\t%t\t%s\t%s\n", record.UserID, record.Name,
+			record.Steam64, record.PersonaName, record.VACBans, record.GameBans, record.CommunityBanned,
+			record.Created, record.Address)
+	}
+
+	if err := table.Flush(); err != nil {
+		fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+	}
+}
+
+// rconCmd runs status on a live server over rcon and prints its players.
+var rconCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "rcon",
+	Args:  cobra.NoArgs,
+	Short: "Show the players of a live server over rcon",
+	Long: `Show the players of a live server over rcon.
+
+Connects to the server with the rcon password, runs the status command and prints the players
+in the same format as the status command. With --enrich, the players are joined with their
+profile summaries and ban states, which requires a steam web api key.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		var (
+			addr     = cmd.Flag("addr").Value.String()
+			password = cmd.Flag("password").Value.String()
+		)
+
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		if addr == "" {
+			fatalf(cmd, exitConfig, "--addr must be provided")
+		}
+
+		client, errDial := extra.DialRCON(cmd.Context(), addr, password, extra.WithRCONTimeout(timeout))
+		if errDial != nil {
+			fatalf(cmd, errorCode(errDial, exitNetwork), "Failed to connect: %v", errDial)
+		}
+
+		status, errStatus := client.Status(cmd.Context())
+
+		_ = client.Close()
+
+		if errStatus != nil {
+			fatalf(cmd, errorCode(errStatus, exitNetwork), "Failed to fetch status: %v", errStatus)
+		}
+
+		if !cmd.Flag("enrich").Changed {
+			writeStatus(cmd, status)
+			os.Exit(0)
+		}
+
+		for _, warning := range status.Warnings {
+			log.Printf("Skipped invalid player row: %v", warning)
+		}
+
+		cache := mustOpenCache(cmd)
+
+		players, errEnrich := extra.EnrichPlayers(cmd.Context(), cacheProvider{cache: cache}, status.Players)
+		if errEnrich != nil {
+			fatalf(cmd, errorCode(errEnrich, exitNetwork), "Failed to enrich players: %v", errEnrich)
+		}
+
+		saveCache(cache)
+		writeEnrichedPlayers(cmd, players)
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rconCmd)
+	rconCmd.Flags().StringP("addr", "a", "", "Server rcon address, host:port")
+	rconCmd.Flags().StringP("password", "p", "", "Server rcon password (env STEAMID_RCON_PASSWORD)")
+	rconCmd.Flags().Duration("timeout", time.Second*10, "Timeout of the rcon connection")
+	rconCmd.Flags().BoolP("enrich", "e", false, "Include player summaries and ban states")
+}
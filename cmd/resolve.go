@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// resolveRecord is the result of resolving a single query in batch mode.
+type resolveRecord struct {
+	conversion
+	Error string `json:"error,omitempty"`
+}
+
+func (r resolveRecord) columns() []string {
+	return append(r.conversion.columns(), "error")
+}
+
+func (r resolveRecord) values() []string {
+	return append(r.conversion.values(), r.Error)
+}
+
+// readQueries reads the non-empty lines of stdin, trimming surrounding whitespace.
+func readQueries() ([]string, error) {
+	var (
+		queries []string
+		scanner = bufio.NewScanner(os.Stdin)
+	)
+
+	for scanner.Scan() {
+		if query := strings.TrimSpace(scanner.Text()); query != "" {
+			queries = append(queries, query)
+		}
+	}
+
+	return queries, scanner.Err() //nolint:wrapcheck
+}
+
+// resolveBatch resolves the queries read from stdin. Failures are written alongside the
+// successful results instead of stopping the batch.
+func resolveBatch(cmd *cobra.Command) {
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+	queries, errRead := readQueries()
+	if errRead != nil {
+		fatalf(cmd, exitFailure, "Failed to read stdin: %v", errRead)
+	}
+
+	client, stop := newBatchClient(cmd)
+	defer stop()
+
+	cache := mustOpenCache(cmd)
+	cache.client = client
+	cache.progress = newProgress(cmd, "resolve", "queries", len(queries))
+	results := cache.resolveAll(cmd.Context(), queries, concurrency)
+
+	cache.progress.finish()
+	saveCache(cache)
+
+	if dbPath, isSQLite := sqliteOutputPath(cmd); isSQLite {
+		var conversions []conversion
+
+		for _, result := range results {
+			if result.Err != nil {
+				log.Printf("Failed to resolve %s: %v", result.Query, result.Err)
+			} else {
+				conversions = append(conversions, newConversion(result.Query, result.SteamID))
+			}
+		}
+
+		writeSQLite(cmd, dbPath, func(out *sqliteOutput) error {
+			return out.writeIDs(conversions)
+		})
+
+		return
+	}
+
+	output, closeOutput := createOutput(cmd)
+	defer closeOutput()
+
+	if outputFormat(cmd) != outputText {
+		records := make([]resolveRecord, len(results))
+
+		for idx, result := range results {
+			if result.Err != nil {
+				records[idx] = resolveRecord{conversion: conversion{Input: result.Query}, Error: result.Err.Error()}
+			} else {
+				records[idx] = resolveRecord{conversion: newConversion(result.Query, result.SteamID)}
+			}
+		}
+
+		if err := writeRecords(cmd, output, records); err != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+		}
+
+		return
+	}
+
+	writer := bufio.NewWriter(output)
+
+	for _, result := range results {
+		if result.Err != nil {
+			_, _ = fmt.Fprintf(writer, "%s\t\t%s\n", result.Query, strings.ReplaceAll(result.Err.Error(), "\n", " "))
+		} else {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t\n", result.Query, result.SteamID.String())
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+	}
+}
+
+// resolveCmd resolves profile urls, vanity names and steam ids to a steam id.
+var resolveCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:     "resolve",
+	Aliases: []string{"r"},
+	Short:   "Resolve profile urls and vanity names to steam ids",
+	Long: `Resolve profile urls and vanity names to steam ids.
+
+Any of the steam id formats, profile urls (https://steamcommunity.com/profiles/...)
+and vanity urls or names (https://steamcommunity.com/id/...) are accepted. Resolving
+vanity names requires a steam web api key to be set with the STEAM_TOKEN environment variable.
+
+With --stdin, one query is read per line and the results are written in the same order
+as "input<TAB>steam64<TAB>error" lines. Queries that fail to resolve do not stop the batch.
+Progress is reported on stderr and web api requests are limited to --rate per second and
+--max-requests in total, queries beyond the limit fail. The results are written to the
+--output file, or with sqlite:path to the ids table of a sqlite database.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if stdin, _ := cmd.Flags().GetBool("stdin"); stdin {
+			resolveBatch(cmd)
+			os.Exit(0)
+		}
+
+		if len(args) == 0 {
+			fatalf(cmd, exitConfig, "Either queries or --stdin must be provided")
+		}
+
+		var (
+			cache       = mustOpenCache(cmd)
+			results     = cache.resolveAll(cmd.Context(), args, 1)
+			conversions = make([]conversion, 0, len(args))
+		)
+
+		saveCache(cache)
+
+		for _, result := range results {
+			if result.Err != nil {
+				fatalf(cmd, errorCode(result.Err, exitResolve), "Failed to resolve %s: %v", result.Query, result.Err)
+			}
+
+			conversions = append(conversions, newConversion(result.Query, result.SteamID))
+		}
+
+		if outputFormat(cmd) != outputText {
+			if err := writeRecords(cmd, os.Stdout, conversions); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+
+			os.Exit(0)
+		}
+
+		for _, conv := range conversions {
+			printAllConversions(steamid.New(conv.Steam64), false)
+		}
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resolveCmd)
+	resolveCmd.Flags().Bool("stdin", false, "Read queries from stdin, one per line")
+	resolveCmd.Flags().IntP("concurrency", "c", 4, "Number of queries resolved concurrently with --stdin")
+	resolveCmd.Flags().StringP("output", "o", "",
+		"Output file with --stdin, or sqlite:path to write a sqlite database. Uses stdout if not specified.")
+	addBatchFlags(resolveCmd, 0)
+}
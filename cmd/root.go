@@ -2,8 +2,8 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
-	"os"
 
 	"github.com/leighmacdonald/steamid/v4/steamid"
 	"github.com/spf13/cobra"
@@ -15,13 +15,39 @@ var rootCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
 	Short: "A library and CLI app to convert between steam id formats",
 	Long:  `A library and CLI app to convert between steam id formats`,
 	//	Run: func(cmd *cobra.Command, args []string) { },
-	Version: fmt.Sprintf("%s - %s - %s", steamid.BuildVersion, steamid.BuildCommit, steamid.BuildDate),
+	Version: steamid.BuildInfo().Version,
+	// Errors are written by Execute so they follow the exit code contract.
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+//
+// Commands exit with 0 on success, 2 for parse errors, 3 for resolution failures, 4 for network
+// errors, 5 for invalid flags or configuration and 1 for anything else.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		fatalf(cmd, errorCode(err, exitConfig), "Error: %v\nRun '%s --help' for usage.", err, cmd.CommandPath())
 	}
 }
+
+// versionText formats the build info written by --version, as a json object when --json is set.
+func versionText(cmd *cobra.Command) string {
+	build := steamid.BuildInfo()
+
+	if outputFormat(cmd) == outputJSON {
+		body, errMarshal := json.Marshal(build)
+		if errMarshal == nil {
+			return string(body)
+		}
+	}
+
+	return fmt.Sprintf("%s - %s - %s", build.Version, build.Commit, build.Date)
+}
+
+func init() {
+	cobra.AddTemplateFunc("versionText", versionText)
+	rootCmd.SetVersionTemplate("{{versionText .}}\n")
+}
@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/leighmacdonald/steamid/v4/steamid/httpbind"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// apiServer implements the http handlers of the serve command.
+type apiServer struct {
+	client    *steamid.Client
+	players   *extra.PlayerDataCache
+	ttl       time.Duration
+	metrics   *serveMetrics
+	auth      *serveAuth
+	maxBatch  int
+	health    *serveHealth
+	resolving *resolveCache
+}
+
+// newAPIServer returns the api of the client, caching results for ttl and keeping up to cacheSize
+// resolved queries, summaries and ban states each.
+func newAPIServer(client *steamid.Client, ttl time.Duration, cacheSize int, metrics *serveMetrics, auth *serveAuth,
+	maxBatch int,
+) *apiServer {
+	return &apiServer{
+		client:    client,
+		players:   extra.NewPlayerDataCache(client, ttl, extra.WithPlayerCacheSize(cacheSize)),
+		ttl:       ttl,
+		metrics:   metrics,
+		auth:      auth,
+		maxBatch:  maxBatch,
+		health:    &serveHealth{client: client},
+		resolving: newResolveCache(ttl, cacheSize),
+	}
+}
+
+func (s *apiServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /convert/{id}", s.metrics.instrument("/convert", s.onConvert))
+	mux.HandleFunc("POST /convert", s.metrics.instrument("/convert/batch", s.onConvertBatch))
+	mux.HandleFunc("GET /resolve/{query...}", s.metrics.instrument("/resolve", s.onResolve))
+	mux.HandleFunc("POST /resolve", s.metrics.instrument("/resolve/batch", s.onResolveBatch))
+	mux.HandleFunc("GET /summary/{id}", s.metrics.instrument("/summary", s.onSummary))
+
+	var api http.Handler = mux
+	if s.auth != nil {
+		api = s.auth.authenticate(mux)
+	}
+
+	// The probes and metrics are neither authenticated nor logged, as they are scraped every few
+	// seconds by clients that do not hold an api token.
+	root := http.NewServeMux()
+	root.HandleFunc("GET /healthz", s.health.onHealth)
+	root.HandleFunc("GET /readyz", s.health.onReady)
+	root.HandleFunc("GET /metrics", s.onMetrics)
+	root.Handle("/", logRequests(api))
+
+	return root
+}
+
+func (s *apiServer) onMetrics(w http.ResponseWriter, _ *http.Request) {
+	hits, misses := s.players.Stats()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.metrics.write(w, hits, misses)
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// errorStatus maps errors returned by the steam web api client to a http status code.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, steamid.ErrNoAPIKey):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, steamid.ErrAPI):
+		return http.StatusBadGateway
+	default:
+		return http.StatusNotFound
+	}
+}
+
+func (s *apiServer) onConvert(w http.ResponseWriter, r *http.Request) {
+	input := r.PathValue("id")
+
+	sid, errBind := httpbind.FromPath(r, "id")
+	if errBind != nil {
+		writeError(w, http.StatusBadRequest, errBind)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newConversion(input, sid))
+}
+
+func (s *apiServer) resolve(ctx context.Context, query string) (steamid.SteamID, error) {
+	if cached, found := s.resolving.get(query, time.Now()); found {
+		s.metrics.observeResolve(true)
+
+		return cached, nil
+	}
+
+	s.metrics.observeResolve(false)
+
+	sid, err := s.client.Resolve(ctx, query)
+	if err != nil {
+		return sid, err //nolint:wrapcheck
+	}
+
+	s.resolving.add(query, sid, time.Now())
+
+	return sid, nil
+}
+
+func (s *apiServer) onResolve(w http.ResponseWriter, r *http.Request) {
+	query := r.PathValue("query")
+
+	sid, err := s.resolve(r.Context(), query)
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newConversion(query, sid))
+}
+
+func (s *apiServer) onSummary(w http.ResponseWriter, r *http.Request) {
+	input := r.PathValue("id")
+
+	sid, errBind := httpbind.FromPath(r, "id")
+	if errBind != nil {
+		writeError(w, http.StatusBadRequest, errBind)
+
+		return
+	}
+
+	summaries, err := s.players.PlayerSummaries(r.Context(), steamid.Collection{sid})
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+
+		return
+	}
+
+	if len(summaries) == 0 {
+		writeError(w, http.StatusNotFound, steamid.ErrInvalidSID)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newSummaryRecord(input, summaries[0]))
+}
+
+// serveCmd runs a http api for steam id conversions and lookups.
+var serveCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "serve",
+	Short: "Run a HTTP api for steam id conversion and resolution",
+	Long: `Run a HTTP api for steam id conversion and resolution.
+
+Endpoints:
+  GET /convert/{id}       All representations of a steam id
+  POST /convert           Convert a batch of steam ids
+  GET /resolve/{query}    Resolve a url encoded profile url, vanity name or steam id
+  POST /resolve           Resolve a batch of profile urls, vanity names or steam ids
+  GET /summary/{id}       Profile summary of a steam id
+  GET /metrics            Prometheus metrics of the served and steam web api requests
+  GET /healthz            Liveness probe, ok while the process is running
+  GET /readyz             Readiness probe, ok when the api key is accepted by the steam web api
+
+Batch bodies are a json array of strings or one query per line, up to --max-batch
+queries. The results are returned in the order of the input, with an error for each
+query that failed.
+
+Resolve and summary results are cached for --cache-ttl, keeping up to --cache-size
+of each, and requests to the steam web api are rate limited. A steam web api
+key must be set using the STEAM_TOKEN environment variable for vanity names and summaries.
+
+When api tokens are listed under serve.tokens in the configuration file, every request
+other than /metrics, /healthz and /readyz must send one as "Authorization: Bearer <token>". Each token is rate limited to
+--client-rate requests per second with bursts of --client-burst, or its own rate and
+burst settings. Convert batches make no steam web api requests and are charged as a single
+request. Resolve batches, and the summaries and bans of the gRPC api, are charged one request
+for each query, and may take the token below zero so that its next requests wait until it is
+refilled. Every request is logged along with the name of its token.
+
+On SIGINT or SIGTERM, /readyz starts failing and the server stops accepting connections,
+waiting up to --shutdown-timeout for in-flight requests to finish before exiting.
+
+--listen accepts a tcp address, or a unix domain socket such as unix:///var/run/steamid.sock
+for sidecars that should not be reachable over the network.
+
+--grpc additionally serves the steamid.v1.SteamIDService gRPC service defined in
+proto/steamid/v1/steamid.proto on a tcp address or unix domain socket. It shares the caches,
+limits and api tokens of the http api, with tokens sent as "authorization: Bearer <token>"
+metadata.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		listen, _ := cmd.Flags().GetString("listen")
+		listenGRPC, _ := cmd.Flags().GetString("grpc")
+		shutdownTimeout, _ := cmd.Flags().GetDuration("shutdown-timeout")
+		ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+		rate, _ := cmd.Flags().GetFloat64("rate")
+		clientRate, _ := cmd.Flags().GetFloat64("client-rate")
+		clientBurst, _ := cmd.Flags().GetInt("client-burst")
+		maxBatch, _ := cmd.Flags().GetInt("max-batch")
+		cacheSize, _ := cmd.Flags().GetInt("cache-size")
+
+		if rate <= 0 {
+			fatalf(cmd, exitConfig, "Rate must be greater than 0")
+		}
+
+		if maxBatch <= 0 {
+			fatalf(cmd, exitConfig, "Max batch must be greater than 0")
+		}
+
+		if cacheSize <= 0 {
+			fatalf(cmd, exitConfig, "Cache size must be greater than 0")
+		}
+
+		cfg, errConfig := loadConfig(cmd)
+		if errConfig != nil {
+			fatalf(cmd, exitConfig, "Failed to load config: %v", errConfig)
+		}
+
+		var auth *serveAuth
+
+		if len(cfg.Serve.Tokens) > 0 {
+			tokenAuth, errAuth := newServeAuth(cfg.Serve.Tokens, clientRate, clientBurst)
+			if errAuth != nil {
+				fatalf(cmd, exitConfig, "Invalid serve tokens: %v", errAuth)
+			}
+
+			auth = tokenAuth
+		} else {
+			log.Printf("No api tokens configured, requests are not authenticated")
+		}
+
+		metrics := newServeMetrics()
+
+		client, stop := newRateLimitedClient(cmd, clientLimits{rate: rate, rejected: metrics.observeRateLimited},
+			steamid.WithRequestHook(metrics.observeAPIRequest))
+		defer stop()
+
+		api := newAPIServer(client, ttl, cacheSize, metrics, auth, maxBatch)
+
+		server := &http.Server{ //nolint:exhaustruct
+			Handler:           api.handler(),
+			ReadHeaderTimeout: time.Second * 10,
+		}
+
+		ctx, stopSignals := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stopSignals()
+
+		listener, errListen := openListener(ctx, listen)
+		if errListen != nil {
+			fatalf(cmd, errorCode(errListen, exitNetwork), "Failed to listen: %v", errListen)
+		}
+
+		errServe := make(chan error, 2)
+
+		go func() {
+			errServe <- server.Serve(listener)
+		}()
+
+		log.Printf("Listening on %s", listen)
+
+		var grpcServer *grpc.Server
+
+		if listenGRPC != "" {
+			grpcListener, errListenGRPC := openListener(ctx, listenGRPC)
+			if errListenGRPC != nil {
+				fatalf(cmd, errorCode(errListenGRPC, exitNetwork), "Failed to listen: %v", errListenGRPC)
+			}
+
+			grpcServer = newGRPCServer(api)
+
+			go func() {
+				errServe <- grpcServer.Serve(grpcListener)
+			}()
+
+			log.Printf("Listening for gRPC on %s", listenGRPC)
+		}
+
+		select {
+		case err := <-errServe:
+			fatalf(cmd, errorCode(err, exitNetwork), "Failed to serve: %v", err)
+		case <-ctx.Done():
+		}
+
+		log.Printf("Shutting down, waiting for in-flight requests")
+		api.health.draining.Store(true)
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		grpcStopped := make(chan struct{})
+
+		go func() {
+			defer close(grpcStopped)
+
+			if grpcServer != nil {
+				stopGRPC(shutdownCtx, grpcServer)
+			}
+		}()
+
+		errShutdown := server.Shutdown(shutdownCtx)
+		<-grpcStopped
+
+		if errShutdown != nil {
+			fatalf(cmd, exitFailure, "Failed to shut down gracefully: %v", errShutdown)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringP("listen", "l", ":8080", "Address to listen on, or unix:///path for a unix socket")
+	serveCmd.Flags().String("grpc", "", "Address to serve the gRPC api on, or unix:///path for a unix socket")
+	serveCmd.Flags().Duration("cache-ttl", time.Minute*10, "How long resolve and summary results are cached")
+	serveCmd.Flags().Int("cache-size", 10000, "Maximum number of resolve results, summaries and ban states each kept in the cache")
+	serveCmd.Flags().Float64("rate", 5, "Maximum requests per second made to the steam web api")
+	serveCmd.Flags().Float64("client-rate", 2, "Maximum requests per second accepted from each api token")
+	serveCmd.Flags().Int("client-burst", 20, "Requests each api token may make in a burst above --client-rate")
+	serveCmd.Flags().Int("max-batch", 5000, "Maximum number of queries in a batch request")
+	serveCmd.Flags().Duration("shutdown-timeout", time.Second*30, "How long to wait for in-flight requests when shutting down")
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errMissingToken = errors.New("missing api token, send it as Authorization: Bearer <token>")
+	errInvalidToken = errors.New("invalid api token")
+	errClientLimit  = errors.New("rate limit exceeded")
+)
+
+// serveToken is a static api token allowed to use the serve api, set in the configuration file.
+type serveToken struct {
+	// Name identifies the consumer in the request log.
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+	// Rate and Burst override the --client-rate and --client-burst limits of the token.
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// tokenBucket limits the requests of a single api token, allowing bursts of up to burst requests
+// which are refilled at rate per second.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// allow takes a request from the bucket, returning false along with the time until one is
+// available when it is empty.
+func (b *tokenBucket) allow(now time.Time) (bool, time.Duration) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	}
+
+	b.tokens--
+
+	return true, 0
+}
+
+// serveClient is a consumer of the serve api identified by its token.
+type serveClient struct {
+	name   string
+	token  []byte
+	bucket tokenBucket
+}
+
+// serveAuth authenticates the requests to the serve api with static tokens and rate limits each
+// token, so a single consumer can not exhaust the steam web api budget shared by all of them.
+type serveAuth struct {
+	mu      sync.Mutex
+	clients []*serveClient
+}
+
+// newServeAuth returns the authenticator of the tokens, using the default limits for tokens which
+// do not set their own. Tokens must be unique and not empty.
+func newServeAuth(tokens []serveToken, rate float64, burst int) (*serveAuth, error) {
+	var (
+		auth = &serveAuth{}
+		seen = map[string]bool{}
+		now  = time.Now()
+	)
+
+	for idx, token := range tokens {
+		if token.Token == "" {
+			return nil, fmt.Errorf("serve token %d has no token", idx+1)
+		}
+
+		if seen[token.Token] {
+			return nil, fmt.Errorf("serve token %d is a duplicate", idx+1)
+		}
+
+		seen[token.Token] = true
+
+		name := token.Name
+		if name == "" {
+			name = "token-" + strconv.Itoa(idx+1)
+		}
+
+		tokenRate, tokenBurst := rate, burst
+		if token.Rate > 0 {
+			tokenRate = token.Rate
+		}
+
+		if token.Burst > 0 {
+			tokenBurst = token.Burst
+		}
+
+		if tokenRate <= 0 || tokenBurst <= 0 {
+			return nil, fmt.Errorf("serve token %s must have a positive rate and burst", name)
+		}
+
+		auth.clients = append(auth.clients, &serveClient{
+			name:  name,
+			token: []byte(token.Token),
+			bucket: tokenBucket{
+				rate:   tokenRate,
+				burst:  float64(tokenBurst),
+				tokens: float64(tokenBurst),
+				last:   now,
+			},
+		})
+	}
+
+	return auth, nil
+}
+
+// client returns the client with the token. Every token is compared in constant time so the
+// response time does not reveal partial matches.
+func (a *serveAuth) client(token string) *serveClient {
+	var found *serveClient
+
+	for _, client := range a.clients {
+		if subtle.ConstantTimeCompare(client.token, []byte(token)) == 1 {
+			found = client
+		}
+	}
+
+	return found
+}
+
+// allow authenticates the token and takes a request from its bucket, returning its client. When
+// the limit of the token is exceeded errClientLimit is returned along with the time until a request
+// is available.
+func (a *serveAuth) allow(token string) (*serveClient, time.Duration, error) {
+	if token == "" {
+		return nil, 0, errMissingToken
+	}
+
+	client := a.client(token)
+	if client == nil {
+		return nil, 0, errInvalidToken
+	}
+
+	a.mu.Lock()
+	allowed, retryAfter := client.bucket.allow(time.Now())
+	a.mu.Unlock()
+
+	if !allowed {
+		return client, retryAfter, errClientLimit
+	}
+
+	return client, 0, nil
+}
+
+// charge takes n more requests from the bucket of the client authenticated for the context, so
+// batches are charged for each of their queries. The bucket may go below zero, delaying the next
+// requests of the client until it is refilled, so batches larger than the burst still succeed.
+func (a *serveAuth) charge(ctx context.Context, n int) {
+	client, found := ctx.Value(serveClientKey{}).(*serveClient)
+	if !found || n <= 0 {
+		return
+	}
+
+	a.mu.Lock()
+	client.bucket.tokens -= float64(n)
+	a.mu.Unlock()
+}
+
+// bearerToken returns the token of an Authorization header value.
+func bearerToken(header string) string {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
+
+// retryAfterSeconds formats the wait until a request is available for a Retry-After header.
+func retryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+}
+
+// clientNameKey is the context key of the name of the authenticated client, which logRequests
+// sets to a string pointer the authentication fills in.
+type clientNameKey struct{}
+
+// serveClientKey is the context key of the client authenticated for a request.
+type serveClientKey struct{}
+
+// withClient records the authenticated client, if any, for the request log and returns a context
+// holding it.
+func withClient(ctx context.Context, client *serveClient) context.Context {
+	if client == nil {
+		return ctx
+	}
+
+	if name, found := ctx.Value(clientNameKey{}).(*string); found {
+		*name = client.name
+	}
+
+	return context.WithValue(ctx, serveClientKey{}, client)
+}
+
+// authenticate wraps the handler, rejecting requests without a valid token with a 401 and those
+// exceeding the limit of their token with a 429.
+func (a *serveAuth) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, retryAfter, errAuth := a.allow(bearerToken(r.Header.Get("Authorization")))
+		ctx := withClient(r.Context(), client)
+
+		switch {
+		case errors.Is(errAuth, errMissingToken):
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errAuth)
+		case errors.Is(errAuth, errInvalidToken):
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeError(w, http.StatusUnauthorized, errAuth)
+		case errors.Is(errAuth, errClientLimit):
+			w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+			writeError(w, http.StatusTooManyRequests, errAuth)
+		default:
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+	})
+}
+
+// logRequests wraps the handler, logging every request along with the name of the client that
+// made it, if known, the response status and the time taken.
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			start    = time.Now()
+			recorder = &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			client   = "-"
+		)
+
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), clientNameKey{}, &client)))
+
+		log.Printf("%s %s %s %s %d %s", r.RemoteAddr, client, r.Method, r.URL.Path, recorder.status,
+			time.Since(start).Round(time.Microsecond))
+	})
+}
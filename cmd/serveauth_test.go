@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServeAuth(t *testing.T) {
+	t.Parallel()
+
+	for _, tokens := range [][]serveToken{
+		{{Name: "empty"}},
+		{{Token: "secret"}, {Token: "secret"}},
+	} {
+		_, errAuth := newServeAuth(tokens, 1, 1)
+		require.Error(t, errAuth, tokens)
+	}
+
+	_, errRate := newServeAuth([]serveToken{{Token: "secret"}}, 0, 1)
+	require.Error(t, errRate)
+
+	auth, errAuth := newServeAuth([]serveToken{{Token: "a"}, {Name: "b", Token: "b", Rate: 5, Burst: 10}}, 1, 2)
+	require.NoError(t, errAuth)
+	require.Equal(t, "token-1", auth.client("a").name)
+	require.InDelta(t, 2, auth.client("a").bucket.burst, 0)
+	require.InDelta(t, 10, auth.client("b").bucket.burst, 0)
+	require.Nil(t, auth.client("c"))
+}
+
+func TestTokenBucket(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now    = time.Now()
+		bucket = tokenBucket{rate: 2, burst: 2, tokens: 2, last: now}
+	)
+
+	for range 2 {
+		allowed, _ := bucket.allow(now)
+		require.True(t, allowed)
+	}
+
+	allowed, retryAfter := bucket.allow(now)
+	require.False(t, allowed)
+	require.Equal(t, time.Millisecond*500, retryAfter)
+
+	allowed, _ = bucket.allow(now.Add(time.Millisecond * 500))
+	require.True(t, allowed)
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	auth, errAuth := newServeAuth([]serveToken{{Name: "test", Token: "secret"}}, 0.5, 2)
+	require.NoError(t, errAuth)
+
+	api := newTestAPI(t, auth, 10)
+
+	missing := serveRequest(api, http.MethodGet, "/convert/[U:1:22202]", "", "")
+	require.Equal(t, http.StatusUnauthorized, missing.Code)
+	require.Equal(t, "Bearer", missing.Header().Get("WWW-Authenticate"))
+	require.Contains(t, missing.Body.String(), "missing api token")
+
+	invalid := serveRequest(api, http.MethodGet, "/convert/[U:1:22202]", "wrong", "")
+	require.Equal(t, http.StatusUnauthorized, invalid.Code)
+	require.Equal(t, `Bearer error="invalid_token"`, invalid.Header().Get("WWW-Authenticate"))
+
+	for range 2 {
+		require.Equal(t, http.StatusOK, serveRequest(api, http.MethodGet, "/convert/[U:1:22202]", "secret", "").Code)
+	}
+
+	limited := serveRequest(api, http.MethodGet, "/convert/[U:1:22202]", "secret", "")
+	require.Equal(t, http.StatusTooManyRequests, limited.Code)
+	require.Equal(t, "2", limited.Header().Get("Retry-After"))
+	require.Contains(t, limited.Body.String(), errClientLimit.Error())
+
+	// The probes and metrics do not require a token.
+	require.Equal(t, http.StatusOK, serveRequest(api, http.MethodGet, "/healthz", "", "").Code)
+	require.Equal(t, http.StatusOK, serveRequest(api, http.MethodGet, "/metrics", "", "").Code)
+}
+
+func TestBearerToken(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "secret", bearerToken("Bearer secret"))
+	require.Equal(t, "secret", bearerToken("bearer  secret "))
+	require.Empty(t, bearerToken("Basic c2VjcmV0"))
+	require.Empty(t, bearerToken("secret"))
+}
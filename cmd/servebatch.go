@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+const (
+	// maxBatchBody is the largest body accepted by the batch endpoints.
+	maxBatchBody = 8 << 20
+	// batchConcurrency is the number of queries of a batch resolved concurrently. Requests to the
+	// steam web api are still limited by --rate.
+	batchConcurrency = 8
+)
+
+var (
+	errBatchBody  = errors.New("invalid batch body, send a json array of strings or one query per line")
+	errBatchEmpty = errors.New("empty batch")
+	errBatchSize  = errors.New("too many queries in batch")
+)
+
+// batchResult is a single result of a batch request. The conversion is left out when the input
+// failed, and Error is set instead.
+type batchResult struct {
+	Input string `json:"input"`
+	*conversion
+	Error string `json:"error,omitempty"`
+}
+
+// isJSONArray reports whether the body is a json array rather than lines of queries, which may
+// also start with a [ when they are steam3 ids.
+func isJSONArray(body []byte) bool {
+	rest, found := bytes.CutPrefix(bytes.TrimSpace(body), []byte("["))
+	if !found {
+		return false
+	}
+
+	rest = bytes.TrimSpace(rest)
+
+	return len(rest) == 0 || rest[0] == '"' || rest[0] == ']'
+}
+
+// readBatch reads the queries of a batch request, either a json array of strings or a body with
+// one query per line. Surrounding whitespace and blank lines are ignored.
+func readBatch(w http.ResponseWriter, r *http.Request, maxQueries int) ([]string, error) {
+	body, errRead := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBatchBody))
+	if errRead != nil {
+		return nil, errors.Join(errRead, errBatchBody)
+	}
+
+	var queries []string
+
+	if isJSONArray(body) {
+		if errDecode := json.Unmarshal(body, &queries); errDecode != nil {
+			return nil, errors.Join(errDecode, errBatchBody)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(body))
+		scanner.Buffer(nil, maxBatchBody)
+
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				queries = append(queries, line)
+			}
+		}
+
+		if errScan := scanner.Err(); errScan != nil {
+			return nil, errors.Join(errScan, errBatchBody)
+		}
+	}
+
+	switch {
+	case len(queries) == 0:
+		return nil, errBatchEmpty
+	case len(queries) > maxQueries:
+		return nil, fmt.Errorf("%w: got %d, the limit is %d", errBatchSize, len(queries), maxQueries)
+	}
+
+	return queries, nil
+}
+
+// chargeBatch charges the api token of the request for every query of a batch, on top of the
+// request charged by the authentication. It is only used for batches requesting the steam web api,
+// convert batches are charged as a single request.
+func (s *apiServer) chargeBatch(ctx context.Context, queries int) {
+	if s.auth != nil {
+		s.auth.charge(ctx, queries-1)
+	}
+}
+
+// onConvertBatch converts every id in the body, returning the results in the order of the input.
+func (s *apiServer) onConvertBatch(w http.ResponseWriter, r *http.Request) {
+	inputs, errBatch := readBatch(w, r, s.maxBatch)
+	if errBatch != nil {
+		writeError(w, http.StatusBadRequest, errBatch)
+
+		return
+	}
+
+	results := make([]batchResult, len(inputs))
+
+	for idx, input := range inputs {
+		results[idx] = batchResult{Input: input}
+
+		sid := steamid.New(input)
+		if !sid.Valid() {
+			results[idx].Error = steamid.Error{Kind: steamid.ErrParse, Input: input, Err: steamid.ErrInvalidSID}.Error()
+
+			continue
+		}
+
+		converted := newConversion(input, sid)
+		results[idx].conversion = &converted
+	}
+
+	writeJSON(w, http.StatusOK, results)
+}
+
+// onResolveBatch resolves every query in the body, returning the results in the order of the
+// input. Results are cached the same way as those of /resolve.
+func (s *apiServer) onResolveBatch(w http.ResponseWriter, r *http.Request) {
+	queries, errBatch := readBatch(w, r, s.maxBatch)
+	if errBatch != nil {
+		writeError(w, http.StatusBadRequest, errBatch)
+
+		return
+	}
+
+	s.chargeBatch(r.Context(), len(queries))
+
+	var (
+		results = make([]batchResult, len(queries))
+		indexes = make(chan int)
+		wg      sync.WaitGroup
+	)
+
+	for range min(batchConcurrency, len(queries)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range indexes {
+				results[idx] = batchResult{Input: queries[idx]}
+
+				sid, errResolve := s.resolve(r.Context(), queries[idx])
+				if errResolve != nil {
+					results[idx].Error = errResolve.Error()
+
+					continue
+				}
+
+				converted := newConversion(queries[idx], sid)
+				results[idx].conversion = &converted
+			}
+		}()
+	}
+
+	for idx := range queries {
+		indexes <- idx
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	writeJSON(w, http.StatusOK, results)
+}
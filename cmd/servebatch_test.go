@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// serveRequest sends a request with the token, if any, to the handler of the api.
+func serveRequest(api *apiServer, method string, target string, token string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	recorder := httptest.NewRecorder()
+	api.handler().ServeHTTP(recorder, req)
+
+	return recorder
+}
+
+func TestBatchChargedPerQuery(t *testing.T) {
+	t.Parallel()
+
+	auth, errAuth := newServeAuth([]serveToken{{Name: "test", Token: "secret"}}, 0.01, 5)
+	require.NoError(t, errAuth)
+
+	api := newTestAPI(t, auth, 10)
+
+	// Convert batches make no steam web api requests, so they are charged once.
+	for range 2 {
+		convert := serveRequest(api, http.MethodPost, "/convert", "secret", "[U:1:1]\n[U:1:2]\n[U:1:3]\n[U:1:4]\n[U:1:5]\n[U:1:6]")
+		require.Equal(t, http.StatusOK, convert.Code)
+	}
+
+	// A resolve batch larger than the remaining burst is accepted, leaving the token in debt.
+	batch := serveRequest(api, http.MethodPost, "/resolve", "secret", "[U:1:1]\n[U:1:2]\n[U:1:3]\n[U:1:4]\n[U:1:5]\n[U:1:6]")
+	require.Equal(t, http.StatusOK, batch.Code)
+
+	limited := serveRequest(api, http.MethodGet, "/convert/[U:1:1]", "secret", "")
+	require.Equal(t, http.StatusTooManyRequests, limited.Code)
+	require.NotEmpty(t, limited.Header().Get("Retry-After"))
+}
+
+func TestConvertBatch(t *testing.T) {
+	t.Parallel()
+
+	api := newTestAPI(t, nil, 3)
+
+	for _, body := range []string{`["[U:1:22202]", "invalid"]`, "[U:1:22202]\n\n invalid \n"} {
+		resp := serveRequest(api, http.MethodPost, "/convert", "", body)
+		require.Equal(t, http.StatusOK, resp.Code, body)
+
+		var results []map[string]any
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &results))
+		require.Len(t, results, 2)
+		require.Equal(t, "76561197960287930", results[0]["steam64"])
+		require.Equal(t, "invalid", results[1]["input"])
+		require.NotEmpty(t, results[1]["error"])
+		require.NotContains(t, results[1], "steam64")
+	}
+}
+
+func TestResolveBatch(t *testing.T) {
+	t.Parallel()
+
+	resp := serveRequest(newTestAPI(t, nil, 3), http.MethodPost, "/resolve", "", `["example", "missing", "[U:1:22202]"]`)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var results []map[string]any
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &results))
+	require.Len(t, results, 3)
+	require.Equal(t, "76561197960287930", results[0]["steam64"])
+	require.NotEmpty(t, results[1]["error"])
+	require.Equal(t, "76561197960287930", results[2]["steam64"])
+}
+
+func TestBatchRejected(t *testing.T) {
+	t.Parallel()
+
+	api := newTestAPI(t, nil, 2)
+
+	for name, body := range map[string]string{
+		"empty":     " \n\n",
+		"oversized": `["[U:1:1]", "[U:1:2]", "[U:1:3]"]`,
+		"malformed": `["[U:1:1]", 2]`,
+		"truncated": `["[U:1:1]"`,
+		"too large": "[U:1:1]\n" + strings.Repeat(" ", maxBatchBody),
+	} {
+		for _, target := range []string{"/convert", "/resolve"} {
+			resp := serveRequest(api, http.MethodPost, target, "", body)
+			require.Equal(t, http.StatusBadRequest, resp.Code, name)
+			require.Contains(t, resp.Body.String(), `"error"`, name)
+		}
+	}
+}
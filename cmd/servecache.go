@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// cachedResolve is a resolved query held by resolveCache.
+type cachedResolve struct {
+	query   string
+	sid     steamid.SteamID
+	expires time.Time
+}
+
+// resolveCache holds the results of /resolve for ttl, keeping at most maxEntries of them. When it
+// is full the expired entries are removed, then the least recently used ones. It is safe for
+// concurrent use.
+type resolveCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// recent orders the entries from the most to the least recently used.
+	recent *list.List
+}
+
+func newResolveCache(ttl time.Duration, maxEntries int) *resolveCache {
+	return &resolveCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    map[string]*list.Element{},
+		recent:     list.New(),
+	}
+}
+
+// get returns the id the query resolved to, unless it is missing or expired.
+func (c *resolveCache) get(query string, now time.Time) (steamid.SteamID, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, found := c.entries[query]
+	if !found {
+		return steamid.SteamID{}, false
+	}
+
+	entry, _ := elem.Value.(*cachedResolve)
+	if !now.Before(entry.expires) {
+		c.remove(elem)
+
+		return steamid.SteamID{}, false
+	}
+
+	c.recent.MoveToFront(elem)
+
+	return entry.sid, true
+}
+
+// add caches the id the query resolved to, evicting entries when the cache is full.
+func (c *resolveCache) add(query string, sid steamid.SteamID, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, found := c.entries[query]; found {
+		entry, _ := elem.Value.(*cachedResolve)
+		entry.sid = sid
+		entry.expires = now.Add(c.ttl)
+		c.recent.MoveToFront(elem)
+
+		return
+	}
+
+	if len(c.entries) >= c.maxEntries {
+		c.removeExpired(now)
+	}
+
+	for len(c.entries) >= c.maxEntries {
+		c.remove(c.recent.Back())
+	}
+
+	c.entries[query] = c.recent.PushFront(&cachedResolve{query: query, sid: sid, expires: now.Add(c.ttl)})
+}
+
+// len returns the number of cached entries, including expired ones not yet removed.
+func (c *resolveCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+func (c *resolveCache) removeExpired(now time.Time) {
+	for elem := c.recent.Back(); elem != nil; {
+		prev := elem.Prev()
+
+		if entry, _ := elem.Value.(*cachedResolve); !now.Before(entry.expires) {
+			c.remove(elem)
+		}
+
+		elem = prev
+	}
+}
+
+func (c *resolveCache) remove(elem *list.Element) {
+	entry, _ := c.recent.Remove(elem).(*cachedResolve)
+	delete(c.entries, entry.query)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCache(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cache = newResolveCache(time.Minute, 2)
+		now   = time.Now()
+		sid1  = steamid.New("[U:1:1]")
+		sid2  = steamid.New("[U:1:2]")
+		sid3  = steamid.New("[U:1:3]")
+	)
+
+	cache.add("a", sid1, now)
+	cache.add("b", sid2, now)
+
+	// Using a keeps it over b, the least recently used entry.
+	cached, found := cache.get("a", now)
+	require.True(t, found)
+	require.Equal(t, sid1, cached)
+
+	cache.add("c", sid3, now)
+	require.Equal(t, 2, cache.len())
+
+	_, found = cache.get("b", now)
+	require.False(t, found)
+
+	_, found = cache.get("a", now)
+	require.True(t, found)
+
+	// Expired entries are not returned and are removed.
+	_, found = cache.get("a", now.Add(time.Minute))
+	require.False(t, found)
+	require.Equal(t, 1, cache.len())
+}
+
+func TestResolveCacheEvictsExpired(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cache = newResolveCache(time.Minute, 3)
+		now   = time.Now()
+	)
+
+	cache.add("old", steamid.New("[U:1:1]"), now)
+	cache.add("a", steamid.New("[U:1:2]"), now.Add(time.Second*30))
+	cache.add("b", steamid.New("[U:1:3]"), now.Add(time.Second*30))
+
+	// The expired entry is evicted before the least recently used one when the cache is full.
+	_, found := cache.get("old", now)
+	require.True(t, found)
+
+	later := now.Add(time.Second * 61)
+	cache.add("c", steamid.New("[U:1:4]"), later)
+	require.Equal(t, 3, cache.len())
+
+	for _, query := range []string{"a", "b", "c"} {
+		_, found = cache.get(query, later)
+		require.True(t, found, query)
+	}
+}
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	steamidv1 "github.com/leighmacdonald/steamid/v4/proto/steamid/v1"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer implements the steamid.v1 gRPC service of the serve command. It shares the caches,
+// limits and api tokens of the http api.
+type grpcServer struct {
+	steamidv1.UnimplementedSteamIDServiceServer
+
+	api *apiServer
+}
+
+// newGRPCServer returns a gRPC server of the service, authenticating requests with the api
+// tokens of the http api when they are set.
+func newGRPCServer(api *apiServer) *grpc.Server {
+	var (
+		unary  = []grpc.UnaryServerInterceptor{logUnary}
+		stream = []grpc.StreamServerInterceptor{logStream}
+	)
+
+	if api.auth != nil {
+		unary = append(unary, api.auth.authenticateUnary)
+		stream = append(stream, api.auth.authenticateStream)
+	}
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(unary...), grpc.ChainStreamInterceptor(stream...))
+	steamidv1.RegisterSteamIDServiceServer(server, &grpcServer{api: api})
+
+	return server
+}
+
+// stopGRPC waits for the in-flight calls of the server to finish, cancelling those still running
+// when the context is done.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+		<-stopped
+	}
+}
+
+// grpcStatus maps errors returned by the steam web api client to a gRPC status, the same way
+// errorStatus does for http.
+func grpcStatus(err error) error {
+	switch {
+	case errors.Is(err, steamid.ErrNoAPIKey):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, steamid.ErrAPI):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.NotFound, err.Error())
+	}
+}
+
+// newConversionMessage returns all representations of the id as a message.
+func newConversionMessage(input string, sid steamid.SteamID) *steamidv1.Conversion {
+	converted := newConversion(input, sid)
+
+	return &steamidv1.Conversion{
+		Input:   converted.Input,
+		Steam:   converted.Steam,
+		Steam3:  converted.Steam3,
+		Steam32: converted.Steam32,
+		Steam64: sid.Uint64(),
+	}
+}
+
+// parseIDs parses the ids of a request, returning them de-duplicated in the order of the input
+// along with the input of each id. Requests with an invalid id or more than maxBatch ids fail.
+func parseIDs(inputs []string, maxBatch int) (steamid.Collection, map[steamid.SteamID]string, error) {
+	switch {
+	case len(inputs) == 0:
+		return nil, nil, status.Error(codes.InvalidArgument, errBatchEmpty.Error())
+	case len(inputs) > maxBatch:
+		return nil, nil, status.Error(codes.InvalidArgument,
+			fmt.Errorf("%w: got %d, the limit is %d", errBatchSize, len(inputs), maxBatch).Error())
+	}
+
+	var (
+		steamIDs = make(steamid.Collection, 0, len(inputs))
+		sidInput = make(map[steamid.SteamID]string, len(inputs))
+	)
+
+	for _, input := range inputs {
+		sid := steamid.New(input)
+		if !sid.Valid() {
+			return nil, nil, status.Error(codes.InvalidArgument,
+				steamid.Error{Kind: steamid.ErrParse, Input: input, Err: steamid.ErrInvalidSID}.Error())
+		}
+
+		if _, found := sidInput[sid]; found {
+			continue
+		}
+
+		steamIDs = append(steamIDs, sid)
+		sidInput[sid] = input
+	}
+
+	return steamIDs, sidInput, nil
+}
+
+func (g *grpcServer) Convert(_ context.Context, req *steamidv1.ConvertRequest) (*steamidv1.Conversion, error) {
+	sid := steamid.New(req.GetId())
+	if !sid.Valid() {
+		return nil, status.Error(codes.InvalidArgument,
+			steamid.Error{Kind: steamid.ErrParse, Input: req.GetId(), Err: steamid.ErrInvalidSID}.Error())
+	}
+
+	return newConversionMessage(req.GetId(), sid), nil
+}
+
+func (g *grpcServer) Resolve(ctx context.Context, req *steamidv1.ResolveRequest) (*steamidv1.Conversion, error) {
+	sid, err := g.api.resolve(ctx, req.GetQuery())
+	if err != nil {
+		return nil, grpcStatus(err)
+	}
+
+	return newConversionMessage(req.GetQuery(), sid), nil
+}
+
+func (g *grpcServer) Summaries(req *steamidv1.SteamIDsRequest, stream grpc.ServerStreamingServer[steamidv1.PlayerSummary]) error {
+	steamIDs, sidInput, errIDs := parseIDs(req.GetIds(), g.api.maxBatch)
+	if errIDs != nil {
+		return errIDs
+	}
+
+	g.api.chargeBatch(stream.Context(), len(req.GetIds()))
+
+	summaries, err := g.api.players.PlayerSummaries(stream.Context(), steamIDs)
+	if err != nil {
+		return grpcStatus(err)
+	}
+
+	found := make(map[steamid.SteamID]steamid.PlayerSummary, len(summaries))
+	for _, summary := range summaries {
+		found[summary.SteamID] = summary
+	}
+
+	for _, sid := range steamIDs {
+		summary, ok := found[sid]
+		if !ok {
+			continue
+		}
+
+		if errSend := stream.Send(newSummaryMessage(sidInput[sid], summary)); errSend != nil {
+			return errSend //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func (g *grpcServer) Bans(req *steamidv1.SteamIDsRequest, stream grpc.ServerStreamingServer[steamidv1.PlayerBanState]) error {
+	steamIDs, sidInput, errIDs := parseIDs(req.GetIds(), g.api.maxBatch)
+	if errIDs != nil {
+		return errIDs
+	}
+
+	g.api.chargeBatch(stream.Context(), len(req.GetIds()))
+
+	bans, err := g.api.players.PlayerBans(stream.Context(), steamIDs)
+	if err != nil {
+		return grpcStatus(err)
+	}
+
+	found := make(map[steamid.SteamID]steamid.PlayerBanState, len(bans))
+	for _, ban := range bans {
+		found[ban.SteamID] = ban
+	}
+
+	for _, sid := range steamIDs {
+		ban, ok := found[sid]
+		if !ok {
+			continue
+		}
+
+		if errSend := stream.Send(newBanMessage(sidInput[sid], ban)); errSend != nil {
+			return errSend //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+func newSummaryMessage(input string, summary steamid.PlayerSummary) *steamidv1.PlayerSummary {
+	return &steamidv1.PlayerSummary{
+		SteamId:     newConversionMessage(input, summary.SteamID),
+		PersonaName: summary.PersonaName,
+		RealName:    summary.RealName,
+		ProfileUrl:  summary.ProfileURL,
+		AvatarUrl:   summary.AvatarFull,
+		CountryCode: summary.LocCountryCode,
+		Visibility:  int32(summary.CommunityVisibilityState), //nolint:gosec
+		TimeCreated: summary.TimeCreated,
+	}
+}
+
+func newBanMessage(input string, ban steamid.PlayerBanState) *steamidv1.PlayerBanState {
+	return &steamidv1.PlayerBanState{
+		SteamId:          newConversionMessage(input, ban.SteamID),
+		CommunityBanned:  ban.CommunityBanned,
+		VacBanned:        ban.VACBanned,
+		NumberOfVacBans:  int32(ban.NumberOfVACBans),  //nolint:gosec
+		DaysSinceLastBan: int32(ban.DaysSinceLastBan), //nolint:gosec
+		NumberOfGameBans: int32(ban.NumberOfGameBans), //nolint:gosec
+		EconomyBan:       ban.EconomyBan,
+	}
+}
+
+// authenticateGRPC authenticates the token of the authorization metadata of a gRPC call, returning
+// a context holding its client. It fails with Unauthenticated without a valid token and
+// ResourceExhausted, along with a retry-after header, when the limit of the token is exceeded.
+func (a *serveAuth) authenticateGRPC(ctx context.Context) (context.Context, error) {
+	var token string
+
+	if md, found := metadata.FromIncomingContext(ctx); found {
+		if values := md.Get("authorization"); len(values) > 0 {
+			token = bearerToken(values[0])
+		}
+	}
+
+	client, retryAfter, errAuth := a.allow(token)
+	ctx = withClient(ctx, client)
+
+	switch {
+	case errors.Is(errAuth, errMissingToken), errors.Is(errAuth, errInvalidToken):
+		return ctx, status.Error(codes.Unauthenticated, errAuth.Error())
+	case errors.Is(errAuth, errClientLimit):
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfterSeconds(retryAfter)))
+
+		return ctx, status.Error(codes.ResourceExhausted, errAuth.Error())
+	default:
+		return ctx, nil
+	}
+}
+
+func (a *serveAuth) authenticateUnary(ctx context.Context, req any, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, errAuth := a.authenticateGRPC(ctx)
+	if errAuth != nil {
+		return nil, errAuth
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *serveAuth) authenticateStream(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, errAuth := a.authenticateGRPC(stream.Context())
+	if errAuth != nil {
+		return errAuth
+	}
+
+	return handler(srv, contextStream{ServerStream: stream, ctx: ctx})
+}
+
+// contextStream is a stream with the context of the interceptors, such as the name of the client
+// filled in by the authentication.
+type contextStream struct {
+	grpc.ServerStream
+
+	ctx context.Context //nolint:containedctx
+}
+
+func (s contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// logCall logs a gRPC call the same way logRequests logs http requests.
+func logCall(ctx context.Context, client string, method string, start time.Time, err error) {
+	remote := "-"
+	if info, found := peer.FromContext(ctx); found && info.Addr != nil {
+		remote = info.Addr.String()
+	}
+
+	log.Printf("%s %s GRPC %s %s %s", remote, client, method, status.Code(err),
+		time.Since(start).Round(time.Microsecond))
+}
+
+func logUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var (
+		start  = time.Now()
+		client = "-"
+	)
+
+	resp, err := handler(context.WithValue(ctx, clientNameKey{}, &client), req)
+	logCall(ctx, client, info.FullMethod, start, err)
+
+	return resp, err
+}
+
+func logStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	var (
+		start  = time.Now()
+		client = "-"
+	)
+
+	err := handler(srv, contextStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), clientNameKey{}, &client)})
+	logCall(stream.Context(), client, info.FullMethod, start, err)
+
+	return err
+}
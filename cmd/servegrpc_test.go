@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	steamidv1 "github.com/leighmacdonald/steamid/v4/proto/steamid/v1"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/leighmacdonald/steamid/v4/steamid/steamidtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGRPC serves the gRPC api of the server over an in-memory connection, returning a client
+// of it.
+func newTestGRPC(t *testing.T, api *apiServer) steamidv1.SteamIDServiceClient {
+	t.Helper()
+
+	var (
+		listener = bufconn.Listen(1 << 20)
+		server   = newGRPCServer(api)
+	)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn, errConn := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, errConn)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return steamidv1.NewSteamIDServiceClient(conn)
+}
+
+// newTestAPI returns an api server of a fake steam web api with a single profile.
+func newTestAPI(t *testing.T, auth *serveAuth, maxBatch int) *apiServer {
+	t.Helper()
+
+	webAPI := steamidtest.NewServer(t)
+	webAPI.AddVanity("example", steamid.New(76561197960287930))
+	webAPI.AddSummary(steamid.PlayerSummary{SteamID: steamid.New(76561197960287930), PersonaName: "Rabscuttle"})
+	webAPI.AddBans(steamid.PlayerBanState{SteamID: steamid.New(76561197960287930), NumberOfVACBans: 2})
+
+	return newAPIServer(webAPI.Client(t), time.Minute, 100, newServeMetrics(), auth, maxBatch)
+}
+
+func TestGRPCConvert(t *testing.T) {
+	t.Parallel()
+
+	client := newTestGRPC(t, newTestAPI(t, nil, 10))
+
+	conversion, errConvert := client.Convert(context.Background(), &steamidv1.ConvertRequest{Id: "[U:1:22202]"})
+	require.NoError(t, errConvert)
+	require.Equal(t, "[U:1:22202]", conversion.GetInput())
+	require.Equal(t, "STEAM_0:0:11101", conversion.GetSteam())
+	require.Equal(t, uint32(22202), conversion.GetSteam32())
+	require.Equal(t, uint64(76561197960287930), conversion.GetSteam64())
+
+	_, errInvalid := client.Convert(context.Background(), &steamidv1.ConvertRequest{Id: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(errInvalid))
+}
+
+func TestGRPCResolve(t *testing.T) {
+	t.Parallel()
+
+	client := newTestGRPC(t, newTestAPI(t, nil, 10))
+
+	conversion, errResolve := client.Resolve(context.Background(), &steamidv1.ResolveRequest{Query: "example"})
+	require.NoError(t, errResolve)
+	require.Equal(t, "example", conversion.GetInput())
+	require.Equal(t, uint64(76561197960287930), conversion.GetSteam64())
+
+	_, errMissing := client.Resolve(context.Background(), &steamidv1.ResolveRequest{Query: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(errMissing))
+}
+
+func TestGRPCSummariesAndBans(t *testing.T) {
+	t.Parallel()
+
+	client := newTestGRPC(t, newTestAPI(t, nil, 2))
+	ids := []string{"STEAM_0:0:11101", "[U:1:1]"}
+
+	summaries, errSummaries := client.Summaries(context.Background(), &steamidv1.SteamIDsRequest{Ids: ids})
+	require.NoError(t, errSummaries)
+
+	summary, errRecv := summaries.Recv()
+	require.NoError(t, errRecv)
+	require.Equal(t, "Rabscuttle", summary.GetPersonaName())
+	require.Equal(t, "STEAM_0:0:11101", summary.GetSteamId().GetInput())
+
+	_, errEnd := summaries.Recv()
+	require.ErrorIs(t, errEnd, io.EOF)
+
+	bans, errBans := client.Bans(context.Background(), &steamidv1.SteamIDsRequest{Ids: ids})
+	require.NoError(t, errBans)
+
+	ban, errRecvBan := bans.Recv()
+	require.NoError(t, errRecvBan)
+	require.Equal(t, int32(2), ban.GetNumberOfVacBans())
+
+	_, errEndBans := bans.Recv()
+	require.ErrorIs(t, errEndBans, io.EOF)
+
+	for _, invalid := range [][]string{nil, {"invalid"}, {"[U:1:1]", "[U:1:2]", "[U:1:3]"}} {
+		stream, errStream := client.Summaries(context.Background(), &steamidv1.SteamIDsRequest{Ids: invalid})
+		require.NoError(t, errStream)
+
+		_, errInvalid := stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(errInvalid), invalid)
+	}
+}
+
+func TestGRPCAuthentication(t *testing.T) {
+	t.Parallel()
+
+	auth, errAuth := newServeAuth([]serveToken{{Name: "test", Token: "secret"}}, 1, 1)
+	require.NoError(t, errAuth)
+
+	client := newTestGRPC(t, newTestAPI(t, auth, 10))
+	request := &steamidv1.ConvertRequest{Id: "[U:1:22202]"}
+
+	_, errMissing := client.Convert(context.Background(), request)
+	require.Equal(t, codes.Unauthenticated, status.Code(errMissing))
+
+	invalidCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong")
+	_, errInvalid := client.Convert(invalidCtx, request)
+	require.Equal(t, codes.Unauthenticated, status.Code(errInvalid))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret")
+	_, errConvert := client.Convert(ctx, request)
+	require.NoError(t, errConvert)
+
+	var header metadata.MD
+
+	_, errLimit := client.Convert(ctx, request, grpc.Header(&header))
+	require.Equal(t, codes.ResourceExhausted, status.Code(errLimit))
+	require.Equal(t, []string{"1"}, header.Get("retry-after"))
+
+	stream, errStream := client.Summaries(context.Background(), &steamidv1.SteamIDsRequest{Ids: []string{"[U:1:22202]"}})
+	require.NoError(t, errStream)
+
+	_, errRecv := stream.Recv()
+	require.Equal(t, codes.Unauthenticated, status.Code(errRecv))
+}
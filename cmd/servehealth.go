@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+const (
+	// readyCheckInterval is how long the result of a readiness check is reused, so frequent probes
+	// do not spend the steam web api budget.
+	readyCheckInterval = time.Second * 30
+	// readyCheckTimeout limits the steam web api request of a readiness check.
+	readyCheckTimeout = time.Second * 5
+)
+
+// readyProbeID is the public profile fetched to check the api key and the steam web api.
+var readyProbeID = steamid.New(76561197960287930) //nolint:gochecknoglobals
+
+// serveHealth reports the liveness and readiness of the serve api.
+type serveHealth struct {
+	client   *steamid.Client
+	draining atomic.Bool
+
+	mu      sync.Mutex
+	checked time.Time
+	err     error
+}
+
+// check fetches a profile summary to confirm the api key is accepted and the steam web api is
+// reachable, reusing the previous result for readyCheckInterval.
+func (h *serveHealth) check(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.checked.IsZero() && time.Since(h.checked) < readyCheckInterval {
+		return h.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readyCheckTimeout)
+	defer cancel()
+
+	_, errCheck := h.client.PlayerSummaries(ctx, steamid.Collection{readyProbeID})
+	if errCheck != nil && ctx.Err() != nil && !h.checked.IsZero() {
+		// The probe gave up before the check finished, which says nothing about the api.
+		return errCheck //nolint:wrapcheck
+	}
+
+	h.checked = time.Now()
+	h.err = errCheck
+
+	return errCheck //nolint:wrapcheck
+}
+
+// onHealth reports that the process is alive.
+func (h *serveHealth) onHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// onReady reports whether requests can be served, which requires a valid api key and a reachable
+// steam web api. It fails once shutdown has started so no new traffic is routed to the server.
+func (h *serveHealth) onReady(w http.ResponseWriter, r *http.Request) {
+	if h.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "shutting down"})
+
+		return
+	}
+
+	if errCheck := h.check(r.Context()); errCheck != nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "not ready", "error": errCheck.Error()})
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	_ "github.com/glebarez/go-sqlite" // registers the sqlite database/sql driver
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// sqliteOutputPrefix selects the sqlite output when used as the prefix of --output.
+const sqliteOutputPrefix = "sqlite:"
+
+// sqliteSchema is the schema of the sqlite output. Every table is keyed by steam64, so writing
+// to an existing database replaces the previous results of the same ids.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS ids (
+    steam64      INTEGER PRIMARY KEY,
+    steam        TEXT NOT NULL,
+    steam3       TEXT NOT NULL,
+    steam32      INTEGER NOT NULL,
+    account_type TEXT NOT NULL,
+    input        TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS summaries (
+    steam64           INTEGER PRIMARY KEY,
+    persona_name      TEXT NOT NULL,
+    real_name         TEXT NOT NULL,
+    profile_url       TEXT NOT NULL,
+    avatar_full       TEXT NOT NULL,
+    visibility        INTEGER NOT NULL,
+    profile_state     INTEGER NOT NULL,
+    country_code      TEXT NOT NULL,
+    state_code        TEXT NOT NULL,
+    time_created      INTEGER NOT NULL,
+    last_logoff       INTEGER NOT NULL,
+    primary_clan_id   TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS bans (
+    steam64             INTEGER PRIMARY KEY,
+    community_banned    INTEGER NOT NULL,
+    vac_banned          INTEGER NOT NULL,
+    vac_bans            INTEGER NOT NULL,
+    game_bans           INTEGER NOT NULL,
+    days_since_last_ban INTEGER NOT NULL,
+    economy_ban         TEXT NOT NULL
+);`
+
+// sqliteOutputPath returns the database path when --output uses the sqlite: prefix.
+func sqliteOutputPath(cmd *cobra.Command) (string, bool) {
+	return strings.CutPrefix(cmd.Flag("output").Value.String(), sqliteOutputPrefix)
+}
+
+// sqliteOutput writes results to a sqlite database in a single transaction.
+type sqliteOutput struct {
+	db *sql.DB
+	tx *sql.Tx
+}
+
+// openSQLiteOutput opens or creates the database and its tables, exiting on failure.
+func openSQLiteOutput(cmd *cobra.Command, path string) *sqliteOutput {
+	db, errOpen := sql.Open("sqlite", path)
+	if errOpen != nil {
+		fatalf(cmd, exitFailure, "Failed to open database (%s): %v", path, errOpen)
+	}
+
+	if _, errSchema := db.ExecContext(cmd.Context(), sqliteSchema); errSchema != nil {
+		_ = db.Close()
+		fatalf(cmd, exitFailure, "Failed to create database tables (%s): %v", path, errSchema)
+	}
+
+	tx, errTx := db.BeginTx(cmd.Context(), nil)
+	if errTx != nil {
+		_ = db.Close()
+		fatalf(cmd, exitFailure, "Failed to write database (%s): %v", path, errTx)
+	}
+
+	return &sqliteOutput{db: db, tx: tx}
+}
+
+// writeIDs inserts the conversions, skipping the ones without a valid steam id.
+func (o *sqliteOutput) writeIDs(conversions []conversion) error {
+	stmt, errPrepare := o.tx.Prepare(`INSERT OR REPLACE INTO ids (steam64, steam, steam3, steam32, account_type, input)
+		VALUES (?, ?, ?, ?, ?, ?)`)
+	if errPrepare != nil {
+		return errPrepare //nolint:wrapcheck
+	}
+
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, conv := range conversions {
+		sid := steamid.New(conv.Steam64)
+		if !sid.Valid() {
+			continue
+		}
+
+		if _, errExec := stmt.Exec(sid, conv.Steam, conv.Steam3, conv.Steam32, sid.AccountType.String(), conv.Input); errExec != nil {
+			return errExec //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// writeSummaries inserts the player summaries.
+func (o *sqliteOutput) writeSummaries(summaries []steamid.PlayerSummary) error {
+	stmt, errPrepare := o.tx.Prepare(`INSERT OR REPLACE INTO summaries (steam64, persona_name, real_name, profile_url,
+		avatar_full, visibility, profile_state, country_code, state_code, time_created, last_logoff, primary_clan_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if errPrepare != nil {
+		return errPrepare //nolint:wrapcheck
+	}
+
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, s := range summaries {
+		if _, errExec := stmt.Exec(s.SteamID, s.PersonaName, s.RealName, s.ProfileURL, s.AvatarFull,
+			s.CommunityVisibilityState, s.ProfileState, s.LocCountryCode, s.LocStateCode, s.TimeCreated,
+			s.LastLogoff, s.PrimaryClanID); errExec != nil {
+			return errExec //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// writeBans inserts the player ban states.
+func (o *sqliteOutput) writeBans(bans []steamid.PlayerBanState) error {
+	stmt, errPrepare := o.tx.Prepare(`INSERT OR REPLACE INTO bans (steam64, community_banned, vac_banned, vac_bans,
+		game_bans, days_since_last_ban, economy_ban) VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if errPrepare != nil {
+		return errPrepare //nolint:wrapcheck
+	}
+
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, b := range bans {
+		if _, errExec := stmt.Exec(b.SteamID, b.CommunityBanned, b.VACBanned, b.NumberOfVACBans, b.NumberOfGameBans,
+			b.DaysSinceLastBan, b.EconomyBan); errExec != nil {
+			return errExec //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// close commits the results and closes the database.
+func (o *sqliteOutput) close() error {
+	errCommit := o.tx.Commit()
+
+	return errors.Join(errCommit, o.db.Close())
+}
+
+// writeSQLite opens the database, writes the results using write and commits them, exiting on failure.
+func writeSQLite(cmd *cobra.Command, path string, write func(out *sqliteOutput) error) {
+	out := openSQLiteOutput(cmd, path)
+
+	if errWrite := write(out); errWrite != nil {
+		_ = out.tx.Rollback()
+		_ = out.db.Close()
+		fatalf(cmd, exitFailure, "Failed to write database (%s): %v", path, errWrite)
+	}
+
+	if errClose := out.close(); errClose != nil {
+		fatalf(cmd, exitFailure, "Failed to write database (%s): %v", path, errClose)
+	}
+}
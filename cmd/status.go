@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/spf13/cobra"
+)
+
+// statusPlayerRecord is a player row of a status dump along with all the representations of its
+// steam id.
+type statusPlayerRecord struct {
+	conversion
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	// Connected is the time connected in seconds.
+	Connected int    `json:"connected"`
+	Ping      int    `json:"ping"`
+	Loss      int    `json:"loss"`
+	State     string `json:"state"`
+	// Address is the ip:port of the player, empty when the dump does not include addresses.
+	Address string `json:"address"`
+}
+
+func (r statusPlayerRecord) columns() []string {
+	return append(r.conversion.columns(), "user_id", "name", "connected", "ping", "loss", "state", "address")
+}
+
+func (r statusPlayerRecord) values() []string {
+	return append(r.conversion.values(), strconv.Itoa(r.UserID), r.Name, strconv.Itoa(r.Connected),
+		strconv.Itoa(r.Ping), strconv.Itoa(r.Loss), r.State, r.Address)
+}
+
+// statusOutput is the json output of the status command.
+type statusOutput struct {
+	ServerName string               `json:"server_name"`
+	Address    string               `json:"address"`
+	Version    string               `json:"version"`
+	Map        string               `json:"map"`
+	Tags       []string             `json:"tags"`
+	PlayersMax int                  `json:"players_max"`
+	Players    []statusPlayerRecord `json:"players"`
+}
+
+func newStatusPlayerRecord(player extra.Player) statusPlayerRecord {
+	record := statusPlayerRecord{
+		conversion: newConversion(string(player.SID.Steam3()), player.SID),
+		UserID:     player.UserID,
+		Name:       player.Name,
+		Connected:  int(player.ConnectedTime.Seconds()),
+		Ping:       player.Ping,
+		Loss:       player.Loss,
+		State:      player.State,
+	}
+
+	if player.IP != nil {
+		record.Address = fmt.Sprintf("%s:%d", player.IP, player.Port)
+	}
+
+	return record
+}
+
+// parseStatusDump parses a status dump, detecting whether the player rows include addresses.
+// Rows that fail to parse are skipped and reported as warnings.
+func parseStatusDump(text string) (extra.Status, error) {
+	status, errStatus := extra.ParseStatus(text, true, extra.WithPartial())
+	if errStatus == nil && len(status.Players) > 0 {
+		return status, nil
+	}
+
+	return extra.ParseStatus(text, false, extra.WithPartial()) //nolint:wrapcheck
+}
+
+// writeStatus writes the server info and players of a parsed status in the selected output format.
+func writeStatus(cmd *cobra.Command, status extra.Status) {
+	for _, warning := range status.Warnings {
+		log.Printf("Skipped invalid player row: %v", warning)
+	}
+
+	players := make([]statusPlayerRecord, 0, len(status.Players))
+	for _, player := range status.Players {
+		players = append(players, newStatusPlayerRecord(player))
+	}
+
+	address := ""
+	if status.IP != nil {
+		address = fmt.Sprintf("%s:%d", status.IP, status.Port)
+	}
+
+	switch outputFormat(cmd) {
+	case outputText:
+	case outputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(statusOutput{
+			ServerName: status.ServerName,
+			Address:    address,
+			Version:    status.Version,
+			Map:        status.Map,
+			Tags:       status.Tags,
+			PlayersMax: status.PlayersMax,
+			Players:    players,
+		}); err != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+		}
+
+		return
+	default:
+		if err := writeRecords(cmd, os.Stdout, players); err != nil {
+			fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+		}
+
+		return
+	}
+
+	fmt.Printf(`Hostname:     %s
+Address:      %s
+Version:      %s
+Map:          %s
+Tags:         %s
+Players:      %d/%d
+
+`, status.ServerName, address, status.Version, status.Map, strings.Join(status.Tags, ","),
+		status.PlayersCount, status.PlayersMax) //nolint:forbidigo
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "USERID\tNAME\tSTEAM64\tSTEAM3\tCONNECTED\tPING\tLOSS\tSTATE\tADDRESS")
+
+	for _, player := range status.Players {
+		record := newStatusPlayerRecord(player)
+		_, _ = fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\n", record.UserID, record.Name,
+			record.Steam64, record.Steam3, player.ConnectedTime, record.Ping, record.Loss, record.State,
+			record.Address)
+	}
+
+	if err := table.Flush(); err != nil {
+		fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+	}
+}
+
+// statusCmd parses a status command dump and prints the server info and players.
+var statusCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "status [file]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Parse the output of the status console command",
+	Long: `Parse the output of the status console command.
+
+The status dump is read from the file, or stdin when no file is given. Player rows with and
+without addresses are both detected. Rows that fail to parse are reported and skipped.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var reader io.Reader = os.Stdin
+
+		if len(args) == 1 && args[0] != "-" {
+			file, errOpen := os.Open(args[0])
+			if errOpen != nil {
+				fatalf(cmd, exitFailure, "Failed to open input file (%s): %v", args[0], errOpen)
+			}
+
+			defer func() {
+				_ = file.Close()
+			}()
+
+			reader = file
+		}
+
+		body, errRead := io.ReadAll(reader)
+		if errRead != nil {
+			fatalf(cmd, exitFailure, "Failed to read input: %v", errRead)
+		}
+
+		status, errStatus := parseStatusDump(string(body))
+		if errStatus != nil {
+			fatalf(cmd, exitParse, "Failed to parse status: %v", errStatus)
+		}
+
+		writeStatus(cmd, status)
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// summaryRecord is a player summary along with all the representations of its steam id.
+type summaryRecord struct {
+	conversion
+	PersonaName string `json:"persona_name"`
+	RealName    string `json:"real_name"`
+	ProfileURL  string `json:"profile_url"`
+	CountryCode string `json:"country_code"`
+	// Visibility is 1 for private and 3 for public profiles.
+	Visibility int `json:"visibility"`
+	// Created is the RFC3339 account creation time, empty when the profile is private.
+	Created string `json:"created"`
+}
+
+func (r summaryRecord) columns() []string {
+	return append(r.conversion.columns(), "persona_name", "real_name", "profile_url", "country_code", "visibility", "created")
+}
+
+func (r summaryRecord) values() []string {
+	return append(r.conversion.values(), r.PersonaName, r.RealName, r.ProfileURL, r.CountryCode,
+		strconv.Itoa(r.Visibility), r.Created)
+}
+
+func newSummaryRecord(input string, summary steamid.PlayerSummary) summaryRecord {
+	record := summaryRecord{
+		conversion:  newConversion(input, summary.SteamID),
+		PersonaName: summary.PersonaName,
+		RealName:    summary.RealName,
+		ProfileURL:  summary.ProfileURL,
+		CountryCode: summary.LocCountryCode,
+		Visibility:  summary.CommunityVisibilityState,
+	}
+
+	if created := summary.Created(); !created.IsZero() {
+		record.Created = created.Format(time.RFC3339)
+	}
+
+	return record
+}
+
+// summaryCmd fetches the profile summaries of steam ids.
+var summaryCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:     "summary",
+	Aliases: []string{"s"},
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Show the profile summaries of steam ids",
+	Long: `Show the profile summaries of steam ids.
+
+Requires a steam web api key to be set with the STEAM_TOKEN environment variable.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			steamIDs = make(steamid.Collection, 0, len(args))
+			inputs   = map[steamid.SteamID]string{}
+		)
+
+		for _, arg := range args {
+			sid := steamid.New(arg)
+			if !sid.Valid() {
+				fatalf(cmd, exitParse, "Failed to convert id: %s", arg)
+			}
+
+			steamIDs = append(steamIDs, sid)
+			inputs[sid] = arg
+		}
+
+		cache := mustOpenCache(cmd)
+
+		summaries, err := cache.playerSummaries(cmd.Context(), steamIDs)
+		if err != nil {
+			fatalf(cmd, errorCode(err, exitNetwork), "Failed to fetch summaries: %v", err)
+		}
+
+		saveCache(cache)
+
+		records := make([]summaryRecord, 0, len(summaries))
+
+		for _, summary := range summaries {
+			records = append(records, newSummaryRecord(inputs[summary.SteamID], summary))
+		}
+
+		if outputFormat(cmd) != outputText {
+			if errWrite := writeRecords(cmd, os.Stdout, records); errWrite != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", errWrite)
+			}
+
+			os.Exit(0)
+		}
+
+		for _, record := range records {
+			fmt.Printf(`Steam64:      %s
+Name:         %s
+Real Name:    %s
+Profile:      %s
+Country:      %s
+Created:      %s
+
+`, record.Steam64, record.PersonaName, record.RealName, record.ProfileURL, record.CountryCode, record.Created) //nolint:forbidigo
+		}
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(summaryCmd)
+}
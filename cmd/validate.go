@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// validateRecord is the validation result of a single input.
+type validateRecord struct {
+	Input string `json:"input"`
+	// Line is the 1-indexed line of the input, or the argument number for command line input.
+	Line    int    `json:"line"`
+	Valid   bool   `json:"valid"`
+	Steam64 string `json:"steam64"`
+	Error   string `json:"error,omitempty"`
+}
+
+func (r validateRecord) columns() []string {
+	return []string{"input", "line", "valid", "steam64", "error"}
+}
+
+func (r validateRecord) values() []string {
+	return []string{r.Input, strconv.Itoa(r.Line), strconv.FormatBool(r.Valid), r.Steam64, r.Error}
+}
+
+// validateInput checks a single input, returning the reason it is not a valid steam id.
+func validateInput(input string, line int) validateRecord {
+	record := validateRecord{Input: input, Line: line}
+
+	sid := steamid.New(input)
+	if errValidate := sid.Validate(); errValidate != nil {
+		record.Error = errValidate.Error()
+		if sid.AccountID == 0 && sid.AccountType == steamid.AccountTypeInvalid && sid.Universe == steamid.UniverseInvalid {
+			record.Error = "unrecognized id format"
+		}
+
+		return record
+	}
+
+	record.Valid = true
+	record.Steam64 = sid.String()
+
+	return record
+}
+
+// readValidateInputs reads the inputs to validate, skipping blank lines and comments.
+func readValidateInputs(reader io.Reader) ([]validateRecord, error) {
+	var (
+		records []validateRecord
+		scanner = bufio.NewScanner(reader)
+		line    int
+	)
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "//") {
+			continue
+		}
+
+		records = append(records, validateInput(text, line))
+	}
+
+	return records, scanner.Err() //nolint:wrapcheck
+}
+
+// validateCmd checks that every input is a valid steam id.
+var validateCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "validate [id...]",
+	Short: "Check that every input is a valid steam id",
+	Long: `Check that every input is a valid steam id.
+
+Ids are read from the arguments, the --file file or stdin, one per line. Blank lines and lines
+starting with # or // are skipped. Each input is reported with the reason it is invalid, followed
+by a summary on stderr. The command exits with code 2 if any input is invalid.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			records     []validateRecord
+			filePath    = cmd.Flag("file").Value.String()
+			invalidOnly = cmd.Flag("invalid-only").Changed
+			invalid     int
+		)
+
+		switch {
+		case filePath != "":
+			file, errOpen := os.Open(filePath)
+			if errOpen != nil {
+				fatalf(cmd, exitFailure, "Failed to open input file (%s): %v", filePath, errOpen)
+			}
+
+			fileRecords, errRead := readValidateInputs(file)
+
+			_ = file.Close()
+
+			if errRead != nil {
+				fatalf(cmd, exitFailure, "Failed to read input: %v", errRead)
+			}
+
+			records = fileRecords
+		case len(args) == 0 || (len(args) == 1 && args[0] == "-"):
+			stdinRecords, errRead := readValidateInputs(os.Stdin)
+			if errRead != nil {
+				fatalf(cmd, exitFailure, "Failed to read stdin: %v", errRead)
+			}
+
+			records = stdinRecords
+		default:
+			for idx, arg := range args {
+				records = append(records, validateInput(arg, idx+1))
+			}
+		}
+
+		output := make([]validateRecord, 0, len(records))
+
+		for _, record := range records {
+			if !record.Valid {
+				invalid++
+			}
+
+			if !invalidOnly || !record.Valid {
+				output = append(output, record)
+			}
+		}
+
+		if outputFormat(cmd) != outputText {
+			if err := writeRecords(cmd, os.Stdout, output); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+		} else {
+			writer := bufio.NewWriter(os.Stdout)
+
+			for _, record := range output {
+				if record.Valid {
+					_, _ = fmt.Fprintf(writer, "%d\t%s\tOK\n", record.Line, record.Input)
+				} else {
+					_, _ = fmt.Fprintf(writer, "%d\t%s\tINVALID\t%s\n", record.Line, record.Input, record.Error)
+				}
+			}
+
+			if err := writer.Flush(); err != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", err)
+			}
+		}
+
+		log.Printf("Checked %d ids: %d valid, %d invalid", len(records), len(records)-invalid, invalid)
+
+		if invalid > 0 {
+			os.Exit(exitParse)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().String("file", "", "File of ids to validate, one per line")
+	validateCmd.Flags().Bool("invalid-only", false, "Only report the invalid ids")
+}
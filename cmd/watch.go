@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/spf13/cobra"
+)
+
+// followReader reads a file like tail -f, waiting for more data to be written instead of
+// returning io.EOF. If the file is truncated, reading restarts from the beginning.
+type followReader struct {
+	ctx      context.Context //nolint:containedctx
+	file     *os.File
+	interval time.Duration
+}
+
+func (r *followReader) Read(buf []byte) (int, error) {
+	for {
+		count, err := r.file.Read(buf)
+		if count > 0 || !errors.Is(err, io.EOF) {
+			return count, err //nolint:wrapcheck
+		}
+
+		if offset, errSeek := r.file.Seek(0, io.SeekCurrent); errSeek == nil {
+			if stat, errStat := r.file.Stat(); errStat == nil && stat.Size() < offset {
+				if _, errReset := r.file.Seek(0, io.SeekStart); errReset != nil {
+					return 0, errReset //nolint:wrapcheck
+				}
+
+				continue
+			}
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return 0, io.EOF
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// formatID returns the steam id in the id type used by the parse command.
+func formatID(sid steamid.SteamID, idType string) string {
+	switch idType {
+	case "steam":
+		return string(sid.Steam(false))
+	case "steam3":
+		return string(sid.Steam3())
+	case "steam32":
+		return fmt.Sprintf("%d", sid.AccountID)
+	default:
+		return sid.String()
+	}
+}
+
+// watchCmd tails a log file and prints the steam ids found in it.
+var watchCmd = &cobra.Command{ //nolint:exhaustruct,gochecknoglobals
+	Use:   "watch <file | ->",
+	Args:  cobra.ExactArgs(1),
+	Short: "Watch a log file for steam ids",
+	Long: `Watch a log file for steam ids.
+
+The file is followed like tail -f and each unique steam id is printed as soon as it is
+written. Use - to read from stdin instead. When --bans is set, only the ids that are also
+found in the ban list file are printed. Any file containing steam ids can be used as the
+ban list, such as banned_user.cfg or a playerlist.json.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			idType    = strings.ToLower(cmd.Flag("type").Value.String())
+			format    = strings.ReplaceAll(cmd.Flag("format").Value.String(), "\\n", "\n")
+			bansPath  = cmd.Flag("bans").Value.String()
+			fromStart = cmd.Flag("from-start").Changed
+			reader    io.Reader
+			bans      steamid.Collection
+			seen      = map[steamid.SteamID]bool{}
+			ctx, stop = signal.NotifyContext(cmd.Context(), os.Interrupt)
+			writer    = bufio.NewWriter(os.Stdout)
+		)
+
+		defer stop()
+
+		switch idType {
+		case "steam", "steam3", "steam32", "steam64":
+		default:
+			fatalf(cmd, exitConfig, "Unknown type, must be one of steam, steam3, steam32, steam64: %s", idType)
+		}
+
+		if bansPath != "" {
+			bansFile, errOpen := os.Open(bansPath)
+			if errOpen != nil {
+				fatalf(cmd, exitFailure, "Failed to open ban list (%s): %v", bansPath, errOpen)
+			}
+
+			banList, errScan := extra.ScanReaderSteamIDs(bansFile)
+
+			_ = bansFile.Close()
+
+			if errScan != nil {
+				fatalf(cmd, exitFailure, "Failed to read ban list: %v", errScan)
+			}
+
+			bans = banList
+		}
+
+		if args[0] == "-" {
+			reader = os.Stdin
+		} else {
+			file, errOpen := os.Open(args[0])
+			if errOpen != nil {
+				fatalf(cmd, exitFailure, "Failed to open input file (%s): %v", args[0], errOpen)
+			}
+
+			defer func() {
+				_ = file.Close()
+			}()
+
+			if !fromStart {
+				if _, errSeek := file.Seek(0, io.SeekEnd); errSeek != nil {
+					fatalf(cmd, exitFailure, "Failed to seek input file: %v", errSeek)
+				}
+			}
+
+			reader = &followReader{ctx: ctx, file: file, interval: time.Millisecond * 250}
+		}
+
+		lines := bufio.NewReader(reader)
+
+		for {
+			line, errLine := lines.ReadString('\n')
+
+			found, _ := extra.ScanReaderSteamIDs(strings.NewReader(line))
+			for _, sid := range found {
+				if seen[sid] || (bansPath != "" && !bans.Contains(sid)) {
+					continue
+				}
+
+				seen[sid] = true
+
+				if templateText := cmd.Flag(outputTemplate).Value.String(); templateText != "" {
+					if errTemplate := writeTemplate(writer, templateText, []conversion{newConversion(sid.String(), sid)}); errTemplate != nil {
+						fatalf(cmd, exitFailure, "Failed to write output: %v", errTemplate)
+					}
+				} else {
+					_, _ = fmt.Fprintf(writer, format, formatID(sid, idType))
+				}
+			}
+
+			if errFlush := writer.Flush(); errFlush != nil {
+				fatalf(cmd, exitFailure, "Failed to write output: %v", errFlush)
+			}
+
+			if errLine != nil {
+				if !errors.Is(errLine, io.EOF) {
+					fatalf(cmd, exitFailure, "Failed to read input: %v", errLine)
+				}
+
+				break
+			}
+		}
+
+		os.Exit(0)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().StringP("type", "t", "steam64", "Output format for steam ids found (steam64, steam, steam3, steam32)")
+	watchCmd.Flags().StringP("format", "f", "%s\n", "Output format to use. Applied to each ID.")
+	completeFlag(watchCmd, "type", idTypes...)
+	watchCmd.Flags().StringP("bans", "b", "", "Only print ids found in this ban list file")
+	watchCmd.Flags().Bool("from-start", false, "Read the existing contents of the file instead of only new lines")
+}
@@ -0,0 +1,154 @@
+// Package steamid maps the function based api of github.com/leighmacdonald/steamid/v3/steamid onto
+// the v4 SteamID type, so code using v3 can be migrated incrementally. Only the import path has to
+// change:
+//
+//	import "github.com/leighmacdonald/steamid/v4/compat/v3"
+//
+// Each function documents its v4 replacement. New code should use the steamid package directly.
+package steamid
+
+import (
+	"context"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// SID64 is the v3 name of steamid.SteamID.
+type SID64 = steamid.SteamID
+
+// SID is a steam id in the STEAM_0:0:86173181 format.
+type SID = steamid.SID
+
+// SID3 is a steam id in the [U:1:172346362] format.
+type SID3 = steamid.SID3
+
+// SID32 is the 32bit account id of a steam id, such as 172346362.
+type SID32 = steamid.SID32
+
+// ErrInvalidSID is returned when a value is not a valid steam id.
+var ErrInvalidSID = steamid.ErrInvalidSID //nolint:gochecknoglobals
+
+// StringToSID64 converts a string of any steam id format into a SID64.
+//
+// Deprecated: use steamid.New and SteamID.Validate.
+func StringToSID64(steamID string) (SID64, error) {
+	sid := steamid.New(steamID)
+	if !sid.Valid() {
+		return SID64{}, steamid.Error{Kind: steamid.ErrParse, Input: steamID, Err: ErrInvalidSID}
+	}
+
+	return sid, nil
+}
+
+// SIDToSID64 converts a STEAM_0:0:86173181 format id, returning an invalid SID64 on failure.
+//
+// Deprecated: use steamid.New.
+func SIDToSID64(steamID SID) SID64 {
+	return steamid.New(string(steamID))
+}
+
+// SID3ToSID64 converts a [U:1:172346362] format id, returning an invalid SID64 on failure.
+//
+// Deprecated: use steamid.New.
+func SID3ToSID64(steamID SID3) SID64 {
+	return steamid.New(string(steamID))
+}
+
+// SID32ToSID64 converts an individual account id.
+//
+// Deprecated: use steamid.New.
+func SID32ToSID64(steamID SID32) SID64 {
+	return steamid.New(int64(steamID))
+}
+
+// SID64ToSID converts the id to the STEAM_0:0:86173181 format, or an empty string when it is
+// not an individual account.
+//
+// Deprecated: use SteamID.Steam.
+func SID64ToSID(steamID SID64) SID {
+	return steamID.Steam(false)
+}
+
+// SID64ToSID3 converts the id to the [U:1:172346362] format.
+//
+// Deprecated: use SteamID.Steam3.
+func SID64ToSID3(steamID SID64) SID3 {
+	return steamID.Steam3()
+}
+
+// SID64ToSID32 returns the account id of the id.
+//
+// Deprecated: use SteamID.AccountID.
+func SID64ToSID32(steamID SID64) SID32 {
+	return steamID.AccountID
+}
+
+// SIDToSID3 converts a STEAM_0:0:86173181 format id to the [U:1:172346362] format.
+//
+// Deprecated: use steamid.New and SteamID.Steam3.
+func SIDToSID3(steamID SID) SID3 {
+	return SID64ToSID3(SIDToSID64(steamID))
+}
+
+// SIDToSID32 returns the account id of a STEAM_0:0:86173181 format id.
+//
+// Deprecated: use steamid.New and SteamID.AccountID.
+func SIDToSID32(steamID SID) SID32 {
+	return SIDToSID64(steamID).AccountID
+}
+
+// SID3ToSID converts a [U:1:172346362] format id to the STEAM_0:0:86173181 format.
+//
+// Deprecated: use steamid.New and SteamID.Steam.
+func SID3ToSID(steamID SID3) SID {
+	return SID64ToSID(SID3ToSID64(steamID))
+}
+
+// SID3ToSID32 returns the account id of a [U:1:172346362] format id.
+//
+// Deprecated: use steamid.New and SteamID.AccountID.
+func SID3ToSID32(steamID SID3) SID32 {
+	return SID3ToSID64(steamID).AccountID
+}
+
+// SID32ToSID converts an individual account id to the STEAM_0:0:86173181 format.
+//
+// Deprecated: use steamid.New and SteamID.Steam.
+func SID32ToSID(steamID SID32) SID {
+	return SID64ToSID(SID32ToSID64(steamID))
+}
+
+// SID32ToSID3 converts an individual account id to the [U:1:172346362] format.
+//
+// Deprecated: use steamid.New and SteamID.Steam3.
+func SID32ToSID3(steamID SID32) SID3 {
+	return SID64ToSID3(SID32ToSID64(steamID))
+}
+
+// SetKey sets the steam web api key used by the resolve functions.
+//
+// Deprecated: use steamid.SetKey, or steamid.NewClient to avoid the package level key.
+func SetKey(key string) error {
+	return steamid.SetKey(key) //nolint:wrapcheck
+}
+
+// ResolveSID64 resolves a profile url, vanity name or id of any format.
+//
+// Deprecated: use steamid.Resolve.
+func ResolveSID64(ctx context.Context, query string) (SID64, error) {
+	return steamid.Resolve(ctx, query) //nolint:wrapcheck
+}
+
+// ResolveVanity resolves a vanity name, the last part of a steamcommunity.com/id/ url.
+//
+// Deprecated: use steamid.ResolveVanity.
+func ResolveVanity(ctx context.Context, query string) (SID64, error) {
+	return steamid.ResolveVanity(ctx, query) //nolint:wrapcheck
+}
+
+// ResolveGID resolves the id of a group from its vanity url.
+//
+// Deprecated: use steamid.ResolveGID.
+func ResolveGID(ctx context.Context, groupVanityURL string) (SID64, error) {
+	return steamid.ResolveGID(ctx, groupVanityURL) //nolint:wrapcheck
+}
@@ -0,0 +1,43 @@
+package steamid_test
+
+import (
+	"testing"
+
+	compat "github.com/leighmacdonald/steamid/v4/compat/v3"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sid64 = steamid.New(76561198132612090)
+		sid   = compat.SID("STEAM_0:0:86173181")
+		sid3  = compat.SID3("[U:1:172346362]")
+		sid32 = compat.SID32(172346362)
+	)
+
+	require.Equal(t, sid64, compat.SIDToSID64(sid))
+	require.Equal(t, sid64, compat.SID3ToSID64(sid3))
+	require.Equal(t, sid64, compat.SID32ToSID64(sid32))
+	require.Equal(t, sid, compat.SID64ToSID(sid64))
+	require.Equal(t, sid3, compat.SID64ToSID3(sid64))
+	require.Equal(t, sid32, compat.SID64ToSID32(sid64))
+	require.Equal(t, sid3, compat.SIDToSID3(sid))
+	require.Equal(t, sid32, compat.SIDToSID32(sid))
+	require.Equal(t, sid, compat.SID3ToSID(sid3))
+	require.Equal(t, sid32, compat.SID3ToSID32(sid3))
+	require.Equal(t, sid, compat.SID32ToSID(sid32))
+	require.Equal(t, sid3, compat.SID32ToSID3(sid32))
+
+	parsed, errParse := compat.StringToSID64("[U:1:172346362]")
+	require.NoError(t, errParse)
+	require.Equal(t, sid64, parsed)
+
+	_, errInvalid := compat.StringToSID64("not an id")
+	require.ErrorIs(t, errInvalid, compat.ErrInvalidSID)
+
+	invalid := compat.SIDToSID64("STEAM_")
+	require.False(t, invalid.Valid())
+}
@@ -0,0 +1,40 @@
+//go:build js && wasm
+
+// Command wasm exposes the steam id conversions to javascript as the global steamid.convert
+// function. It is built without the web api and yaml support, see index.html for its use.
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// convert returns the id in every format, or null when the input is not a valid steam id. Steam64
+// ids are returned as strings as they do not fit in a javascript number.
+func convert(_ js.Value, args []js.Value) any {
+	if len(args) != 1 {
+		return js.Null()
+	}
+
+	sid := steamid.New(args[0].String())
+	if !sid.Valid() {
+		return js.Null()
+	}
+
+	return map[string]any{
+		"steam64":    sid.String(),
+		"steam":      string(sid.Steam(false)),
+		"steam3":     string(sid.Steam3()),
+		"steam32":    int(sid.AccountID),
+		"friendCode": sid.FriendCode(),
+		"type":       sid.AccountType.String(),
+	}
+}
+
+func main() {
+	js.Global().Set("steamid", map[string]any{"convert": js.FuncOf(convert)})
+
+	// Keep the exported function available for the lifetime of the page.
+	select {}
+}
@@ -0,0 +1,406 @@
+package extra
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+const (
+	a2sHeaderSingle  = -1
+	a2sHeaderSplit   = -2
+	a2sInfoRequest   = 'T'
+	a2sInfoResponse  = 'I'
+	a2sPlayerRequest = 'U'
+	a2sPlayerReply   = 'D'
+	a2sChallenge     = 'A'
+	// a2sMaxPacketSize is the largest udp packet sent by Source servers.
+	a2sMaxPacketSize   = 1400
+	defaultA2STimeout  = time.Second * 5
+	a2sInfoQueryString = "Source Engine Query\x00"
+)
+
+// Extra data flags of the A2S_INFO response.
+const (
+	a2sEDFGameID   = 0x01
+	a2sEDFSteamID  = 0x10
+	a2sEDFKeywords = 0x20
+	a2sEDFSourceTV = 0x40
+	a2sEDFPort     = 0x80
+)
+
+var (
+	ErrA2SResponse = errors.New("invalid a2s response")
+	ErrA2SQuery    = errors.New("failed to query server")
+)
+
+// ServerInfo is the response of an A2S_INFO query.
+type ServerInfo struct {
+	Protocol    int
+	Name        string
+	Map         string
+	Folder      string
+	Game        string
+	AppID       steamid.AppID
+	Players     int
+	MaxPlayers  int
+	Bots        int
+	ServerType  string
+	Environment string
+	Password    bool
+	VAC         bool
+	Version     string
+	// The following fields are only set when the server includes them in the response.
+	Port         int
+	SteamID      steamid.SteamID
+	SourceTVPort int
+	SourceTVName string
+	Keywords     []string
+	GameID       uint64
+}
+
+// ServerPlayer is a player from an A2S_PLAYER query. The query does not include the players
+// steam ids.
+type ServerPlayer struct {
+	Index    int
+	Name     string
+	Score    int
+	Duration time.Duration
+}
+
+// a2sReader reads the little endian fields of an A2S response.
+type a2sReader struct {
+	data []byte
+	err  error
+}
+
+func (r *a2sReader) next(size int) []byte {
+	if r.err != nil {
+		return make([]byte, size)
+	}
+
+	if len(r.data) < size {
+		r.err = fmt.Errorf("%w: truncated", ErrA2SResponse)
+
+		return make([]byte, size)
+	}
+
+	value := r.data[:size]
+	r.data = r.data[size:]
+
+	return value
+}
+
+func (r *a2sReader) byte() byte {
+	return r.next(1)[0]
+}
+
+func (r *a2sReader) uint16() uint16 {
+	return binary.LittleEndian.Uint16(r.next(2))
+}
+
+func (r *a2sReader) int32() int32 {
+	return int32(binary.LittleEndian.Uint32(r.next(4)))
+}
+
+func (r *a2sReader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(r.next(8))
+}
+
+func (r *a2sReader) float32() float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(r.next(4)))
+}
+
+func (r *a2sReader) string() string {
+	if r.err != nil {
+		return ""
+	}
+
+	end := bytes.IndexByte(r.data, 0)
+	if end < 0 {
+		r.err = fmt.Errorf("%w: unterminated string", ErrA2SResponse)
+
+		return ""
+	}
+
+	value := string(r.data[:end])
+	r.data = r.data[end+1:]
+
+	return value
+}
+
+// a2sRequest sends the request and reads the response, answering a challenge when the server
+// sends one. The challenge is appended to the request, replacing the placeholder challenge
+// of A2S_PLAYER requests.
+func a2sRequest(ctx context.Context, addr string, request []byte, placeholder bool) ([]byte, error) {
+	var dialer net.Dialer
+
+	conn, errDial := dialer.DialContext(ctx, "udp", addr)
+	if errDial != nil {
+		return nil, errors.Join(errDial, ErrA2SQuery)
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	deadline, found := ctx.Deadline()
+	if !found {
+		deadline = time.Now().Add(defaultA2STimeout)
+	}
+
+	if errDeadline := conn.SetDeadline(deadline); errDeadline != nil {
+		return nil, errors.Join(errDeadline, ErrA2SQuery)
+	}
+
+	// Servers can keep asking for a new challenge, so only a few attempts are made.
+	for range 3 {
+		if _, errWrite := conn.Write(request); errWrite != nil {
+			return nil, errors.Join(errWrite, ErrA2SQuery)
+		}
+
+		response, errRead := readA2SResponse(conn)
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		if len(response) == 5 && response[0] == a2sChallenge {
+			if placeholder {
+				request = request[:len(request)-4]
+			}
+
+			request = append(request, response[1:]...)
+			placeholder = true
+
+			continue
+		}
+
+		return response, nil
+	}
+
+	return nil, fmt.Errorf("%w: too many challenges", ErrA2SResponse)
+}
+
+// readA2SResponse reads a response, joining the packets of split responses. The leading
+// single packet header is removed.
+func readA2SResponse(conn net.Conn) ([]byte, error) {
+	var (
+		buf   = make([]byte, a2sMaxPacketSize*2)
+		parts map[int][]byte
+		total int
+	)
+
+	for {
+		size, errRead := conn.Read(buf)
+		if errRead != nil {
+			return nil, errors.Join(errRead, ErrA2SQuery)
+		}
+
+		reader := &a2sReader{data: buf[:size]}
+
+		switch reader.int32() {
+		case a2sHeaderSingle:
+			if reader.err != nil {
+				return nil, reader.err
+			}
+
+			return bytes.Clone(reader.data), nil
+		case a2sHeaderSplit:
+			responseID := reader.int32()
+			count := int(reader.byte())
+			number := int(reader.byte())
+			_ = reader.uint16()
+
+			if reader.err != nil {
+				return nil, reader.err
+			}
+
+			if responseID < 0 {
+				return nil, fmt.Errorf("%w: compressed responses are not supported", ErrA2SResponse)
+			}
+
+			if parts == nil {
+				parts = map[int][]byte{}
+				total = count
+			}
+
+			if count != total || number >= total {
+				return nil, fmt.Errorf("%w: invalid split packet", ErrA2SResponse)
+			}
+
+			parts[number] = bytes.Clone(reader.data)
+
+			if len(parts) < total {
+				continue
+			}
+
+			var joined []byte
+			for idx := range total {
+				joined = append(joined, parts[idx]...)
+			}
+
+			reader = &a2sReader{data: joined}
+			if reader.int32() != a2sHeaderSingle || reader.err != nil {
+				return nil, fmt.Errorf("%w: invalid split payload", ErrA2SResponse)
+			}
+
+			return reader.data, nil
+		default:
+			return nil, fmt.Errorf("%w: unknown header", ErrA2SResponse)
+		}
+	}
+}
+
+func a2sServerType(value byte) string {
+	switch value {
+	case 'd':
+		return "dedicated"
+	case 'l':
+		return "listen"
+	case 'p':
+		return "proxy"
+	default:
+		return string(value)
+	}
+}
+
+func a2sEnvironment(value byte) string {
+	switch value {
+	case 'l':
+		return "linux"
+	case 'w':
+		return "windows"
+	case 'm', 'o':
+		return "mac"
+	default:
+		return string(value)
+	}
+}
+
+// parseServerInfo parses an A2S_INFO response, without the leading packet header.
+func parseServerInfo(data []byte) (ServerInfo, error) {
+	reader := &a2sReader{data: data}
+	if header := reader.byte(); header != a2sInfoResponse {
+		return ServerInfo{}, fmt.Errorf("%w: unexpected response type %q", ErrA2SResponse, header)
+	}
+
+	info := ServerInfo{
+		Protocol:    int(reader.byte()),
+		Name:        reader.string(),
+		Map:         reader.string(),
+		Folder:      reader.string(),
+		Game:        reader.string(),
+		AppID:       steamid.AppID(reader.uint16()),
+		Players:     int(reader.byte()),
+		MaxPlayers:  int(reader.byte()),
+		Bots:        int(reader.byte()),
+		ServerType:  a2sServerType(reader.byte()),
+		Environment: a2sEnvironment(reader.byte()),
+		Password:    reader.byte() == 1,
+		VAC:         reader.byte() == 1,
+		Version:     reader.string(),
+	}
+
+	if reader.err != nil {
+		return ServerInfo{}, reader.err
+	}
+
+	// The extra data flags are optional.
+	if len(reader.data) == 0 {
+		return info, nil
+	}
+
+	flags := reader.byte()
+
+	if flags&a2sEDFPort != 0 {
+		info.Port = int(reader.uint16())
+	}
+
+	if flags&a2sEDFSteamID != 0 {
+		info.SteamID = steamid.New(reader.uint64())
+	}
+
+	if flags&a2sEDFSourceTV != 0 {
+		info.SourceTVPort = int(reader.uint16())
+		info.SourceTVName = reader.string()
+	}
+
+	if flags&a2sEDFKeywords != 0 {
+		if keywords := reader.string(); keywords != "" {
+			info.Keywords = strings.Split(keywords, ",")
+		}
+	}
+
+	if flags&a2sEDFGameID != 0 {
+		info.GameID = reader.uint64()
+	}
+
+	if reader.err != nil {
+		return ServerInfo{}, reader.err
+	}
+
+	return info, nil
+}
+
+// parseServerPlayers parses an A2S_PLAYER response, without the leading packet header.
+func parseServerPlayers(data []byte) ([]ServerPlayer, error) {
+	reader := &a2sReader{data: data}
+	if header := reader.byte(); header != a2sPlayerReply {
+		return nil, fmt.Errorf("%w: unexpected response type %q", ErrA2SResponse, header)
+	}
+
+	count := int(reader.byte())
+	players := make([]ServerPlayer, 0, count)
+
+	for range count {
+		player := ServerPlayer{
+			Index: int(reader.byte()),
+			Name:  reader.string(),
+			Score: int(reader.int32()),
+		}
+
+		player.Duration = time.Duration(float64(reader.float32()) * float64(time.Second))
+
+		if reader.err != nil {
+			return nil, reader.err
+		}
+
+		players = append(players, player)
+	}
+
+	return players, nil
+}
+
+// QueryInfo sends an A2S_INFO query to the game server at addr. If the context has no deadline,
+// the query times out after 5 seconds.
+func QueryInfo(ctx context.Context, addr string) (ServerInfo, error) {
+	request := append([]byte{0xFF, 0xFF, 0xFF, 0xFF, a2sInfoRequest}, a2sInfoQueryString...)
+
+	response, errRequest := a2sRequest(ctx, addr, request, false)
+	if errRequest != nil {
+		return ServerInfo{}, errRequest
+	}
+
+	return parseServerInfo(response)
+}
+
+// QueryPlayers sends an A2S_PLAYER query to the game server at addr. If the context has no
+// deadline, the query times out after 5 seconds.
+func QueryPlayers(ctx context.Context, addr string) ([]ServerPlayer, error) {
+	request := []byte{0xFF, 0xFF, 0xFF, 0xFF, a2sPlayerRequest, 0xFF, 0xFF, 0xFF, 0xFF}
+
+	response, errRequest := a2sRequest(ctx, addr, request, true)
+	if errRequest != nil {
+		return nil, errRequest
+	}
+
+	return parseServerPlayers(response)
+}
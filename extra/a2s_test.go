@@ -0,0 +1,122 @@
+package extra_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func testInfoResponse() []byte {
+	buf := []byte{0xFF, 0xFF, 0xFF, 0xFF, 'I', 17}
+	buf = append(buf, "Uncletopia | US West 2\x00pl_goldrush\x00tf\x00Team Fortress\x00"...)
+	buf = binary.LittleEndian.AppendUint16(buf, 440)
+	buf = append(buf, 11, 32, 1, 'd', 'l', 0, 1)
+	buf = append(buf, "8604597\x00"...)
+	buf = append(buf, 0x80|0x10|0x20|0x01)
+	buf = binary.LittleEndian.AppendUint16(buf, 27015)
+	buf = binary.LittleEndian.AppendUint64(buf, 85568392923453780)
+	buf = append(buf, "nocrits,payload\x00"...)
+
+	return binary.LittleEndian.AppendUint64(buf, 440)
+}
+
+func testPlayersResponse() []byte {
+	buf := []byte{0xFF, 0xFF, 0xFF, 0xFF, 'D', 2}
+
+	for idx, name := range []string{"Dulahan", "Nox"} {
+		buf = append(buf, byte(idx))
+		buf = append(buf, name+"\x00"...)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(10*(idx+1)))
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(90.5))
+	}
+
+	return buf
+}
+
+// newTestA2SServer starts a server which requires a challenge for every query and splits the
+// player response over two packets.
+func newTestA2SServer(t *testing.T) string {
+	t.Helper()
+
+	conn, errListen := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, errListen)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	challenge := []byte{0x01, 0x02, 0x03, 0x04}
+
+	go func() {
+		buf := make([]byte, 1400)
+
+		for {
+			size, addr, errRead := conn.ReadFrom(buf)
+			if errRead != nil {
+				return
+			}
+
+			request := buf[:size]
+
+			if !bytes.HasSuffix(request, challenge) {
+				_, _ = conn.WriteTo(append([]byte{0xFF, 0xFF, 0xFF, 0xFF, 'A'}, challenge...), addr)
+
+				continue
+			}
+
+			switch request[4] {
+			case 'T':
+				_, _ = conn.WriteTo(testInfoResponse(), addr)
+			case 'U':
+				payload := testPlayersResponse()
+				half := len(payload) / 2
+
+				for number, part := range [][]byte{payload[:half], payload[half:]} {
+					packet := []byte{0xFE, 0xFF, 0xFF, 0xFF, 0x01, 0x00, 0x00, 0x00, 2, byte(number)}
+					packet = binary.LittleEndian.AppendUint16(packet, 1248)
+					_, _ = conn.WriteTo(append(packet, part...), addr)
+				}
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestQueryInfo(t *testing.T) {
+	t.Parallel()
+
+	info, errInfo := extra.QueryInfo(context.Background(), newTestA2SServer(t))
+	require.NoError(t, errInfo)
+	require.Equal(t, "Uncletopia | US West 2", info.Name)
+	require.Equal(t, "pl_goldrush", info.Map)
+	require.Equal(t, steamid.AppID(440), info.AppID)
+	require.Equal(t, 11, info.Players)
+	require.Equal(t, 32, info.MaxPlayers)
+	require.Equal(t, "dedicated", info.ServerType)
+	require.Equal(t, "linux", info.Environment)
+	require.True(t, info.VAC)
+	require.Equal(t, 27015, info.Port)
+	require.Equal(t, steamid.New(uint64(85568392923453780)), info.SteamID)
+	require.Equal(t, []string{"nocrits", "payload"}, info.Keywords)
+	require.Equal(t, uint64(440), info.GameID)
+}
+
+func TestQueryPlayers(t *testing.T) {
+	t.Parallel()
+
+	players, errPlayers := extra.QueryPlayers(context.Background(), newTestA2SServer(t))
+	require.NoError(t, errPlayers)
+	require.Equal(t, []extra.ServerPlayer{
+		{Index: 0, Name: "Dulahan", Score: 10, Duration: time.Millisecond * 90500},
+		{Index: 1, Name: "Nox", Score: 20, Duration: time.Millisecond * 90500},
+	}, players)
+}
@@ -0,0 +1,442 @@
+package extra
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+var reInsertBans = regexp.MustCompile("(?i)INSERT\\s+INTO\\s+`?(\\w*bans)`?\\s*(\\(([^)]*)\\))?\\s*VALUES\\s*")
+
+var (
+	ErrParseSQL       = errors.New("failed to parse sql")
+	ErrParseBanConfig = errors.New("failed to parse ban config")
+)
+
+// sbBanColumns is the column order of the SourceBans++ sb_bans table, used when an INSERT
+// statement does not specify its columns.
+var sbBanColumns = []string{ //nolint:gochecknoglobals
+	"bid", "ip", "authid", "name", "created", "ends", "length", "reason", "aid", "adminIp",
+	"sid", "country", "RemovedBy", "RemoveType", "RemovedOn", "type", "ureason",
+}
+
+// BanEntry is a single ban imported from or exported to one of the supported ban list formats.
+type BanEntry struct {
+	// SID is invalid for IP only bans.
+	SID    steamid.SteamID
+	IP     string
+	Name   string
+	Reason string
+	// Created is the zero time when the source format does not record it.
+	Created time.Time
+	// Duration is 0 for permanent bans.
+	Duration time.Duration
+	// Removed is true for bans that have been lifted or have expired.
+	Removed bool
+}
+
+// Permanent returns true when the ban does not expire.
+func (b BanEntry) Permanent() bool {
+	return b.Duration == 0
+}
+
+// Expires returns when the ban expires. The zero time is returned for permanent bans or when the
+// creation time is unknown.
+func (b BanEntry) Expires() time.Time {
+	if b.Permanent() || b.Created.IsZero() {
+		return time.Time{}
+	}
+
+	return b.Created.Add(b.Duration)
+}
+
+// sqlScanner tokenizes the VALUES section of a MySQL INSERT statement.
+type sqlScanner struct {
+	input string
+	pos   int
+}
+
+func (s *sqlScanner) skipSpace() {
+	for s.pos < len(s.input) && strings.ContainsRune(" \t\r\n", rune(s.input[s.pos])) {
+		s.pos++
+	}
+}
+
+func (s *sqlScanner) consume(char byte) bool {
+	s.skipSpace()
+
+	if s.pos < len(s.input) && s.input[s.pos] == char {
+		s.pos++
+
+		return true
+	}
+
+	return false
+}
+
+// value reads a single value, returning ok as false for NULL.
+func (s *sqlScanner) value() (string, bool, error) {
+	s.skipSpace()
+
+	if s.pos >= len(s.input) {
+		return "", false, fmt.Errorf("%w: unexpected end of input", ErrParseSQL)
+	}
+
+	if s.input[s.pos] != '\'' {
+		start := s.pos
+		for s.pos < len(s.input) && !strings.ContainsRune(",) \t\r\n", rune(s.input[s.pos])) {
+			s.pos++
+		}
+
+		token := s.input[start:s.pos]
+		if strings.EqualFold(token, "NULL") {
+			return "", false, nil
+		}
+
+		return token, true, nil
+	}
+
+	var out strings.Builder
+
+	for s.pos++; s.pos < len(s.input); s.pos++ {
+		char := s.input[s.pos]
+
+		switch {
+		case char == '\\' && s.pos+1 < len(s.input):
+			s.pos++
+			out.WriteByte(unescapeSQL(s.input[s.pos]))
+		case char == '\'' && s.pos+1 < len(s.input) && s.input[s.pos+1] == '\'':
+			s.pos++
+			out.WriteByte('\'')
+		case char == '\'':
+			s.pos++
+
+			return out.String(), true, nil
+		default:
+			out.WriteByte(char)
+		}
+	}
+
+	return "", false, fmt.Errorf("%w: unterminated string", ErrParseSQL)
+}
+
+func unescapeSQL(char byte) byte {
+	switch char {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case '0':
+		return 0
+	default:
+		return char
+	}
+}
+
+// tuple reads a single parenthesised row of values.
+func (s *sqlScanner) tuple() ([]*string, error) {
+	if !s.consume('(') {
+		return nil, fmt.Errorf("%w: expected ( at offset %d", ErrParseSQL, s.pos)
+	}
+
+	var values []*string
+
+	for {
+		value, notNull, errValue := s.value()
+		if errValue != nil {
+			return nil, errValue
+		}
+
+		if notNull {
+			values = append(values, &value)
+		} else {
+			values = append(values, nil)
+		}
+
+		if s.consume(')') {
+			return values, nil
+		}
+
+		if !s.consume(',') {
+			return nil, fmt.Errorf("%w: expected , at offset %d", ErrParseSQL, s.pos)
+		}
+	}
+}
+
+// ParseSourceBansSQL reads the bans from a SourceBans or SourceBans++ database dump, such as one
+// created by mysqldump. Only the INSERT statements for the bans table are parsed, everything
+// else in the dump is ignored. Both extended and single row inserts, with or without a
+// column list, are supported.
+func ParseSourceBansSQL(reader io.Reader) ([]BanEntry, error) {
+	body, errRead := io.ReadAll(reader)
+	if errRead != nil {
+		return nil, errors.Join(errRead, ErrParseSQL)
+	}
+
+	var (
+		input   = string(body)
+		entries []BanEntry
+	)
+
+	for _, loc := range reInsertBans.FindAllStringSubmatchIndex(input, -1) {
+		columns := sbBanColumns
+
+		if loc[6] >= 0 {
+			columns = nil
+			for _, column := range strings.Split(input[loc[6]:loc[7]], ",") {
+				columns = append(columns, strings.Trim(strings.TrimSpace(column), "`"))
+			}
+		}
+
+		scanner := &sqlScanner{input: input, pos: loc[1]}
+
+		for {
+			row, errRow := scanner.tuple()
+			if errRow != nil {
+				return nil, errRow
+			}
+
+			entries = append(entries, banFromRow(columns, row))
+
+			if !scanner.consume(',') {
+				break
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func banFromRow(columns []string, row []*string) BanEntry {
+	values := map[string]string{}
+
+	for idx, column := range columns {
+		if idx < len(row) && row[idx] != nil {
+			values[column] = *row[idx]
+		}
+	}
+
+	entry := BanEntry{
+		SID:     steamid.New(values["authid"]),
+		IP:      values["ip"],
+		Name:    values["name"],
+		Reason:  values["reason"],
+		Removed: values["RemoveType"] != "",
+	}
+
+	if created, errCreated := strconv.ParseInt(values["created"], 10, 64); errCreated == nil && created > 0 {
+		entry.Created = time.Unix(created, 0)
+	}
+
+	if length, errLength := strconv.ParseInt(values["length"], 10, 64); errLength == nil && length > 0 {
+		entry.Duration = time.Duration(length) * time.Second
+	}
+
+	return entry
+}
+
+func escapeSQL(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`, "\x00", `\0`).Replace(value)
+}
+
+// WriteSourceBansSQL writes the bans as INSERT statements for the SourceBans++ sb_bans table. The
+// admin and server ids are set to 0, which SourceBans displays as the console and web panel.
+func WriteSourceBansSQL(writer io.Writer, entries []BanEntry) error {
+	buf := bufio.NewWriter(writer)
+
+	for _, entry := range entries {
+		var (
+			authID  string
+			banType = 1
+			created = entry.Created
+			ends    int64
+		)
+
+		if entry.SID.Valid() {
+			authID = string(entry.SID.Steam(false))
+			banType = 0
+		}
+
+		if created.IsZero() {
+			created = time.Now()
+		}
+
+		if !entry.Permanent() {
+			ends = created.Add(entry.Duration).Unix()
+		} else {
+			ends = created.Unix()
+		}
+
+		removeType := "NULL"
+		if entry.Removed {
+			removeType = "'E'"
+		}
+
+		_, errWrite := fmt.Fprintf(buf, "INSERT INTO `sb_bans` (`ip`, `authid`, `name`, `created`, `ends`, `length`, "+
+			"`reason`, `aid`, `adminIp`, `sid`, `RemoveType`, `type`) VALUES ('%s', '%s', '%s', %d, %d, %d, '%s', 0, '', 0, %s, %d);\n",
+			escapeSQL(entry.IP), authID, escapeSQL(entry.Name), created.Unix(), ends, int64(entry.Duration.Seconds()),
+			escapeSQL(entry.Reason), removeType, banType)
+		if errWrite != nil {
+			return errors.Join(errWrite, ErrWrite)
+		}
+	}
+
+	if errFlush := buf.Flush(); errFlush != nil {
+		return errors.Join(errFlush, ErrFlush)
+	}
+
+	return nil
+}
+
+// ParseBannedUsers reads a banned_user.cfg style ban list, which is the format written by the
+// srcds `writeid` command and the SourceBans "export permanent bans" function. eg:
+//
+//	banid 0 STEAM_0:1:61934148
+//	banid 0.0 [U:1:172346362]
+//
+// Lines that are not banid commands are ignored.
+func ParseBannedUsers(reader io.Reader) ([]BanEntry, error) {
+	var (
+		scanner = bufio.NewScanner(reader)
+		entries []BanEntry
+		lineNum int
+	)
+
+	for scanner.Scan() {
+		lineNum++
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !strings.EqualFold(fields[0], "banid") {
+			continue
+		}
+
+		minutes, errMinutes := strconv.ParseFloat(fields[1], 64)
+		if errMinutes != nil || minutes < 0 || minutes > math.MaxInt32 {
+			return nil, LineError{Line: lineNum, Text: scanner.Text(), Err: ErrParseBanConfig}
+		}
+
+		// An optional kick argument may follow the id.
+		sid := steamid.New(fields[2])
+		if !sid.Valid() {
+			return nil, LineError{Line: lineNum, Text: scanner.Text(), Err: steamid.ErrInvalidSID}
+		}
+
+		entries = append(entries, BanEntry{SID: sid, Duration: time.Duration(minutes * float64(time.Minute))})
+	}
+
+	if errScan := scanner.Err(); errScan != nil {
+		return nil, errors.Join(errScan, ErrScan)
+	}
+
+	return entries, nil
+}
+
+// WriteBannedUsers writes the bans in the banned_user.cfg format. Entries without a valid steam id,
+// such as IP only bans, and removed bans are skipped.
+func WriteBannedUsers(writer io.Writer, entries []BanEntry) error {
+	buf := bufio.NewWriter(writer)
+
+	for _, entry := range entries {
+		if !entry.SID.Valid() || entry.Removed {
+			continue
+		}
+
+		if _, errWrite := fmt.Fprintf(buf, "banid %d %s\n", int64(entry.Duration.Minutes()), entry.SID.Steam(false)); errWrite != nil {
+			return errors.Join(errWrite, ErrWrite)
+		}
+	}
+
+	if errFlush := buf.Flush(); errFlush != nil {
+		return errors.Join(errFlush, ErrFlush)
+	}
+
+	return nil
+}
+
+var (
+	reListIDHeader = regexp.MustCompile(`^(?:ID filter list: (\d+) entr(?:y|ies)|(\d+) entr(?:y|ies) in the ban list)$`)
+	reListIDEntry  = regexp.MustCompile(`^(\d+)\s+(\S+)\s*:\s*(permanent|[\d.]+ min)$`)
+)
+
+// ListIDEntry is a single ban from the listid console command output.
+type ListIDEntry struct {
+	Index int
+	SID   steamid.SteamID
+	// Remaining is 0 for permanent bans.
+	Remaining time.Duration
+}
+
+// Permanent returns true when the ban does not expire.
+func (e ListIDEntry) Permanent() bool {
+	return e.Remaining == 0
+}
+
+// ParseListID parses the output of the listid console command, which lists the active steam id bans
+// of a server along with the time remaining for each. eg:
+//
+//	ID filter list: 2 entries
+//	1 STEAM_0:1:61934148 : permanent
+//	2 [U:1:148883280] : 20.000 min
+//
+// The older "%i entries in the ban list" header is also accepted. An error is returned if the header
+// is missing or the number of entries does not match it.
+func ParseListID(text string) ([]ListIDEntry, error) {
+	var (
+		entries  []ListIDEntry
+		expected = -1
+	)
+
+	for lineNum, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+
+		if match := reListIDHeader.FindStringSubmatch(line); match != nil {
+			expected, _ = strconv.Atoi(match[1] + match[2])
+
+			continue
+		}
+
+		match := reListIDEntry.FindStringSubmatch(line)
+		if match == nil || expected < 0 {
+			continue
+		}
+
+		sid := steamid.New(match[2])
+		if !sid.Valid() {
+			return nil, LineError{Line: lineNum + 1, Text: line, Err: steamid.ErrInvalidSID}
+		}
+
+		index, _ := strconv.Atoi(match[1])
+		entry := ListIDEntry{Index: index, SID: sid}
+
+		if match[3] != "permanent" {
+			minutes, errMinutes := strconv.ParseFloat(strings.TrimSuffix(match[3], " min"), 64)
+			if errMinutes != nil {
+				return nil, LineError{Line: lineNum + 1, Text: line, Err: errors.Join(errMinutes, ErrParseBanConfig)}
+			}
+
+			entry.Remaining = time.Duration(minutes * float64(time.Minute))
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if expected < 0 {
+		return nil, fmt.Errorf("%w: missing listid header", ErrParseBanConfig)
+	}
+
+	if len(entries) != expected {
+		return nil, fmt.Errorf("%w: expected %d entries, got %d", ErrParseBanConfig, expected, len(entries))
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,105 @@
+package extra_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+const sourceBansDump = "-- MySQL dump 10.13\n" +
+	"DROP TABLE IF EXISTS `sb_bans`;\n" +
+	"INSERT INTO `sb_admins` VALUES (0,'CONSOLE','STEAM_ID_SERVER','','','',0,'',NULL,0,0);\n" +
+	"INSERT INTO `sb_bans` VALUES (1,'','STEAM_0:0:74441640','Dulahan',1600000000,1600003600,3600," +
+	"'it\\'s (a) test, ok',1,'10.0.0.1',0,NULL,NULL,NULL,NULL,0,NULL)," +
+	"(2,'1.2.3.4','','',1600000000,1600000000,0,'ip ban',1,'',0,NULL,NULL,NULL,NULL,1,NULL)," +
+	"(3,'','STEAM_0:1:61934148','Lifted',1600000000,1600000000,0,'',1,'',0,NULL,1,'U',1600000100,0,'appeal');\n" +
+	"INSERT INTO `sb_bans` (`authid`, `name`, `created`, `length`, `reason`) VALUES ('[U:1:186134686]', 'Nox', 1600000000, 0, 'cheating');\n"
+
+func TestParseSourceBansSQL(t *testing.T) {
+	t.Parallel()
+
+	entries, err := extra.ParseSourceBansSQL(strings.NewReader(sourceBansDump))
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	require.Equal(t, steamid.New("STEAM_0:0:74441640"), entries[0].SID)
+	require.Equal(t, "Dulahan", entries[0].Name)
+	require.Equal(t, "it's (a) test, ok", entries[0].Reason)
+	require.Equal(t, time.Hour, entries[0].Duration)
+	require.Equal(t, time.Unix(1600003600, 0), entries[0].Expires())
+
+	require.False(t, entries[1].SID.Valid())
+	require.Equal(t, "1.2.3.4", entries[1].IP)
+	require.True(t, entries[1].Permanent())
+
+	require.True(t, entries[2].Removed)
+
+	require.Equal(t, steamid.New(76561198146400414), entries[3].SID)
+	require.Equal(t, "cheating", entries[3].Reason)
+
+	_, errInvalid := extra.ParseSourceBansSQL(strings.NewReader("INSERT INTO `sb_bans` VALUES (1,'unterminated"))
+	require.ErrorIs(t, errInvalid, extra.ErrParseSQL)
+}
+
+func TestWriteSourceBansSQL(t *testing.T) {
+	t.Parallel()
+
+	entries, err := extra.ParseSourceBansSQL(strings.NewReader(sourceBansDump))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, extra.WriteSourceBansSQL(&buf, entries))
+
+	roundTrip, errRoundTrip := extra.ParseSourceBansSQL(&buf)
+	require.NoError(t, errRoundTrip)
+	require.Equal(t, entries, roundTrip)
+}
+
+func TestBannedUsers(t *testing.T) {
+	t.Parallel()
+
+	entries, err := extra.ParseBannedUsers(strings.NewReader("// comment\nbanid 0 STEAM_0:1:61934148\nbanid 60.0 [U:1:148883280] kick\n"))
+	require.NoError(t, err)
+	require.Equal(t, []extra.BanEntry{
+		{SID: steamid.New("STEAM_0:1:61934148")},
+		{SID: steamid.New("[U:1:148883280]"), Duration: time.Hour},
+	}, entries)
+
+	var buf bytes.Buffer
+	require.NoError(t, extra.WriteBannedUsers(&buf, append(entries, extra.BanEntry{IP: "1.2.3.4"})))
+	require.Equal(t, "banid 0 STEAM_0:1:61934148\nbanid 60 STEAM_0:0:74441640\n", buf.String())
+
+	_, errInvalid := extra.ParseBannedUsers(strings.NewReader("banid x STEAM_0:1:61934148\n"))
+	require.ErrorIs(t, errInvalid, extra.ErrParseBanConfig)
+}
+
+func TestParseListID(t *testing.T) {
+	t.Parallel()
+
+	entries, err := extra.ParseListID("ID filter list: 2 entries\n1 STEAM_0:1:61934148 : permanent\n2 [U:1:148883280] : 20.500 min\n")
+	require.NoError(t, err)
+	require.Equal(t, []extra.ListIDEntry{
+		{Index: 1, SID: steamid.New("STEAM_0:1:61934148")},
+		{Index: 2, SID: steamid.New("[U:1:148883280]"), Remaining: time.Minute*20 + time.Second*30},
+	}, entries)
+	require.True(t, entries[0].Permanent())
+
+	legacy, errLegacy := extra.ParseListID("1 entry in the ban list\r\n1 STEAM_0:1:61934148 : permanent\r\n")
+	require.NoError(t, errLegacy)
+	require.Len(t, legacy, 1)
+
+	empty, errEmpty := extra.ParseListID("ID filter list: 0 entries\n")
+	require.NoError(t, errEmpty)
+	require.Empty(t, empty)
+
+	_, errMissing := extra.ParseListID("Unknown command \"listid\"\n")
+	require.ErrorIs(t, errMissing, extra.ErrParseBanConfig)
+
+	_, errCount := extra.ParseListID("ID filter list: 2 entries\n1 STEAM_0:1:61934148 : permanent\n")
+	require.ErrorIs(t, errCount, extra.ErrParseBanConfig)
+}
@@ -0,0 +1,389 @@
+// Package bansync keeps the VAC, game and community ban states of a collection of steam ids up
+// to date, emitting events when they change. Ids are refreshed in batches with a delay between
+// requests, and the last known states are persisted with a Store so a restart does not refetch
+// ids that were checked recently.
+//
+//	syncer := bansync.New(client, steamIDs, bansync.WithStore(bansync.NewFileStore("bans.json")))
+//	syncer.Run(ctx, func(event bansync.Event) {
+//		fmt.Println(event.SID, event.Type)
+//	})
+package bansync
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+const (
+	// DefaultInterval is the default age at which ban states are refreshed.
+	DefaultInterval = time.Hour
+	// DefaultRateLimit is the default delay between ban requests within a single sync.
+	DefaultRateLimit = time.Second
+)
+
+var (
+	ErrFetchBans = errors.New("failed to fetch player bans")
+	ErrStore     = errors.New("failed to access ban state store")
+)
+
+// Provider fetches the ban states of steam ids. It is implemented by *steamid.Client and
+// *extra.PlayerDataCache.
+type Provider interface {
+	PlayerBans(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerBanState, error)
+}
+
+var _ Provider = (*steamid.Client)(nil)
+
+// EventType describes the kind of change an Event represents.
+type EventType int
+
+const (
+	VACBanAdded EventType = iota + 1
+	GameBanAdded
+	// BanRemoved is emitted when the number of VAC or game bans decreases, which happens when a
+	// ban is overturned.
+	BanRemoved
+	CommunityBanChanged
+	EconomyBanChanged
+)
+
+func (e EventType) String() string {
+	switch e {
+	case VACBanAdded:
+		return "VAC Ban Added"
+	case GameBanAdded:
+		return "Game Ban Added"
+	case BanRemoved:
+		return "Ban Removed"
+	case CommunityBanChanged:
+		return "Community Ban Changed"
+	case EconomyBanChanged:
+		return "Economy Ban Changed"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is a change between the last known and current ban state of a player.
+type Event struct {
+	Type     EventType
+	SID      steamid.SteamID
+	Current  steamid.PlayerBanState
+	Previous steamid.PlayerBanState
+}
+
+// State is the last known ban state of a player, along with the time it was fetched.
+type State struct {
+	steamid.PlayerBanState
+	CheckedAt time.Time `json:"CheckedAt"`
+}
+
+// Syncer refreshes the ban states of a collection of steam ids. It is safe for concurrent use,
+// but only a single sync runs at a time.
+type Syncer struct {
+	source       Provider
+	store        Store
+	interval     time.Duration
+	rateLimit    time.Duration
+	errorHandler func(error)
+
+	syncMu   sync.Mutex
+	mu       sync.Mutex
+	steamIDs steamid.Collection
+	states   map[steamid.SteamID]State
+}
+
+// Option configures optional Syncer settings.
+type Option func(*Syncer)
+
+// WithStore sets the store the ban states are persisted to, an in memory store by default.
+func WithStore(store Store) Option {
+	return func(s *Syncer) {
+		s.store = store
+	}
+}
+
+// WithInterval sets the age at which ban states are refreshed, DefaultInterval by default or when
+// not positive. It is also the period at which Run syncs.
+func WithInterval(interval time.Duration) Option {
+	return func(s *Syncer) {
+		s.interval = interval
+	}
+}
+
+// WithRateLimit sets the delay between the ban requests of a sync, each of which covers up to
+// steamid.MaxBatchIDs ids. DefaultRateLimit is used by default.
+func WithRateLimit(interval time.Duration) Option {
+	return func(s *Syncer) {
+		s.rateLimit = interval
+	}
+}
+
+// WithErrorHandler sets the function called with the errors of the syncs performed by Run, which
+// are otherwise ignored.
+func WithErrorHandler(handler func(error)) Option {
+	return func(s *Syncer) {
+		s.errorHandler = handler
+	}
+}
+
+// New returns a syncer for the steam ids, fetching their ban states from the source.
+func New(source Provider, steamIDs steamid.Collection, opts ...Option) *Syncer {
+	syncer := &Syncer{
+		source:       source,
+		store:        NewMemoryStore(),
+		interval:     DefaultInterval,
+		rateLimit:    DefaultRateLimit,
+		errorHandler: func(error) {},
+	}
+
+	for _, opt := range opts {
+		opt(syncer)
+	}
+
+	if syncer.interval <= 0 {
+		syncer.interval = DefaultInterval
+	}
+
+	syncer.SetSteamIDs(steamIDs)
+
+	return syncer
+}
+
+// SetSteamIDs replaces the steam ids that are synced. Invalid and duplicate ids are ignored. The
+// states of removed ids are dropped from the store on the next sync.
+func (s *Syncer) SetSteamIDs(steamIDs steamid.Collection) {
+	var (
+		unique steamid.Collection
+		seen   = map[steamid.SteamID]bool{}
+	)
+
+	for _, sid := range steamIDs {
+		if sid.Valid() && !seen[sid] {
+			seen[sid] = true
+			unique = append(unique, sid)
+		}
+	}
+
+	s.mu.Lock()
+	s.steamIDs = unique
+	s.mu.Unlock()
+}
+
+// States returns the last known ban states of the synced ids. Ids that have not been fetched yet
+// are left out.
+func (s *Syncer) States() []State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make([]State, 0, len(s.steamIDs))
+
+	for _, sid := range s.steamIDs {
+		if state, found := s.states[sid]; found {
+			states = append(states, state)
+		}
+	}
+
+	return states
+}
+
+// Sync fetches the ban states of the ids that have not been checked within the interval and
+// returns the changes from their last known states. The first state fetched for an id is
+// recorded without an event. The stored states are loaded on the first sync and saved after
+// every sync, including those that fail part way so completed batches are not fetched again.
+func (s *Syncer) Sync(ctx context.Context) ([]Event, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	if errLoad := s.load(ctx); errLoad != nil {
+		return nil, errLoad
+	}
+
+	var (
+		events  []Event
+		stale   = s.stale()
+		errSync error
+	)
+
+	for start := 0; start < len(stale); start += steamid.MaxBatchIDs {
+		if start > 0 {
+			if errWait := wait(ctx, s.rateLimit); errWait != nil {
+				errSync = errWait
+
+				break
+			}
+		}
+
+		batch := stale[start:min(start+steamid.MaxBatchIDs, len(stale))]
+
+		bans, errBans := s.source.PlayerBans(ctx, batch)
+		if errBans != nil {
+			errSync = errors.Join(errBans, ErrFetchBans)
+
+			break
+		}
+
+		events = append(events, s.update(bans)...)
+	}
+
+	if errSave := s.save(ctx); errSave != nil {
+		return events, errors.Join(errSync, errSave)
+	}
+
+	return events, errSync
+}
+
+// Run syncs immediately and then once per interval, calling the handler with every event, until
+// the context is cancelled. Sync errors are passed to the error handler and the failed ids are
+// retried on the next sync.
+func (s *Syncer) Run(ctx context.Context, handler func(Event)) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		events, errSync := s.Sync(ctx)
+		for _, event := range events {
+			handler(event)
+		}
+
+		if errSync != nil && ctx.Err() == nil {
+			s.errorHandler(errSync)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// load reads the stored states, if they have not been loaded yet.
+func (s *Syncer) load(ctx context.Context) error {
+	s.mu.Lock()
+	loaded := s.states != nil
+	s.mu.Unlock()
+
+	if loaded {
+		return nil
+	}
+
+	stored, errLoad := s.store.Load(ctx)
+	if errLoad != nil {
+		return errors.Join(errLoad, ErrStore)
+	}
+
+	states := make(map[steamid.SteamID]State, len(stored))
+	for _, state := range stored {
+		states[state.SteamID] = state
+	}
+
+	s.mu.Lock()
+	s.states = states
+	s.mu.Unlock()
+
+	return nil
+}
+
+// save writes the states of the synced ids to the store.
+func (s *Syncer) save(ctx context.Context) error {
+	if errSave := s.store.Save(ctx, s.States()); errSave != nil {
+		return errors.Join(errSave, ErrStore)
+	}
+
+	return nil
+}
+
+// stale returns the synced ids which have not been checked within the interval.
+func (s *Syncer) stale() steamid.Collection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		stale steamid.Collection
+		now   = time.Now()
+	)
+
+	for _, sid := range s.steamIDs {
+		if state, found := s.states[sid]; !found || now.Sub(state.CheckedAt) >= s.interval {
+			stale = append(stale, sid)
+		}
+	}
+
+	return stale
+}
+
+// update records the fetched ban states, returning the changes from the previous states.
+func (s *Syncer) update(bans []steamid.PlayerBanState) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		events []Event
+		now    = time.Now()
+	)
+
+	for _, ban := range bans {
+		previous, found := s.states[ban.SteamID]
+		if found {
+			events = append(events, diff(previous.PlayerBanState, ban)...)
+		}
+
+		s.states[ban.SteamID] = State{PlayerBanState: ban, CheckedAt: now}
+	}
+
+	return events
+}
+
+// diff returns the events for the changes between two ban states of a player.
+func diff(previous steamid.PlayerBanState, current steamid.PlayerBanState) []Event {
+	var types []EventType
+
+	if current.NumberOfVACBans > previous.NumberOfVACBans || (current.VACBanned && !previous.VACBanned) {
+		types = append(types, VACBanAdded)
+	}
+
+	if current.NumberOfGameBans > previous.NumberOfGameBans {
+		types = append(types, GameBanAdded)
+	}
+
+	if current.NumberOfVACBans < previous.NumberOfVACBans || current.NumberOfGameBans < previous.NumberOfGameBans {
+		types = append(types, BanRemoved)
+	}
+
+	if current.CommunityBanned != previous.CommunityBanned {
+		types = append(types, CommunityBanChanged)
+	}
+
+	if current.EconomyBan != previous.EconomyBan {
+		types = append(types, EconomyBanChanged)
+	}
+
+	events := make([]Event, len(types))
+	for idx, eventType := range types {
+		events[idx] = Event{Type: eventType, SID: current.SteamID, Current: current, Previous: previous}
+	}
+
+	return events
+}
+
+// wait blocks for the duration, returning early with the error of the context if it is cancelled.
+func wait(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,182 @@
+package bansync_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra/bansync"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestProvider = errors.New("provider failed")
+
+// testProvider returns the configured ban states and records the batches it was asked for.
+type testProvider struct {
+	mu      sync.Mutex
+	bans    map[steamid.SteamID]steamid.PlayerBanState
+	batches []int
+	fail    bool
+}
+
+func (p *testProvider) set(ban steamid.PlayerBanState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.bans[ban.SteamID] = ban
+}
+
+func (p *testProvider) PlayerBans(_ context.Context, steamIDs steamid.Collection) ([]steamid.PlayerBanState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.fail {
+		return nil, errTestProvider
+	}
+
+	p.batches = append(p.batches, len(steamIDs))
+
+	bans := make([]steamid.PlayerBanState, 0, len(steamIDs))
+	for _, sid := range steamIDs {
+		ban, found := p.bans[sid]
+		if !found {
+			ban = steamid.PlayerBanState{SteamID: sid, EconomyBan: "none"}
+		}
+
+		bans = append(bans, ban)
+	}
+
+	return bans, nil
+}
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		sid      = steamid.New(76561198132612090)
+		steamIDs = steamid.Collection{sid, sid, {}}
+		provider = &testProvider{bans: map[steamid.SteamID]steamid.PlayerBanState{}}
+	)
+
+	for idx := range uint64(150) {
+		steamIDs = append(steamIDs, steamid.New(76561197960265729+idx))
+	}
+
+	syncer := bansync.New(provider, steamIDs, bansync.WithInterval(-1), bansync.WithRateLimit(0))
+
+	events, errSync := syncer.Sync(ctx)
+	require.NoError(t, errSync)
+	require.Empty(t, events)
+	require.Equal(t, []int{100, 51}, provider.batches)
+	require.Len(t, syncer.States(), 151)
+
+	// States checked within the interval are not fetched again.
+	_, errSync = syncer.Sync(ctx)
+	require.NoError(t, errSync)
+	require.Len(t, provider.batches, 2)
+
+	syncer = bansync.New(provider, steamid.Collection{sid}, bansync.WithInterval(time.Nanosecond), bansync.WithRateLimit(0))
+
+	_, errSync = syncer.Sync(ctx)
+	require.NoError(t, errSync)
+
+	provider.set(steamid.PlayerBanState{SteamID: sid, VACBanned: true, NumberOfVACBans: 1, NumberOfGameBans: 1, EconomyBan: "none"})
+
+	events, errSync = syncer.Sync(ctx)
+	require.NoError(t, errSync)
+	require.Len(t, events, 2)
+	require.Equal(t, bansync.VACBanAdded, events[0].Type)
+	require.Equal(t, bansync.GameBanAdded, events[1].Type)
+	require.Equal(t, sid, events[0].SID)
+	require.False(t, events[0].Previous.VACBanned)
+	require.True(t, events[0].Current.VACBanned)
+
+	provider.set(steamid.PlayerBanState{SteamID: sid, CommunityBanned: true, EconomyBan: "probation"})
+
+	events, errSync = syncer.Sync(ctx)
+	require.NoError(t, errSync)
+	require.Len(t, events, 3)
+	require.Equal(t, bansync.BanRemoved, events[0].Type)
+	require.Equal(t, bansync.CommunityBanChanged, events[1].Type)
+	require.Equal(t, "Economy Ban Changed", events[2].Type.String())
+
+	provider.fail = true
+
+	_, errFail := syncer.Sync(ctx)
+	require.ErrorIs(t, errFail, bansync.ErrFetchBans)
+	require.ErrorIs(t, errFail, errTestProvider)
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		sid      = steamid.New(76561198132612090)
+		path     = filepath.Join(t.TempDir(), "bans.json")
+		provider = &testProvider{bans: map[steamid.SteamID]steamid.PlayerBanState{}}
+	)
+
+	provider.set(steamid.PlayerBanState{SteamID: sid, EconomyBan: "none"})
+
+	_, errSync := bansync.New(provider, steamid.Collection{sid}, bansync.WithStore(bansync.NewFileStore(path))).Sync(ctx)
+	require.NoError(t, errSync)
+
+	// The stored state is loaded by a new syncer, so the ban is reported as a change.
+	provider.set(steamid.PlayerBanState{SteamID: sid, NumberOfGameBans: 1, EconomyBan: "none"})
+
+	syncer := bansync.New(provider, steamid.Collection{sid},
+		bansync.WithStore(bansync.NewFileStore(path)), bansync.WithInterval(time.Nanosecond))
+
+	events, errSync := syncer.Sync(ctx)
+	require.NoError(t, errSync)
+	require.Len(t, events, 1)
+	require.Equal(t, bansync.GameBanAdded, events[0].Type)
+
+	states, errLoad := bansync.NewFileStore(path).Load(ctx)
+	require.NoError(t, errLoad)
+	require.Len(t, states, 1)
+	require.Equal(t, 1, states[0].NumberOfGameBans)
+	require.False(t, states[0].CheckedAt.IsZero())
+
+	missing, errMissing := bansync.NewFileStore(filepath.Join(t.TempDir(), "missing.json")).Load(ctx)
+	require.NoError(t, errMissing)
+	require.Empty(t, missing)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sid      = steamid.New(76561198132612090)
+		provider = &testProvider{bans: map[steamid.SteamID]steamid.PlayerBanState{}}
+		events   = make(chan bansync.Event, 1)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	syncer := bansync.New(provider, steamid.Collection{sid}, bansync.WithInterval(time.Millisecond))
+
+	go syncer.Run(ctx, func(event bansync.Event) {
+		events <- event
+	})
+
+	require.Eventually(t, func() bool {
+		return len(syncer.States()) == 1
+	}, time.Second, time.Millisecond)
+
+	provider.set(steamid.PlayerBanState{SteamID: sid, NumberOfVACBans: 1, VACBanned: true, EconomyBan: "none"})
+
+	select {
+	case event := <-events:
+		require.Equal(t, bansync.VACBanAdded, event.Type)
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+	}
+}
@@ -0,0 +1,118 @@
+package bansync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists the last known ban states between syncs.
+type Store interface {
+	// Load returns all stored states. An empty store returns no states and no error.
+	Load(ctx context.Context) ([]State, error)
+	// Save replaces the stored states.
+	Save(ctx context.Context, states []State) error
+}
+
+var (
+	_ Store = (*MemoryStore)(nil)
+	_ Store = (*FileStore)(nil)
+)
+
+// MemoryStore is a Store which keeps the states in memory, so they are lost when the process
+// exits.
+type MemoryStore struct {
+	mu     sync.Mutex
+	states []State
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Load implements Store.
+func (m *MemoryStore) Load(_ context.Context) ([]State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]State(nil), m.states...), nil
+}
+
+// Save implements Store.
+func (m *MemoryStore) Save(_ context.Context, states []State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.states = append([]State(nil), states...)
+
+	return nil
+}
+
+// FileStore is a Store which keeps the states in a JSON file. Saves write a temporary file that
+// replaces the previous one, so an interrupted save does not corrupt the stored states.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore returns a store using the file at path. The file does not need to exist yet.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load implements Store.
+func (f *FileStore) Load(_ context.Context) ([]State, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, errRead := os.ReadFile(f.path)
+	if errRead != nil {
+		if errors.Is(errRead, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, errRead //nolint:wrapcheck
+	}
+
+	var states []State
+	if errUnmarshal := json.Unmarshal(data, &states); errUnmarshal != nil {
+		return nil, errUnmarshal //nolint:wrapcheck
+	}
+
+	return states, nil
+}
+
+// Save implements Store.
+func (f *FileStore) Save(_ context.Context, states []State) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, errMarshal := json.Marshal(states)
+	if errMarshal != nil {
+		return errMarshal //nolint:wrapcheck
+	}
+
+	temp, errCreate := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if errCreate != nil {
+		return errCreate //nolint:wrapcheck
+	}
+
+	if _, errWrite := temp.Write(data); errWrite != nil {
+		_ = temp.Close()
+		_ = os.Remove(temp.Name())
+
+		return errWrite //nolint:wrapcheck
+	}
+
+	if errClose := temp.Close(); errClose != nil {
+		_ = os.Remove(temp.Name())
+
+		return errClose //nolint:wrapcheck
+	}
+
+	return os.Rename(temp.Name(), f.path) //nolint:wrapcheck
+}
@@ -0,0 +1,94 @@
+package extra_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+)
+
+// benchLog builds a server log of about size bytes mixing chat lines, status output and lines
+// without any ids, similar to the inputs of the cli parse command.
+func benchLog(size int) []byte {
+	lines := []string{
+		`L 01/01/2024 - 00:00:00: "Dulahan<4247><[U:1:148883280]><Red>" say "gg"` + "\n",
+		`L 01/01/2024 - 00:00:01: "Nox<4235><[U:1:186134686]><Blue>" killed "WolfXine<2><[U:1:166779318]><Red>" with "scattergun"` + "\n",
+		"L 01/01/2024 - 00:00:02: World triggered \"Round_Start\"\n",
+		"banid 0 STEAM_0:1:507127 https://steamcommunity.com/profiles/76561198084134025/\n",
+		fuzzStatusSeed,
+	}
+
+	var buf bytes.Buffer
+
+	for idx := 0; buf.Len() < size; idx++ {
+		buf.WriteString(lines[idx%len(lines)])
+	}
+
+	return buf.Bytes()
+}
+
+func BenchmarkFindReaderSteamIDs(b *testing.B) {
+	input := benchLog(1 << 20)
+
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+
+	for range b.N {
+		_ = extra.FindReaderSteamIDs(bytes.NewReader(input))
+	}
+}
+
+func BenchmarkFindReaderSteamIDMatches(b *testing.B) {
+	input := benchLog(1 << 20)
+
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+
+	for range b.N {
+		_, _ = extra.FindReaderSteamIDMatches(bytes.NewReader(input))
+	}
+}
+
+func BenchmarkParseStatus(b *testing.B) {
+	b.SetBytes(int64(len(fuzzStatusSeed)))
+	b.ReportAllocs()
+
+	for range b.N {
+		_, _ = extra.ParseStatus(fuzzStatusSeed, true)
+	}
+}
+
+func BenchmarkFindSteamIDs(b *testing.B) {
+	line := `L 01/01/2024 - 00:00:01: "Nox<4235><[U:1:186134686]><Blue>" killed "WolfXine<2><STEAM_0:0:83389659><Red>"`
+
+	b.SetBytes(int64(len(line)))
+	b.ReportAllocs()
+
+	for range b.N {
+		_ = extra.FindSteamIDs(line)
+	}
+}
+
+func BenchmarkScanFileSteamIDStats(b *testing.B) {
+	var (
+		input = benchLog(16 << 20)
+		path  = filepath.Join(b.TempDir(), "bench.log")
+	)
+
+	if err := os.WriteFile(path, input, 0o600); err != nil {
+		b.Fatal(err)
+	}
+
+	for name, opts := range map[string][]extra.ScanOption{"read": nil, "mmap": {extra.WithMmap()}} {
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			b.ReportAllocs()
+
+			for range b.N {
+				_, _, _ = extra.ScanFileSteamIDStats(path, opts...)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package extra
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// logTimeLayout is the timestamp format used by srcds log files.
+const logTimeLayout = "01/02/2006 - 15:04:05"
+
+var reChatLine = regexp.MustCompile(
+	`^(?:L )?(\d{2}/\d{2}/\d{4} - \d{2}:\d{2}:\d{2}): "(.*?)<(\d+)><(.*?)><(.*?)>" (say|say_team) "(.*)"$`)
+
+var (
+	ErrNotChatLine = errors.New("not a chat line")
+	ErrParseTime   = errors.New("failed to parse log timestamp")
+)
+
+// ChatMessage represents a single `say` or `say_team` line from a srcds log.
+type ChatMessage struct {
+	Time    time.Time
+	Name    string
+	UserID  int
+	SID     steamid.SteamID
+	Team    string
+	Message string
+	// TeamOnly is true for messages sent with say_team.
+	TeamOnly bool
+}
+
+// ParseChatLine parses a single srcds log line containing a chat message, e.g.
+//
+//	L 10/16/2026 - 12:34:56: "Player<12><[U:1:172346362]><Red>" say "hello"
+//
+// ErrNotChatLine is returned for lines that are not chat messages. Messages sent by bots or
+// the server console are returned with an invalid SID.
+func ParseChatLine(line string) (ChatMessage, error) {
+	match := reChatLine.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+	if match == nil {
+		return ChatMessage{}, ErrNotChatLine
+	}
+
+	created, errTime := time.Parse(logTimeLayout, match[1])
+	if errTime != nil {
+		return ChatMessage{}, errors.Join(errTime, ErrParseTime)
+	}
+
+	userID, errUserID := strconv.ParseUint(match[3], 10, 64)
+	if errUserID != nil {
+		return ChatMessage{}, errors.Join(errUserID, ErrParseUserID)
+	}
+
+	return ChatMessage{
+		Time:     created,
+		Name:     match[2],
+		UserID:   int(userID),
+		SID:      steamid.New(match[4]),
+		Team:     match[5],
+		Message:  match[7],
+		TeamOnly: match[6] == "say_team",
+	}, nil
+}
+
+// Alias is a name that a steam id has been seen using.
+type Alias struct {
+	Name      string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// AliasTable builds up the associations between names and steam ids over time. It is safe for
+// concurrent use.
+type AliasTable struct {
+	mu      sync.RWMutex
+	aliases map[steamid.SteamID][]Alias
+}
+
+// NewAliasTable returns an empty AliasTable.
+func NewAliasTable() *AliasTable {
+	return &AliasTable{aliases: map[steamid.SteamID][]Alias{}}
+}
+
+// Add records that sid was seen using name at the time provided. Invalid steam ids and empty
+// names are ignored.
+func (t *AliasTable) Add(sid steamid.SteamID, name string, seen time.Time) {
+	if !sid.Valid() || name == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	aliases := t.aliases[sid]
+	for idx := range aliases {
+		if aliases[idx].Name != name {
+			continue
+		}
+
+		if seen.Before(aliases[idx].FirstSeen) {
+			aliases[idx].FirstSeen = seen
+		}
+
+		if seen.After(aliases[idx].LastSeen) {
+			aliases[idx].LastSeen = seen
+		}
+
+		return
+	}
+
+	t.aliases[sid] = append(aliases, Alias{Name: name, FirstSeen: seen, LastSeen: seen})
+}
+
+// AddChat records the name and steam id of the sender of a chat message.
+func (t *AliasTable) AddChat(msg ChatMessage) {
+	t.Add(msg.SID, msg.Name, msg.Time)
+}
+
+// AddStatus records the name and steam id of every player in a parsed status output. Status output
+// does not contain a timestamp, so the time it was captured must be provided.
+func (t *AliasTable) AddStatus(status Status, seen time.Time) {
+	for _, player := range status.Players {
+		t.Add(player.SID, player.Name, seen)
+	}
+}
+
+// ReadLog records the senders of every chat message in a srcds log stream. Lines that are not
+// chat messages are ignored.
+func (t *AliasTable) ReadLog(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		msg, errMsg := ParseChatLine(scanner.Text())
+		if errMsg != nil {
+			continue
+		}
+
+		t.AddChat(msg)
+	}
+
+	if errScan := scanner.Err(); errScan != nil {
+		return errors.Join(errScan, ErrScan)
+	}
+
+	return nil
+}
+
+// Aliases returns all the names that sid has been seen using, ordered by when they were first seen.
+func (t *AliasTable) Aliases(sid steamid.SteamID) []Alias {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	aliases := slices.Clone(t.aliases[sid])
+	slices.SortStableFunc(aliases, func(a, b Alias) int {
+		return a.FirstSeen.Compare(b.FirstSeen)
+	})
+
+	return aliases
+}
+
+// SteamIDs returns all the steam ids that have been seen using name. Names are matched exactly.
+func (t *AliasTable) SteamIDs(name string) steamid.Collection {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var found steamid.Collection
+
+	for sid, aliases := range t.aliases {
+		if slices.ContainsFunc(aliases, func(alias Alias) bool {
+			return alias.Name == name
+		}) {
+			found = append(found, sid)
+		}
+	}
+
+	slices.SortFunc(found, func(a, b steamid.SteamID) int {
+		return cmp.Compare(a.Uint64(), b.Uint64())
+	})
+
+	return found
+}
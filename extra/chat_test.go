@@ -0,0 +1,62 @@
+package extra_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChatLine(t *testing.T) {
+	t.Parallel()
+
+	msg, err := extra.ParseChatLine(`L 10/16/2026 - 12:34:56: "Some <Name>" <3<12><[U:1:172346362]><Red>" say_team "gg "ez""`)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 10, 16, 12, 34, 56, 0, time.UTC), msg.Time)
+	require.Equal(t, `Some <Name>" <3`, msg.Name)
+	require.Equal(t, 12, msg.UserID)
+	require.Equal(t, steamid.New(76561198132612090), msg.SID)
+	require.Equal(t, "Red", msg.Team)
+	require.Equal(t, `gg "ez"`, msg.Message)
+	require.True(t, msg.TeamOnly)
+
+	console, errConsole := extra.ParseChatLine(`L 10/16/2026 - 12:34:56: "Console<0><Console><Console>" say "restarting"`)
+	require.NoError(t, errConsole)
+	require.False(t, console.SID.Valid())
+
+	_, errNotChat := extra.ParseChatLine(`L 10/16/2026 - 12:34:56: "Player<12><[U:1:172346362]><Red>" triggered "domination"`)
+	require.ErrorIs(t, errNotChat, extra.ErrNotChatLine)
+}
+
+func TestAliasTable(t *testing.T) {
+	t.Parallel()
+
+	log := `L 10/16/2026 - 12:00:00: "first<2><[U:1:172346362]><Red>" say "hi"
+L 10/16/2026 - 12:01:00: "joined team"
+L 10/16/2026 - 12:05:00: "second<2><[U:1:172346362]><Red>" say "changed name"
+L 10/16/2026 - 12:10:00: "first<2><[U:1:172346362]><Red>" say_team "and back"
+L 10/16/2026 - 12:11:00: "first<3><STEAM_0:1:61934148><Blue>" say "same name"
+L 10/16/2026 - 12:12:00: "bot<4><BOT><Blue>" say "beep"
+`
+
+	table := extra.NewAliasTable()
+	require.NoError(t, table.ReadLog(strings.NewReader(log)))
+
+	sid := steamid.New("[U:1:172346362]")
+	aliases := table.Aliases(sid)
+	require.Len(t, aliases, 2)
+	require.Equal(t, "first", aliases[0].Name)
+	require.Equal(t, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), aliases[0].FirstSeen)
+	require.Equal(t, time.Date(2026, 10, 16, 12, 10, 0, 0, time.UTC), aliases[0].LastSeen)
+	require.Equal(t, "second", aliases[1].Name)
+
+	require.Equal(t, steamid.Collection{steamid.New(76561198084134025), sid}, table.SteamIDs("first"))
+	require.Empty(t, table.SteamIDs("bot"))
+
+	table.AddStatus(extra.Status{Players: []extra.Player{{Name: "third", SID: sid}}},
+		time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+	require.Len(t, table.Aliases(sid), 3)
+}
@@ -0,0 +1,533 @@
+package extra
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+const (
+	demoStampSource1 = "HL2DEMO\x00"
+	demoStampSource2 = "PBDEMS2\x00"
+	demoPathLen      = 260
+	// demoMaxFrameLen limits the size of a single frame payload that will be read into memory.
+	demoMaxFrameLen = 64 * 1024 * 1024
+	// demoCmdInfoLen is the size of the democmdinfo_t struct preceding packet data.
+	demoCmdInfoLen = 76
+)
+
+// Source 1 demo frame commands. Demo protocol 4 (CS:GO) inserted dem_customdata before
+// dem_stringtables.
+const (
+	demSignon       = 1
+	demPacket       = 2
+	demSyncTick     = 3
+	demConsoleCmd   = 4
+	demUserCmd      = 5
+	demDataTables   = 6
+	demStop         = 7
+	demStringTables = 8
+	demCustomData   = 8
+)
+
+var (
+	ErrDemoRead        = errors.New("failed to read demo")
+	ErrDemoFormat      = errors.New("invalid demo file")
+	ErrDemoUnsupported = errors.New("unsupported demo format")
+	ErrDemoFrame       = errors.New("invalid demo frame")
+)
+
+// DemoHeader contains the header fields of a Source 1 demo file.
+type DemoHeader struct {
+	DemoProtocol    int
+	NetworkProtocol int
+	ServerName      string
+	ClientName      string
+	MapName         string
+	GameDirectory   string
+	PlaybackTime    time.Duration
+	Ticks           int
+	Frames          int
+	SignonLength    int
+}
+
+// DemoPlayer is a player found within the userinfo string table of a demo.
+type DemoPlayer struct {
+	SID    steamid.SteamID
+	Name   string
+	UserID int
+	// GUID is the steam id as the engine formatted it, e.g. STEAM_0:1:61934148 or [U:1:123868297].
+	GUID string
+	// Bot and HLTV are set for bots and SourceTV respectively, these do not have a valid SID.
+	Bot  bool
+	HLTV bool
+}
+
+// Demo is the result of ParseDemo. Players holds those found in the string table snapshots, which
+// leaves out most players that joined after the recording started.
+type Demo struct {
+	Header  DemoHeader
+	Players []DemoPlayer
+}
+
+type rawDemoHeader struct {
+	DemoProtocol    int32
+	NetworkProtocol int32
+	ServerName      [demoPathLen]byte
+	ClientName      [demoPathLen]byte
+	MapName         [demoPathLen]byte
+	GameDirectory   [demoPathLen]byte
+	PlaybackTime    float32
+	Ticks           int32
+	Frames          int32
+	SignonLength    int32
+}
+
+func cString(value []byte) string {
+	if idx := bytes.IndexByte(value, 0); idx >= 0 {
+		return string(value[:idx])
+	}
+
+	return string(value)
+}
+
+// ParseDemo reads the header and string tables of a Source 1 (.dem) demo file, such as those
+// recorded by TF2, CS:GO and other Source 2013 games, and extracts the participating players.
+//
+// Only the string table snapshots stored in the demo are read. The userinfo updates sent in
+// network packets are not decoded, so players are those that were connected when a snapshot was
+// written, which is typically the start of the recording. Players that joined later are usually
+// missing, and the result should not be treated as everyone that played.
+//
+// Source 2 demos (CS2, Deadlock) are detected and ErrDemoUnsupported is returned.
+func ParseDemo(reader io.Reader) (Demo, error) {
+	bufReader := bufio.NewReader(reader)
+
+	header, errHeader := readDemoHeader(bufReader)
+	if errHeader != nil {
+		return Demo{}, errHeader
+	}
+
+	demo := Demo{Header: header}
+	players := map[int]DemoPlayer{}
+
+	var order []int
+
+	for {
+		tables, errFrame := readDemoFrame(bufReader, header.DemoProtocol)
+		if errFrame != nil {
+			if errors.Is(errFrame, io.EOF) {
+				break
+			}
+
+			return demo, errFrame
+		}
+
+		for _, player := range tables {
+			if _, found := players[player.UserID]; !found {
+				order = append(order, player.UserID)
+			}
+
+			players[player.UserID] = player
+		}
+	}
+
+	for _, userID := range order {
+		demo.Players = append(demo.Players, players[userID])
+	}
+
+	return demo, nil
+}
+
+func readDemoHeader(reader io.Reader) (DemoHeader, error) {
+	stamp := make([]byte, len(demoStampSource1))
+	if _, errStamp := io.ReadFull(reader, stamp); errStamp != nil {
+		return DemoHeader{}, errors.Join(errStamp, ErrDemoRead)
+	}
+
+	switch string(stamp) {
+	case demoStampSource1:
+	case demoStampSource2:
+		return DemoHeader{}, fmt.Errorf("%w: source 2", ErrDemoUnsupported)
+	default:
+		return DemoHeader{}, ErrDemoFormat
+	}
+
+	var raw rawDemoHeader
+	if errRead := binary.Read(reader, binary.LittleEndian, &raw); errRead != nil {
+		return DemoHeader{}, errors.Join(errRead, ErrDemoRead)
+	}
+
+	playback := float64(raw.PlaybackTime)
+	if math.IsNaN(playback) || math.IsInf(playback, 0) || playback < 0 || playback > math.MaxInt32 {
+		playback = 0
+	}
+
+	return DemoHeader{
+		DemoProtocol:    int(raw.DemoProtocol),
+		NetworkProtocol: int(raw.NetworkProtocol),
+		ServerName:      cString(raw.ServerName[:]),
+		ClientName:      cString(raw.ClientName[:]),
+		MapName:         cString(raw.MapName[:]),
+		GameDirectory:   cString(raw.GameDirectory[:]),
+		PlaybackTime:    time.Duration(playback * float64(time.Second)),
+		Ticks:           int(raw.Ticks),
+		Frames:          int(raw.Frames),
+		SignonLength:    int(raw.SignonLength),
+	}, nil
+}
+
+func readInt32(reader io.Reader) (int, error) {
+	var value int32
+	if errRead := binary.Read(reader, binary.LittleEndian, &value); errRead != nil {
+		return 0, errors.Join(errRead, ErrDemoRead)
+	}
+
+	return int(value), nil
+}
+
+func skipBytes(reader io.Reader, count int64) error {
+	if _, errSkip := io.CopyN(io.Discard, reader, count); errSkip != nil {
+		return errors.Join(errSkip, ErrDemoRead)
+	}
+
+	return nil
+}
+
+// readPayload reads a length prefixed frame payload, discarding it if keep is false.
+func readPayload(reader io.Reader, keep bool) ([]byte, error) {
+	length, errLength := readInt32(reader)
+	if errLength != nil {
+		return nil, errLength
+	}
+
+	if length < 0 || length > demoMaxFrameLen {
+		return nil, fmt.Errorf("%w: payload length %d", ErrDemoFrame, length)
+	}
+
+	if !keep {
+		return nil, skipBytes(reader, int64(length))
+	}
+
+	payload := make([]byte, length)
+	if _, errRead := io.ReadFull(reader, payload); errRead != nil {
+		return nil, errors.Join(errRead, ErrDemoRead)
+	}
+
+	return payload, nil
+}
+
+// readDemoFrame reads a single frame, returning any players found if it was a string tables
+// frame. io.EOF is returned once the stop frame or the end of the file is reached.
+func readDemoFrame(reader *bufio.Reader, protocol int) ([]DemoPlayer, error) {
+	cmd, errCmd := reader.ReadByte()
+	if errCmd != nil {
+		if errors.Is(errCmd, io.EOF) {
+			return nil, io.EOF
+		}
+
+		return nil, errors.Join(errCmd, ErrDemoRead)
+	}
+
+	// tick
+	if _, errTick := readInt32(reader); errTick != nil {
+		return nil, errTick
+	}
+
+	stringTablesCmd := demStringTables
+
+	if protocol >= 4 {
+		// player slot
+		if _, errSlot := reader.ReadByte(); errSlot != nil {
+			return nil, errors.Join(errSlot, ErrDemoRead)
+		}
+
+		stringTablesCmd = demStringTables + 1
+	}
+
+	switch {
+	case cmd == demStop:
+		return nil, io.EOF
+	case cmd == demSyncTick:
+		return nil, nil
+	case cmd == demSignon || cmd == demPacket:
+		cmdInfoLen := int64(demoCmdInfoLen)
+		if protocol >= 4 {
+			// split screen players
+			cmdInfoLen *= 2
+		}
+
+		// cmd info, sequence in and out
+		if errSkip := skipBytes(reader, cmdInfoLen+8); errSkip != nil {
+			return nil, errSkip
+		}
+
+		_, errPayload := readPayload(reader, false)
+
+		return nil, errPayload
+	case cmd == demConsoleCmd || cmd == demDataTables:
+		_, errPayload := readPayload(reader, false)
+
+		return nil, errPayload
+	case cmd == demUserCmd || (protocol >= 4 && cmd == demCustomData):
+		// outgoing sequence or custom data type
+		if _, errSeq := readInt32(reader); errSeq != nil {
+			return nil, errSeq
+		}
+
+		_, errPayload := readPayload(reader, false)
+
+		return nil, errPayload
+	case int(cmd) == stringTablesCmd:
+		payload, errPayload := readPayload(reader, true)
+		if errPayload != nil {
+			return nil, errPayload
+		}
+
+		return parseDemoStringTables(payload, protocol)
+	default:
+		return nil, fmt.Errorf("%w: unknown command %d", ErrDemoFrame, cmd)
+	}
+}
+
+// bitReader reads values from a Source engine bf_write buffer, which stores bits least
+// significant first.
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (b *bitReader) readBit() (bool, error) {
+	if b.pos >= len(b.data)*8 {
+		return false, fmt.Errorf("%w: string table overflow", ErrDemoFrame)
+	}
+
+	value := b.data[b.pos/8]&(1<<(b.pos%8)) != 0
+	b.pos++
+
+	return value, nil
+}
+
+func (b *bitReader) readBits(count int) (uint32, error) {
+	var value uint32
+
+	for idx := 0; idx < count; idx++ {
+		bit, errBit := b.readBit()
+		if errBit != nil {
+			return 0, errBit
+		}
+
+		if bit {
+			value |= 1 << idx
+		}
+	}
+
+	return value, nil
+}
+
+func (b *bitReader) readBytes(count int) ([]byte, error) {
+	if b.pos+count*8 > len(b.data)*8 {
+		return nil, fmt.Errorf("%w: string table overflow", ErrDemoFrame)
+	}
+
+	out := make([]byte, count)
+
+	for idx := range out {
+		value, errValue := b.readBits(8)
+		if errValue != nil {
+			return nil, errValue
+		}
+
+		out[idx] = byte(value)
+	}
+
+	return out, nil
+}
+
+func (b *bitReader) readString() (string, error) {
+	var out []byte
+
+	for {
+		value, errValue := b.readBits(8)
+		if errValue != nil {
+			return "", errValue
+		}
+
+		if value == 0 {
+			return string(out), nil
+		}
+
+		out = append(out, byte(value))
+	}
+}
+
+type stringTableEntry struct {
+	value    string
+	userData []byte
+}
+
+func (b *bitReader) readStringTableEntries() ([]stringTableEntry, error) {
+	count, errCount := b.readBits(16)
+	if errCount != nil {
+		return nil, errCount
+	}
+
+	var entries []stringTableEntry
+
+	for idx := 0; idx < int(count); idx++ {
+		value, errValue := b.readString()
+		if errValue != nil {
+			return nil, errValue
+		}
+
+		entry := stringTableEntry{value: value}
+
+		hasData, errHasData := b.readBit()
+		if errHasData != nil {
+			return nil, errHasData
+		}
+
+		if hasData {
+			size, errSize := b.readBits(16)
+			if errSize != nil {
+				return nil, errSize
+			}
+
+			entry.userData, errValue = b.readBytes(int(size))
+			if errValue != nil {
+				return nil, errValue
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseDemoStringTables(payload []byte, protocol int) ([]DemoPlayer, error) {
+	reader := &bitReader{data: payload}
+
+	numTables, errNumTables := reader.readBits(8)
+	if errNumTables != nil {
+		return nil, errNumTables
+	}
+
+	var players []DemoPlayer
+
+	for idx := 0; idx < int(numTables); idx++ {
+		name, errName := reader.readString()
+		if errName != nil {
+			return nil, errName
+		}
+
+		entries, errEntries := reader.readStringTableEntries()
+		if errEntries != nil {
+			return nil, errEntries
+		}
+
+		hasClientEntries, errClient := reader.readBit()
+		if errClient != nil {
+			return nil, errClient
+		}
+
+		if hasClientEntries {
+			clientEntries, errClientEntries := reader.readStringTableEntries()
+			if errClientEntries != nil {
+				return nil, errClientEntries
+			}
+
+			entries = append(entries, clientEntries...)
+		}
+
+		if name != "userinfo" {
+			continue
+		}
+
+		for _, entry := range entries {
+			if len(entry.userData) == 0 {
+				continue
+			}
+
+			player, errPlayer := parsePlayerInfo(entry.userData, protocol)
+			if errPlayer != nil {
+				return nil, errPlayer
+			}
+
+			players = append(players, player)
+		}
+	}
+
+	return players, nil
+}
+
+// player_info_t layouts. Source 2013 games such as TF2 store the struct in little endian, while
+// CS:GO (demo protocol 4) has a larger struct with a leading version and xuid stored in big endian.
+const (
+	playerInfoLen         = 132
+	playerInfoLenCSGO     = 340
+	playerInfoNameLen     = 32
+	playerInfoNameLenCSGO = 128
+	playerInfoGUIDLen     = 33
+)
+
+func parsePlayerInfo(data []byte, protocol int) (DemoPlayer, error) {
+	var (
+		player    DemoPlayer
+		order     binary.ByteOrder = binary.LittleEndian
+		offset    int
+		nameLen   = playerInfoNameLen
+		xuid      uint64
+		minLength = playerInfoLen
+	)
+
+	if protocol >= 4 {
+		order = binary.BigEndian
+		nameLen = playerInfoNameLenCSGO
+		minLength = playerInfoLenCSGO
+	}
+
+	if len(data) < minLength {
+		return DemoPlayer{}, fmt.Errorf("%w: player info length %d", ErrDemoFrame, len(data))
+	}
+
+	if protocol >= 4 {
+		xuid = order.Uint64(data[8:16])
+		offset = 16
+	}
+
+	player.Name = cString(data[offset : offset+nameLen])
+	offset += nameLen
+	player.UserID = int(int32(order.Uint32(data[offset : offset+4])))
+	offset += 4
+	player.GUID = cString(data[offset : offset+playerInfoGUIDLen])
+	// friendsID is 4 byte aligned after the guid
+	offset += playerInfoGUIDLen + 3
+	friendsID := order.Uint32(data[offset : offset+4])
+	offset += 4 + nameLen
+	player.Bot = data[offset] != 0
+	player.HLTV = data[offset+1] != 0
+
+	if player.Bot || player.HLTV {
+		return player, nil
+	}
+
+	switch {
+	case xuid != 0:
+		player.SID = steamid.New(strconv.FormatUint(xuid, 10))
+	case friendsID != 0:
+		player.SID = steamid.New(int64(friendsID))
+	default:
+		player.SID = steamid.New(player.GUID)
+	}
+
+	return player, nil
+}
@@ -0,0 +1,170 @@
+package extra_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+// bitWriter writes values in the same least significant bit first order as the engine's bf_write.
+type bitWriter struct {
+	data []byte
+	pos  int
+}
+
+func (b *bitWriter) writeBits(value uint32, count int) {
+	for idx := 0; idx < count; idx++ {
+		if b.pos/8 >= len(b.data) {
+			b.data = append(b.data, 0)
+		}
+
+		if value&(1<<idx) != 0 {
+			b.data[b.pos/8] |= 1 << (b.pos % 8)
+		}
+
+		b.pos++
+	}
+}
+
+func (b *bitWriter) writeString(value string) {
+	for _, char := range []byte(value) {
+		b.writeBits(uint32(char), 8)
+	}
+
+	b.writeBits(0, 8)
+}
+
+func fixedString(value string, size int) []byte {
+	out := make([]byte, size)
+	copy(out, value)
+
+	return out
+}
+
+func tf2PlayerInfo(name string, userID int32, guid string, friendsID uint32, bot bool) []byte {
+	var buf bytes.Buffer
+
+	buf.Write(fixedString(name, 32))
+	_ = binary.Write(&buf, binary.LittleEndian, userID)
+	buf.Write(fixedString(guid, 33))
+	buf.Write([]byte{0, 0, 0})
+	_ = binary.Write(&buf, binary.LittleEndian, friendsID)
+	buf.Write(fixedString(name, 32))
+
+	if bot {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+
+	buf.Write(make([]byte, 132-buf.Len()))
+
+	return buf.Bytes()
+}
+
+func buildTestDemo(t *testing.T) []byte {
+	t.Helper()
+
+	var demo bytes.Buffer
+
+	demo.WriteString("HL2DEMO\x00")
+	require.NoError(t, binary.Write(&demo, binary.LittleEndian, []int32{3, 24}))
+	demo.Write(fixedString("Uncletopia | US West 2", 260))
+	demo.Write(fixedString("SourceTV Demo", 260))
+	demo.Write(fixedString("pl_goldrush", 260))
+	demo.Write(fixedString("tf", 260))
+	require.NoError(t, binary.Write(&demo, binary.LittleEndian, float32(90.5)))
+	require.NoError(t, binary.Write(&demo, binary.LittleEndian, []int32{6033, 3000, 0}))
+
+	// A packet frame that should be skipped
+	demo.WriteByte(2)
+	require.NoError(t, binary.Write(&demo, binary.LittleEndian, int32(1)))
+	demo.Write(make([]byte, 76+8))
+	require.NoError(t, binary.Write(&demo, binary.LittleEndian, int32(4)))
+	demo.Write([]byte{1, 2, 3, 4})
+
+	// sync tick
+	demo.WriteByte(3)
+	require.NoError(t, binary.Write(&demo, binary.LittleEndian, int32(1)))
+
+	var tables bitWriter
+
+	tables.writeBits(2, 8)
+	tables.writeString("downloadables")
+	tables.writeBits(1, 16)
+	tables.writeString("materials/custom.vmt")
+	tables.writeBits(0, 1)
+	tables.writeBits(0, 1)
+	tables.writeString("userinfo")
+
+	players := [][]byte{
+		tf2PlayerInfo("SourceTV", 2, "BOT", 0, true),
+		tf2PlayerInfo("Dulahan", 4247, "[U:1:148883280]", 148883280, false),
+		tf2PlayerInfo("Nox", 4235, "[U:1:186134686]", 0, false),
+	}
+
+	tables.writeBits(uint32(len(players)), 16)
+
+	for idx, info := range players {
+		tables.writeString(string(rune('0' + idx)))
+		tables.writeBits(1, 1)
+		tables.writeBits(uint32(len(info)), 16)
+
+		for _, value := range info {
+			tables.writeBits(uint32(value), 8)
+		}
+	}
+
+	tables.writeBits(0, 1)
+
+	demo.WriteByte(8)
+	require.NoError(t, binary.Write(&demo, binary.LittleEndian, int32(2)))
+	require.NoError(t, binary.Write(&demo, binary.LittleEndian, int32(len(tables.data))))
+	demo.Write(tables.data)
+
+	// stop
+	demo.WriteByte(7)
+	require.NoError(t, binary.Write(&demo, binary.LittleEndian, int32(3)))
+
+	return demo.Bytes()
+}
+
+func TestParseDemo(t *testing.T) {
+	t.Parallel()
+
+	demo, err := extra.ParseDemo(bytes.NewReader(buildTestDemo(t)))
+	require.NoError(t, err)
+	require.Equal(t, extra.DemoHeader{
+		DemoProtocol:    3,
+		NetworkProtocol: 24,
+		ServerName:      "Uncletopia | US West 2",
+		ClientName:      "SourceTV Demo",
+		MapName:         "pl_goldrush",
+		GameDirectory:   "tf",
+		PlaybackTime:    90500 * time.Millisecond,
+		Ticks:           6033,
+		Frames:          3000,
+	}, demo.Header)
+	require.Len(t, demo.Players, 3)
+	require.True(t, demo.Players[0].Bot)
+	require.False(t, demo.Players[0].SID.Valid())
+	require.Equal(t, "Dulahan", demo.Players[1].Name)
+	require.Equal(t, 4247, demo.Players[1].UserID)
+	require.Equal(t, steamid.New("[U:1:148883280]"), demo.Players[1].SID)
+	require.Equal(t, steamid.New("[U:1:186134686]"), demo.Players[2].SID)
+
+	_, errSource2 := extra.ParseDemo(bytes.NewReader([]byte("PBDEMS2\x00rest of file")))
+	require.ErrorIs(t, errSource2, extra.ErrDemoUnsupported)
+
+	_, errFormat := extra.ParseDemo(bytes.NewReader([]byte("not a demo file")))
+	require.ErrorIs(t, errFormat, extra.ErrDemoFormat)
+
+	truncated := buildTestDemo(t)
+	_, errTruncated := extra.ParseDemo(bytes.NewReader(truncated[:len(truncated)-40]))
+	require.ErrorIs(t, errTruncated, extra.ErrDemoRead)
+}
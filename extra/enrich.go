@@ -0,0 +1,326 @@
+package extra
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+var (
+	ErrFetchSummaries = errors.New("failed to fetch player summaries")
+	ErrFetchBans      = errors.New("failed to fetch player bans")
+)
+
+// PlayerDataProvider provides the Steam Web API lookups used by EnrichPlayers. It is implemented
+// by *steamid.Client and the cache returned by NewPlayerDataCache.
+type PlayerDataProvider interface {
+	PlayerSummaries(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerSummary, error)
+	PlayerBans(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerBanState, error)
+}
+
+var (
+	_ PlayerDataProvider = (*steamid.Client)(nil)
+	_ PlayerDataProvider = (*PlayerDataCache)(nil)
+)
+
+// EnrichedPlayer is a status Player joined with their profile summary and ban state.
+type EnrichedPlayer struct {
+	Player
+	PersonaName      string
+	AvatarURL        string
+	ProfileURL       string
+	CommunityBanned  bool
+	VACBanned        bool
+	NumberOfVACBans  int
+	NumberOfGameBans int
+	DaysSinceLastBan int
+	// AccountCreated and AccountAge are zero when the profile is private.
+	AccountCreated time.Time
+	AccountAge     time.Duration
+	// HasSummary and HasBans are false when the API did not return data for the player.
+	HasSummary bool
+	HasBans    bool
+}
+
+// EnrichPlayers joins the players from a ParseStatus result with their profile summaries and ban
+// states. All players are looked up together, so the number of requests made is
+// the minimum required by the API batch limits. Players are returned in the same order as provided.
+func EnrichPlayers(ctx context.Context, client PlayerDataProvider, players []Player) ([]EnrichedPlayer, error) {
+	steamIDs := make(steamid.Collection, 0, len(players))
+	for _, player := range players {
+		steamIDs = append(steamIDs, player.SID)
+	}
+
+	summaries, errSummaries := client.PlayerSummaries(ctx, steamIDs)
+	if errSummaries != nil {
+		return nil, errors.Join(errSummaries, ErrFetchSummaries)
+	}
+
+	bans, errBans := client.PlayerBans(ctx, steamIDs)
+	if errBans != nil {
+		return nil, errors.Join(errBans, ErrFetchBans)
+	}
+
+	summaryMap := make(map[steamid.SteamID]steamid.PlayerSummary, len(summaries))
+	for _, summary := range summaries {
+		summaryMap[summary.SteamID] = summary
+	}
+
+	banMap := make(map[steamid.SteamID]steamid.PlayerBanState, len(bans))
+	for _, ban := range bans {
+		banMap[ban.SteamID] = ban
+	}
+
+	now := time.Now()
+	enriched := make([]EnrichedPlayer, len(players))
+
+	for idx, player := range players {
+		result := EnrichedPlayer{Player: player}
+
+		if summary, found := summaryMap[player.SID]; found {
+			result.HasSummary = true
+			result.PersonaName = summary.PersonaName
+			result.AvatarURL = summary.AvatarFull
+			result.ProfileURL = summary.ProfileURL
+
+			if created := summary.Created(); !created.IsZero() {
+				result.AccountCreated = created
+				result.AccountAge = now.Sub(created)
+			}
+		}
+
+		if ban, found := banMap[player.SID]; found {
+			result.HasBans = true
+			result.CommunityBanned = ban.CommunityBanned
+			result.VACBanned = ban.VACBanned
+			result.NumberOfVACBans = ban.NumberOfVACBans
+			result.NumberOfGameBans = ban.NumberOfGameBans
+			result.DaysSinceLastBan = ban.DaysSinceLastBan
+		}
+
+		enriched[idx] = result
+	}
+
+	return enriched, nil
+}
+
+// DefaultPlayerCacheSize is the number of summaries, and of ban states, kept by a PlayerDataCache
+// unless set with WithPlayerCacheSize.
+const DefaultPlayerCacheSize = 10000
+
+// expiringEntry is a value held by an expiringCache.
+type expiringEntry[V any] struct {
+	sid     steamid.SteamID
+	value   V
+	expires time.Time
+}
+
+// expiringCache holds values for a ttl, keeping at most maxEntries of them. When it is full the
+// expired entries are removed, then the least recently used ones. It is not safe for concurrent
+// use.
+type expiringCache[V any] struct {
+	ttl        time.Duration
+	maxEntries int
+	entries    map[steamid.SteamID]*list.Element
+	// recent orders the entries from the most to the least recently used.
+	recent *list.List
+}
+
+func newExpiringCache[V any](ttl time.Duration, maxEntries int) *expiringCache[V] {
+	return &expiringCache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    map[steamid.SteamID]*list.Element{},
+		recent:     list.New(),
+	}
+}
+
+func (c *expiringCache[V]) get(sid steamid.SteamID, now time.Time) (V, bool) {
+	elem, found := c.entries[sid]
+	if !found {
+		var empty V
+
+		return empty, false
+	}
+
+	entry, _ := elem.Value.(*expiringEntry[V])
+	if !now.Before(entry.expires) {
+		c.remove(elem)
+
+		var empty V
+
+		return empty, false
+	}
+
+	c.recent.MoveToFront(elem)
+
+	return entry.value, true
+}
+
+func (c *expiringCache[V]) add(sid steamid.SteamID, value V, now time.Time) {
+	if elem, found := c.entries[sid]; found {
+		entry, _ := elem.Value.(*expiringEntry[V])
+		entry.value = value
+		entry.expires = now.Add(c.ttl)
+		c.recent.MoveToFront(elem)
+
+		return
+	}
+
+	if len(c.entries) >= c.maxEntries {
+		for elem := c.recent.Back(); elem != nil; {
+			prev := elem.Prev()
+
+			if entry, _ := elem.Value.(*expiringEntry[V]); !now.Before(entry.expires) {
+				c.remove(elem)
+			}
+
+			elem = prev
+		}
+	}
+
+	for len(c.entries) >= c.maxEntries {
+		c.remove(c.recent.Back())
+	}
+
+	c.entries[sid] = c.recent.PushFront(&expiringEntry[V]{sid: sid, value: value, expires: now.Add(c.ttl)})
+}
+
+func (c *expiringCache[V]) remove(elem *list.Element) {
+	entry, _ := c.recent.Remove(elem).(*expiringEntry[V])
+	delete(c.entries, entry.sid)
+}
+
+// PlayerDataCache is a PlayerDataProvider that caches the results of another provider in memory.
+// Only the ids missing from the cache are requested from the underlying provider. Results expire
+// after the ttl, and once the cache is full the expired and then the least recently used results
+// are evicted.
+type PlayerDataCache struct {
+	source    PlayerDataProvider
+	mu        sync.Mutex
+	summaries *expiringCache[steamid.PlayerSummary]
+	bans      *expiringCache[steamid.PlayerBanState]
+	hits      int
+	misses    int
+}
+
+// PlayerCacheOption configures a PlayerDataCache.
+type PlayerCacheOption func(*playerCacheOptions)
+
+type playerCacheOptions struct {
+	size int
+}
+
+// WithPlayerCacheSize sets the number of summaries, and of ban states, kept by the cache, which
+// is DefaultPlayerCacheSize by default.
+func WithPlayerCacheSize(size int) PlayerCacheOption {
+	return func(o *playerCacheOptions) {
+		if size > 0 {
+			o.size = size
+		}
+	}
+}
+
+// NewPlayerDataCache returns a cache over the source provider that keeps results for the duration of ttl.
+func NewPlayerDataCache(source PlayerDataProvider, ttl time.Duration, opts ...PlayerCacheOption) *PlayerDataCache {
+	options := playerCacheOptions{size: DefaultPlayerCacheSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &PlayerDataCache{
+		source:    source,
+		summaries: newExpiringCache[steamid.PlayerSummary](ttl, options.size),
+		bans:      newExpiringCache[steamid.PlayerBanState](ttl, options.size),
+	}
+}
+
+// Stats returns the number of ids found in and missing from the cache across all lookups.
+func (c *PlayerDataCache) Stats() (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hits, c.misses
+}
+
+// PlayerSummaries implements PlayerDataProvider.
+func (c *PlayerDataCache) PlayerSummaries(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerSummary, error) {
+	var (
+		results []steamid.PlayerSummary
+		missing steamid.Collection
+		now     = time.Now()
+	)
+
+	c.mu.Lock()
+	for _, sid := range steamIDs {
+		if cached, found := c.summaries.get(sid, now); found {
+			results = append(results, cached)
+		} else {
+			missing = append(missing, sid)
+		}
+	}
+
+	c.hits += len(steamIDs) - len(missing)
+	c.misses += len(missing)
+	c.mu.Unlock()
+
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	fetched, errFetch := c.source.PlayerSummaries(ctx, missing)
+	if errFetch != nil {
+		return nil, errFetch //nolint:wrapcheck
+	}
+
+	c.mu.Lock()
+	for _, summary := range fetched {
+		c.summaries.add(summary.SteamID, summary, now)
+	}
+	c.mu.Unlock()
+
+	return append(results, fetched...), nil
+}
+
+// PlayerBans implements PlayerDataProvider.
+func (c *PlayerDataCache) PlayerBans(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerBanState, error) {
+	var (
+		results []steamid.PlayerBanState
+		missing steamid.Collection
+		now     = time.Now()
+	)
+
+	c.mu.Lock()
+	for _, sid := range steamIDs {
+		if cached, found := c.bans.get(sid, now); found {
+			results = append(results, cached)
+		} else {
+			missing = append(missing, sid)
+		}
+	}
+
+	c.hits += len(steamIDs) - len(missing)
+	c.misses += len(missing)
+	c.mu.Unlock()
+
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	fetched, errFetch := c.source.PlayerBans(ctx, missing)
+	if errFetch != nil {
+		return nil, errFetch //nolint:wrapcheck
+	}
+
+	c.mu.Lock()
+	for _, ban := range fetched {
+		c.bans.add(ban.SteamID, ban, now)
+	}
+	c.mu.Unlock()
+
+	return append(results, fetched...), nil
+}
@@ -0,0 +1,136 @@
+package extra_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePlayerData struct {
+	summaryCalls int
+	banCalls     int
+}
+
+func (f *fakePlayerData) PlayerSummaries(_ context.Context, steamIDs steamid.Collection) ([]steamid.PlayerSummary, error) {
+	f.summaryCalls++
+
+	var summaries []steamid.PlayerSummary
+
+	for _, sid := range steamIDs {
+		if sid.AccountID == 186134686 {
+			// Missing profile
+			continue
+		}
+
+		summaries = append(summaries, steamid.PlayerSummary{
+			SteamID:     sid,
+			PersonaName: "persona " + sid.String(),
+			AvatarFull:  "https://avatars.example/" + sid.String(),
+			TimeCreated: time.Now().Add(-time.Hour * 24).Unix(),
+		})
+	}
+
+	return summaries, nil
+}
+
+func (f *fakePlayerData) PlayerBans(_ context.Context, steamIDs steamid.Collection) ([]steamid.PlayerBanState, error) {
+	f.banCalls++
+
+	bans := make([]steamid.PlayerBanState, 0, len(steamIDs))
+	for _, sid := range steamIDs {
+		bans = append(bans, steamid.PlayerBanState{SteamID: sid, VACBanned: sid.AccountID == 148883280, NumberOfVACBans: 1})
+	}
+
+	return bans, nil
+}
+
+func TestEnrichPlayers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		source  = &fakePlayerData{}
+		cache   = extra.NewPlayerDataCache(source, time.Minute)
+		players = []extra.Player{
+			{Name: "Dulahan", SID: steamid.New("[U:1:148883280]")},
+			{Name: "Nox", SID: steamid.New("[U:1:186134686]")},
+		}
+	)
+
+	enriched, err := extra.EnrichPlayers(context.Background(), cache, players)
+	require.NoError(t, err)
+	require.Len(t, enriched, 2)
+
+	require.Equal(t, "Dulahan", enriched[0].Name)
+	require.Equal(t, "persona 76561198109149008", enriched[0].PersonaName)
+	require.True(t, enriched[0].HasSummary)
+	require.True(t, enriched[0].VACBanned)
+	require.InDelta(t, float64(time.Hour*24), float64(enriched[0].AccountAge), float64(time.Minute))
+
+	require.False(t, enriched[1].HasSummary)
+	require.True(t, enriched[1].HasBans)
+	require.False(t, enriched[1].VACBanned)
+
+	_, errCached := extra.EnrichPlayers(context.Background(), cache, players[:1])
+	require.NoError(t, errCached)
+	require.Equal(t, 1, source.summaryCalls)
+	require.Equal(t, 1, source.banCalls)
+
+	hits, misses := cache.Stats()
+	require.Equal(t, 2, hits)
+	require.Equal(t, 4, misses)
+}
+
+func TestPlayerDataCacheSize(t *testing.T) {
+	t.Parallel()
+
+	var (
+		source = &fakePlayerData{}
+		cache  = extra.NewPlayerDataCache(source, time.Minute, extra.WithPlayerCacheSize(2))
+		ctx    = context.Background()
+		sid1   = steamid.New("[U:1:1]")
+		sid2   = steamid.New("[U:1:2]")
+		sid3   = steamid.New("[U:1:3]")
+	)
+
+	_, errBans := cache.PlayerBans(ctx, steamid.Collection{sid1, sid2})
+	require.NoError(t, errBans)
+
+	// Using sid1 keeps it over sid2, the least recently used ban state.
+	_, errBans = cache.PlayerBans(ctx, steamid.Collection{sid1})
+	require.NoError(t, errBans)
+	require.Equal(t, 1, source.banCalls)
+
+	_, errBans = cache.PlayerBans(ctx, steamid.Collection{sid3})
+	require.NoError(t, errBans)
+
+	_, errBans = cache.PlayerBans(ctx, steamid.Collection{sid1, sid3})
+	require.NoError(t, errBans)
+	require.Equal(t, 2, source.banCalls)
+
+	_, errBans = cache.PlayerBans(ctx, steamid.Collection{sid2})
+	require.NoError(t, errBans)
+	require.Equal(t, 3, source.banCalls)
+}
+
+func TestPlayerDataCacheExpiry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		source = &fakePlayerData{}
+		cache  = extra.NewPlayerDataCache(source, time.Nanosecond)
+		ids    = steamid.Collection{steamid.New("[U:1:1]")}
+	)
+
+	for range 2 {
+		_, errBans := cache.PlayerBans(context.Background(), ids)
+		require.NoError(t, errBans)
+
+		time.Sleep(time.Millisecond)
+	}
+
+	require.Equal(t, 2, source.banCalls)
+}
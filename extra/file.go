@@ -2,19 +2,25 @@ package extra
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"regexp"
+	"runtime"
 	"slices"
+	"strings"
+	"sync"
 
 	"github.com/leighmacdonald/steamid/v4/steamid"
 )
 
 var (
-	ErrIDType = errors.New("invalid sid type")
-	ErrWrite  = errors.New("failed to write to output file")
-	ErrFlush  = errors.New("failed to flush contents")
+	ErrIDType   = errors.New("invalid sid type")
+	ErrWrite    = errors.New("failed to write to output file")
+	ErrFlush    = errors.New("failed to flush contents")
+	ErrScan     = errors.New("failed to scan input")
+	ErrReadFile = errors.New("failed to read file")
 )
 
 // ParseReader attempt to find all types of steam ids in the data stream provided by the
@@ -36,9 +42,14 @@ func ParseReader(input io.Reader, output io.Writer, format string, idType string
 		return fmt.Errorf("%w: %s", ErrIDType, idType)
 	}
 
+	found, errScan := ScanReaderSteamIDs(input)
+	if errScan != nil {
+		return errScan
+	}
+
 	writer := bufio.NewWriter(output)
 
-	for _, id := range FindReaderSteamIDs(input) {
+	for _, id := range found {
 		value := ""
 
 		switch idType {
@@ -65,56 +76,623 @@ func ParseReader(input io.Reader, output io.Writer, format string, idType string
 	return nil
 }
 
+// scannable reports whether a candidate found by FindReaderSteamIDs should be returned. Numeric
+// candidates can decode to types that are almost certainly false positives, so only individual,
+// clan, persistent game server and anonymous game server ids are accepted.
+func scannable(sid steamid.SteamID) bool {
+	if !sid.Valid() {
+		return false
+	}
+
+	switch sid.AccountType {
+	case steamid.AccountTypeIndividual, steamid.AccountTypeClan, steamid.AccountTypeAnonGameServer:
+		return true
+	case steamid.AccountTypeGameServer:
+		return sid.Instance == steamid.InstanceAll
+	default:
+		return false
+	}
+}
+
+// candidate is a substring of a line written in one of the steam id formats.
+type candidate struct {
+	start  int
+	end    int
+	format Format
+}
+
+// formatOrder is the order candidates of each format are returned in by lineCandidates.
+var formatOrder = map[Format]int{FormatSteam: 0, FormatSteam64: 1, FormatSteam3: 2} //nolint:gochecknoglobals
+
+// lineCandidates appends the steam id candidates of the line to found in a single pass over it.
+// Each format yields its leftmost non overlapping matches of:
+//
+//	steam:   STEAM_[01]:[01]:[0-9]{1,10}
+//	steam64: 7656119\d{10}|10358279\d{10}|8556839\d{10}|9[0-4]\d{15}
+//	steam3:  \[[UgGA]:1:\d+(:\d+)?]
+//
+// Matches of different formats may overlap, such as the steam64 within [U:1:76561198132612090].
+// The formats start with different characters, so candidates are returned ordered by their start.
+func lineCandidates(found []candidate, line string) []candidate {
+	// next holds the offset the following match of each format may start at.
+	var nextSteam, nextSteam64, nextSteam3 int
+
+	for idx := 0; idx < len(line); idx++ {
+		var (
+			end    int
+			format Format
+		)
+
+		switch char := line[idx]; {
+		case char == 'S' && idx >= nextSteam:
+			end, format = matchSteam(line, idx), FormatSteam
+			nextSteam = max(nextSteam, end)
+		case char == '[' && idx >= nextSteam3:
+			end, format = matchSteam3(line, idx), FormatSteam3
+			nextSteam3 = max(nextSteam3, end)
+		case char >= '1' && char <= '9' && idx >= nextSteam64:
+			end, format = matchSteam64(line, idx), FormatSteam64
+			nextSteam64 = max(nextSteam64, end)
+		}
+
+		if end > 0 {
+			found = append(found, candidate{start: idx, end: end, format: format})
+		}
+	}
+
+	return found
+}
+
+// sortByFormat orders the candidates by format, then by their start.
+func sortByFormat(found []candidate) {
+	slices.SortStableFunc(found, func(a, b candidate) int {
+		return formatOrder[a.format] - formatOrder[b.format]
+	})
+}
+
+// countDigits returns the number of consecutive digits, up to limit, at the start offset of value.
+func countDigits(value string, start int, limit int) int {
+	count := 0
+	for start+count < len(value) && count < limit && value[start+count] >= '0' && value[start+count] <= '9' {
+		count++
+	}
+
+	return count
+}
+
+// matchSteam returns the end of the steam format id starting at start, or 0 when there is none.
+func matchSteam(line string, start int) int {
+	rest := line[start:]
+	if len(rest) < len("STEAM_0:0:0") || !strings.HasPrefix(rest, "STEAM_") ||
+		(rest[6] != '0' && rest[6] != '1') || rest[7] != ':' || (rest[8] != '0' && rest[8] != '1') || rest[9] != ':' {
+		return 0
+	}
+
+	if digits := countDigits(rest, 10, 10); digits > 0 {
+		return start + 10 + digits
+	}
+
+	return 0
+}
+
+// matchSteam64 returns the end of the steam64 format id starting at start, or 0 when there is none.
+func matchSteam64(line string, start int) int {
+	var (
+		rest   = line[start:]
+		prefix int
+		length = 17
+	)
+
+	switch {
+	case strings.HasPrefix(rest, "7656119"), strings.HasPrefix(rest, "8556839"):
+		prefix = 7
+	case strings.HasPrefix(rest, "10358279"):
+		prefix, length = 8, 18
+	case len(rest) >= 2 && rest[0] == '9' && rest[1] >= '0' && rest[1] <= '4':
+		prefix = 2
+	default:
+		return 0
+	}
+
+	if countDigits(rest, prefix, length-prefix) != length-prefix {
+		return 0
+	}
+
+	return start + length
+}
+
+// matchSteam3 returns the end of the steam3 format id starting at start, or 0 when there is none.
+func matchSteam3(line string, start int) int {
+	rest := line[start:]
+	if len(rest) < len("[U:1:0]") || strings.IndexByte("UgGA", rest[1]) < 0 || rest[2:5] != ":1:" {
+		return 0
+	}
+
+	end := 5 + countDigits(rest, 5, len(rest))
+	if end == 5 || end == len(rest) {
+		return 0
+	}
+
+	if rest[end] == ':' {
+		if instance := countDigits(rest, end+1, len(rest)); instance > 0 && end+1+instance < len(rest) &&
+			rest[end+1+instance] == ']' {
+			return start + end + instance + 2
+		}
+	}
+
+	if rest[end] == ']' {
+		return start + end + 1
+	}
+
+	return 0
+}
+
+// appendMatches appends the scannable ids within the line to found, ordered by format.
+func appendMatches(found []steamid.SteamID, line string) []steamid.SteamID {
+	candidates := lineCandidates(nil, line)
+	sortByFormat(candidates)
+
+	for _, match := range candidates {
+		sid := steamid.New(line[match.start:match.end])
+		if !scannable(sid) {
+			continue
+		}
+
+		found = append(found, sid)
+	}
+
+	return found
+}
+
+// maxIDLength is longer than the longest textual steam id format, [A:1:4294967295:1048575].
+const maxIDLength = 32
+
+// ScanOption configures how input is read by the steam id scanning functions.
+type ScanOption func(*scanOptions)
+
+type scanOptions struct {
+	maxLineSize int
+	dedupe      dedupeMode
+	mmap        bool
+	onInvalid   func(Match)
+}
+
+// dedupeMode controls how ScanReaderSteamIDs removes duplicate ids.
+type dedupeMode int
+
+const (
+	// dedupeExact compares the full 64-bit ids, which is the default.
+	dedupeExact dedupeMode = iota
+	// dedupeNone keeps every occurrence.
+	dedupeNone
+	// dedupeAccount compares the account ids only.
+	dedupeAccount
+)
+
+// WithAllOccurrences disables removing duplicates, returning every occurrence of an id in the
+// order they are found.
+func WithAllOccurrences() ScanOption {
+	return func(o *scanOptions) {
+		o.dedupe = dedupeNone
+	}
+}
+
+// WithUniqueByAccount treats ids with the same account id as duplicates, even when their
+// account type, instance or universe differ, keeping the first one found. This means unrelated ids
+// such as the user [U:1:22202] and the group [g:1:22202] are merged, so it is only useful when the
+// input is known to hold a single kind of id. By default the full 64-bit ids are compared.
+func WithUniqueByAccount() ScanOption {
+	return func(o *scanOptions) {
+		o.dedupe = dedupeAccount
+	}
+}
+
+// WithInvalidHook calls hook for every candidate written like a steam id that is not a valid one,
+// such as [U:1:0], along with where it was found. The SteamID of the match is the
+// invalid value it was parsed to. ParseFiles calls it concurrently from the goroutines reading
+// each file.
+func WithInvalidHook(hook func(Match)) ScanOption {
+	return func(o *scanOptions) {
+		o.onInvalid = hook
+	}
+}
+
+func newScanOptions(opts []ScanOption) scanOptions {
+	options := scanOptions{maxLineSize: bufio.MaxScanTokenSize, dedupe: dedupeExact}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithScanMaxLineSize sets the maximum number of bytes of a single line that are buffered at once,
+// which defaults to bufio.MaxScanTokenSize. Lines longer than this, such as minified JSON, are
+// split into chunks at a position that does not fall within a steam id.
+func WithScanMaxLineSize(size int) ScanOption {
+	return func(o *scanOptions) {
+		o.maxLineSize = max(size, maxIDLength*2)
+	}
+}
+
+// isIDByte reports whether the byte can be part of a textual steam id.
+func isIDByte(char byte) bool {
+	return char >= '0' && char <= '9' || char >= 'A' && char <= 'Z' || char >= 'a' && char <= 'z' ||
+		char == '_' || char == ':' || char == '[' || char == ']'
+}
+
+// splitLines returns a bufio.SplitFunc that behaves like bufio.ScanLines but keeps the line
+// terminator so that byte offsets into the stream can be tracked. Lines longer than maxSize are
+// returned in multiple chunks, each without a terminator, split after the last byte that cannot
+// be part of a steam id.
+func splitLines(maxSize int) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			return i + 1, data[:i+1], nil
+		}
+
+		if atEOF {
+			return len(data), data, nil
+		}
+
+		if len(data) >= maxSize {
+			cut := maxSize
+
+			for i := maxSize - 1; i > 0 && i >= maxSize-maxIDLength; i-- {
+				if !isIDByte(data[i]) {
+					cut = i + 1
+
+					break
+				}
+			}
+
+			return cut, data[:cut], nil
+		}
+
+		return 0, nil, nil
+	}
+}
+
+// newLineScanner returns a scanner over the reader using the splitLines split function.
+func newLineScanner(reader io.Reader, options scanOptions) *bufio.Scanner {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, min(options.maxLineSize, 4096)), options.maxLineSize)
+	scanner.Split(splitLines(options.maxLineSize))
+
+	return scanner
+}
+
 // FindReaderSteamIDs attempts to parse any strings of any known format within the body to a common SID64 format.
+// Errors reading from the reader are ignored, returning what was found up until that point. Use
+// ScanReaderSteamIDs to have them reported.
+//
+// Duplicates are removed by comparing the full 64-bit ids, so the same account id with a different
+// account type, instance or universe is kept. Use WithUniqueByAccount to compare account ids only.
+//
+// The following identifiers are detected:
+//
+//	Steam2:   STEAM_0:0:86173181, STEAM_1:0:86173181
+//	Steam3:   [U:1:172346362], [g:1:4000000], [G:1:3414356], [A:1:1234567:12345]
+//	Steam64:  individual (7656119...), clan (10358279...), game server (8556839...)
+//	          and anonymous game server (90-94...) ids
 func FindReaderSteamIDs(reader io.Reader) []steamid.SteamID {
+	found, _ := ScanReaderSteamIDs(reader)
+
+	return found
+}
+
+// ScanReaderSteamIDs works like FindReaderSteamIDs, but also returns any error encountered while
+// reading. The ids found before the error are returned along with it.
+func ScanReaderSteamIDs(reader io.Reader, opts ...ScanOption) ([]steamid.SteamID, error) {
+	found, _, err := ScanReaderSteamIDStats(reader, opts...)
+
+	return found, err
+}
+
+// ScanStats describes the candidates seen while scanning for steam ids.
+type ScanStats struct {
+	// Formats counts the valid ids found in each format, including duplicates.
+	Formats map[Format]int
+	// Invalid is the number of candidates matching a steam id pattern that are not valid ids.
+	Invalid int
+	// LinesWithoutIDs is the number of lines that are not blank but hold no valid id.
+	LinesWithoutIDs int
+	// Duplicates is the number of valid ids removed because they were already found.
+	Duplicates int
+	// Unique is the number of ids returned, which includes duplicates when using WithAllOccurrences.
+	Unique int
+}
+
+// ScanReaderSteamIDStats works like ScanReaderSteamIDs, additionally returning statistics about
+// the candidates found while scanning.
+func ScanReaderSteamIDStats(reader io.Reader, opts ...ScanOption) ([]steamid.SteamID, ScanStats, error) {
+	return scanReader(reader, newScanOptions(opts))
+}
+
+func scanReader(reader io.Reader, options scanOptions) ([]steamid.SteamID, ScanStats, error) {
 	var (
-		scanner  = bufio.NewScanner(reader)
-		freSID   = regexp.MustCompile(`STEAM_0:[01]:[0-9][0-9]{0,8}`)
-		freSID64 = regexp.MustCompile(`7656119\d{10}`)
-		freSID3  = regexp.MustCompile(`\[U:1:\d+]`)
-		// Store only unique entries
-		found []steamid.SteamID
+		scanner = newLineScanner(reader, options)
+		scan    = newIDScan(options)
 	)
 
 	for scanner.Scan() {
-		line := scanner.Text()
-		if matches := freSID.FindAllStringSubmatch(line, -1); matches != nil {
-			for _, i := range matches {
-				sid := steamid.New(i[0])
-				if !sid.Valid() {
-					continue
-				}
-				found = append(found, sid)
+		scan.scanLine(scanner.Text())
+	}
+
+	uniq, stats := scan.result(options.dedupe)
+
+	if errScan := scanner.Err(); errScan != nil {
+		return uniq, stats, errors.Join(errScan, ErrScan)
+	}
+
+	return uniq, stats, nil
+}
+
+// idScan collects the ids found in the lines of an input along with their statistics.
+type idScan struct {
+	stats      ScanStats
+	found      []steamid.SteamID
+	candidates []candidate
+	onInvalid  func(Match)
+	// line is the 1-indexed number of the line being scanned, column the offset of the current chunk
+	// within an overlong line and offset that of the chunk within the input.
+	line   int
+	column int
+	offset int64
+	// lineText and lineID are set once the current line has non-blank text and a valid id.
+	lineText bool
+	lineID   bool
+}
+
+func newIDScan(options scanOptions) *idScan {
+	return &idScan{stats: ScanStats{Formats: map[Format]int{}}, onInvalid: options.onInvalid, line: 1}
+}
+
+// scanLine scans a line, or a chunk of an overlong one, including its line ending.
+func (s *idScan) scanLine(line string) {
+	s.candidates = lineCandidates(s.candidates[:0], line)
+	sortByFormat(s.candidates)
+
+	for _, match := range s.candidates {
+		sid := steamid.New(line[match.start:match.end])
+		if !scannable(sid) {
+			s.stats.Invalid++
+
+			if s.onInvalid != nil {
+				s.onInvalid(Match{
+					SteamID: sid,
+					Line:    s.line,
+					Column:  s.column + match.start + 1,
+					Offset:  s.offset + int64(match.start),
+					// Mapped files are unmapped once scanned, so the text must not refer to them.
+					Text:   strings.Clone(line[match.start:match.end]),
+					Format: match.format,
+				})
 			}
+
+			continue
 		}
-		if matches := freSID64.FindAllStringSubmatch(line, -1); matches != nil {
-			for _, i := range matches {
-				sid := steamid.New(i[0])
-				if !sid.Valid() {
-					continue
-				}
-				found = append(found, sid)
+
+		s.stats.Formats[match.format]++
+		s.found = append(s.found, sid)
+		s.lineID = true
+	}
+
+	s.offset += int64(len(line))
+	s.lineText = s.lineText || strings.TrimSpace(line) != ""
+
+	if strings.HasSuffix(line, "\n") {
+		s.endLine()
+	} else {
+		s.column += len(line)
+	}
+}
+
+// endLine counts the current line if it held no ids and moves on to the next one.
+func (s *idScan) endLine() {
+	if s.lineText && !s.lineID {
+		s.stats.LinesWithoutIDs++
+	}
+
+	s.line++
+	s.column = 0
+	s.lineText, s.lineID = false, false
+}
+
+// result returns the ids found without the duplicates removed by mode.
+func (s *idScan) result(mode dedupeMode) ([]steamid.SteamID, ScanStats) {
+	if s.column > 0 {
+		// The last line has no line ending.
+		s.endLine()
+	}
+
+	uniq := dedupe(s.found, mode)
+
+	s.stats.Unique = len(uniq)
+	s.stats.Duplicates = len(s.found) - len(uniq)
+
+	return uniq, s.stats
+}
+
+// dedupe removes the duplicate ids according to the mode, keeping the first occurrence of each.
+func dedupe(found []steamid.SteamID, mode dedupeMode) []steamid.SteamID {
+	switch mode {
+	case dedupeNone:
+		return found
+	case dedupeAccount:
+		var (
+			seen = make(map[steamid.SID32]struct{}, len(found))
+			uniq []steamid.SteamID
+		)
+
+		for _, foundID := range found {
+			if _, dupe := seen[foundID.AccountID]; !dupe {
+				seen[foundID.AccountID] = struct{}{}
+				uniq = append(uniq, foundID)
 			}
 		}
-		if matches := freSID3.FindAllStringSubmatch(line, -1); matches != nil {
-			for _, i := range matches {
-				sid := steamid.New(i[0])
-				if !sid.Valid() {
-					continue
+
+		return uniq
+	default:
+		return steamid.Collection(found).Unique()
+	}
+}
+
+// ParseFiles finds the steam ids in many files concurrently, such as a directory of rotated log
+// files, using up to workers goroutines, or one per cpu when workers is not positive. The ids are
+// merged without duplicates in the order of the paths they were found in, using the same
+// ScanOption values as ScanReaderSteamIDs. Use WithMmap to map large files into memory.
+//
+// Files that fail to be read do not stop the others from being parsed. The ids found are
+// returned along with the errors of every failed file, each wrapping ErrReadFile and naming its path.
+func ParseFiles(ctx context.Context, paths []string, workers int, opts ...ScanOption) (steamid.Collection, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var (
+		options = newScanOptions(opts)
+		results = make([][]steamid.SteamID, len(paths))
+		errs    = make([]error, len(paths))
+		queue   = make(chan int)
+		wg      sync.WaitGroup
+	)
+
+	for range min(workers, len(paths)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range queue {
+				found, _, errParse := scanFile(ctx, paths[idx], options)
+				if errParse != nil {
+					errParse = fmt.Errorf("%s: %w", paths[idx], errParse)
 				}
-				found = append(found, sid)
+
+				results[idx], errs[idx] = found, errParse
 			}
+		}()
+	}
+
+	for idx := range paths {
+		if ctx.Err() != nil {
+			break
 		}
+
+		queue <- idx
+	}
+
+	close(queue)
+	wg.Wait()
+
+	var merged []steamid.SteamID
+	for _, found := range results {
+		merged = append(merged, found...)
 	}
 
-	var uniq []steamid.SteamID
-	for _, foundID := range found {
-		if !slices.ContainsFunc(uniq, func(sid steamid.SteamID) bool {
-			return foundID.Int64() == sid.Int64()
-		}) {
-			uniq = append(uniq, foundID)
+	return dedupe(merged, options.dedupe), errors.Join(append(errs, ctx.Err())...)
+}
+
+// Format identifies the textual representation a steam id was found in.
+type Format string
+
+const (
+	FormatSteam   Format = "steam"
+	FormatSteam3  Format = "steam3"
+	FormatSteam64 Format = "steam64"
+)
+
+// FindSteamIDs returns every steam id within the text, including duplicates. Unlike
+// ScanReaderSteamIDs it does not buffer the input, which makes it cheap to call once per line
+// of a stream.
+func FindSteamIDs(text string) []steamid.SteamID {
+	return appendMatches(nil, text)
+}
+
+// Match describes a single steam id occurrence found by FindReaderSteamIDMatches, or a candidate
+// that is not a valid id passed to the WithInvalidHook hook.
+type Match struct {
+	SteamID steamid.SteamID
+	// Line is the 1-indexed line number the match was found on.
+	Line int
+	// Column is the 1-indexed byte offset of the match within its line.
+	Column int
+	// Offset is the byte offset of the match from the start of the reader.
+	Offset int64
+	// Text is the original matched text.
+	Text string
+	// Format is the format the id was written in.
+	Format Format
+}
+
+// FindReaderSteamIDMatches works like FindReaderSteamIDs, but instead of returning the unique
+// ids, it returns every occurrence along with where it was found and the format it was written in.
+// Matches are returned in the order they appear in the input.
+func FindReaderSteamIDMatches(reader io.Reader, opts ...ScanOption) ([]Match, error) {
+	var (
+		options = newScanOptions(opts)
+		scanner = newLineScanner(reader, options)
+		offset  int64
+		lineNum = 1
+		// column is the offset of the current chunk within an overlong line.
+		column     int
+		matches    []Match
+		candidates []candidate
+	)
+
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimRight(raw, "\r\n")
+
+		candidates = lineCandidates(candidates[:0], line)
+
+		for _, match := range candidates {
+			text := line[match.start:match.end]
+
+			sid := steamid.New(text)
+			if !scannable(sid) {
+				if options.onInvalid != nil {
+					options.onInvalid(Match{
+						SteamID: sid,
+						Line:    lineNum,
+						Column:  column + match.start + 1,
+						Offset:  offset + int64(match.start),
+						Text:    text,
+						Format:  match.format,
+					})
+				}
+
+				continue
+			}
+
+			matches = append(matches, Match{
+				SteamID: sid,
+				Line:    lineNum,
+				Column:  column + match.start + 1,
+				Offset:  offset + int64(match.start),
+				Text:    text,
+				Format:  match.format,
+			})
+		}
+
+		offset += int64(len(raw))
+
+		if strings.HasSuffix(raw, "\n") {
+			lineNum++
+			column = 0
+		} else {
+			column += len(raw)
 		}
 	}
 
-	return uniq
+	if errScan := scanner.Err(); errScan != nil {
+		return matches, errors.Join(errScan, ErrScan)
+	}
+
+	return matches, nil
 }
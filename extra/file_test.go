@@ -2,10 +2,17 @@ package extra_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
 
 	"github.com/stretchr/testify/require"
 )
@@ -56,3 +63,266 @@ STEAM_0:0:86173181
 		require.Equalf(t, expected, buf64.String(), "Failed to generate: %s", format)
 	}
 }
+
+func TestFindReaderSteamIDsTypes(t *testing.T) {
+	t.Parallel()
+
+	testBody := `clan: 103582791441572968 [g:1:4000000]
+server: [G:1:3414356] (85568392923453780)
+anon: [A:1:1234567:12345] 90125013919913607
+universe one: STEAM_1:0:86173181
+not an id: 90000000000000001
+`
+
+	ids := extra.FindReaderSteamIDs(strings.NewReader(testBody))
+	require.Equal(t, []string{
+		"103582791441572968", "103582791433521408", "85568392923453780",
+		"90125013919913607", "76561198132612090",
+	}, steamid.Collection(ids).ToStringSlice())
+}
+
+func TestFindSteamIDs(t *testing.T) {
+	t.Parallel()
+
+	ids := extra.FindSteamIDs(`L 01/01/2024 - 00:00:00: "foo<2><[U:1:22202]><Red>" killed "bar<3><STEAM_0:0:11101><Blue>"`)
+	require.Equal(t, []string{"76561197960287930", "76561197960287930"}, steamid.Collection(ids).ToStringSlice())
+	require.Empty(t, extra.FindSteamIDs("no ids here"))
+
+	// Each format is matched independently, so ids may overlap and are ordered by format.
+	overlapping := extra.FindSteamIDs("[U:1:1:76561198132612090] STEAM_0:0:11101 [U:1:22202:]")
+	require.Equal(t, []string{"76561197960287930", "76561198132612090"}, steamid.Collection(overlapping).ToStringSlice())
+}
+
+func TestFindReaderSteamIDMatches(t *testing.T) {
+	t.Parallel()
+
+	testBody := "first line\r\n[U:1:172346362]STEAM_0:0:86173182 [U:1:172346362]\n\n  76561198132612090"
+
+	matches, err := extra.FindReaderSteamIDMatches(strings.NewReader(testBody))
+	require.NoError(t, err)
+	require.Len(t, matches, 4)
+
+	expected := []extra.Match{
+		{Line: 2, Column: 1, Offset: 12, Text: "[U:1:172346362]", Format: extra.FormatSteam3},
+		{Line: 2, Column: 16, Offset: 27, Text: "STEAM_0:0:86173182", Format: extra.FormatSteam},
+		{Line: 2, Column: 35, Offset: 46, Text: "[U:1:172346362]", Format: extra.FormatSteam3},
+		{Line: 4, Column: 3, Offset: 65, Text: "76561198132612090", Format: extra.FormatSteam64},
+	}
+
+	for i, match := range matches {
+		require.Equal(t, expected[i].Line, match.Line)
+		require.Equal(t, expected[i].Column, match.Column)
+		require.Equal(t, expected[i].Offset, match.Offset)
+		require.Equal(t, expected[i].Text, match.Text)
+		require.Equal(t, expected[i].Format, match.Format)
+		require.Equal(t, expected[i].Text, testBody[match.Offset:match.Offset+int64(len(match.Text))])
+	}
+
+	require.Equal(t, steamid.New("[U:1:172346362]"), matches[3].SteamID)
+}
+
+func TestScanReaderSteamIDsLongLines(t *testing.T) {
+	t.Parallel()
+
+	// A single line well over the default 64KB buffer, with ids placed across chunk boundaries.
+	var builder strings.Builder
+	for i := 0; i < 5000; i++ {
+		builder.WriteString(`{"name":"player","steamid":"[U:1:`)
+		builder.WriteString(strings.Repeat("1", 1+i%9))
+		builder.WriteString(`]"},`)
+	}
+
+	builder.WriteString(`{"id":76561198132612090}`)
+	builder.WriteString("\n[U:1:148883280]\n")
+
+	for _, opts := range [][]extra.ScanOption{nil, {extra.WithScanMaxLineSize(100)}} {
+		found, err := extra.ScanReaderSteamIDs(strings.NewReader(builder.String()), opts...)
+		require.NoError(t, err)
+		require.Len(t, found, 11)
+		require.Equal(t, steamid.New("[U:1:148883280]"), found[len(found)-1])
+
+		matches, errMatches := extra.FindReaderSteamIDMatches(strings.NewReader(builder.String()), opts...)
+		require.NoError(t, errMatches)
+		require.Len(t, matches, 5002)
+		require.Equal(t, 2, matches[len(matches)-1].Line)
+		require.Equal(t, 1, matches[len(matches)-1].Column)
+
+		last := matches[len(matches)-2]
+		require.Equal(t, 1, last.Line)
+		require.Equal(t, int64(last.Column-1), last.Offset)
+		require.Equal(t, "76561198132612090", last.Text)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read(_ []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestScanReaderSteamIDsError(t *testing.T) {
+	t.Parallel()
+
+	found, err := extra.ScanReaderSteamIDs(io.MultiReader(strings.NewReader("[U:1:148883280]\n"), errReader{}))
+	require.ErrorIs(t, err, extra.ErrScan)
+	require.Len(t, found, 1)
+
+	require.ErrorIs(t, extra.ParseReader(errReader{}, io.Discard, "%s\n", "steam64"), extra.ErrScan)
+}
+
+func TestScanReaderSteamIDStats(t *testing.T) {
+	t.Parallel()
+
+	input := "STEAM_0:0:11101 [U:1:22202] 76561197960287930\n[U:1:0] STEAM_0:0:0 [U:1:5]\n"
+
+	found, stats, err := extra.ScanReaderSteamIDStats(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	require.Equal(t, extra.ScanStats{
+		Formats:    map[extra.Format]int{extra.FormatSteam: 1, extra.FormatSteam3: 2, extra.FormatSteam64: 1},
+		Invalid:    2,
+		Duplicates: 2,
+		Unique:     2,
+	}, stats)
+}
+
+func TestScanInvalidHook(t *testing.T) {
+	t.Parallel()
+
+	var (
+		input    = "STEAM_0:0:11101 [U:1:0]\nno ids here\n\n  \r\nx STEAM_0:0:0\nlast [U:1:5]"
+		path     = filepath.Join(t.TempDir(), "ids.log")
+		expected = []extra.Match{
+			{SteamID: steamid.New("[U:1:0]"), Line: 1, Column: 17, Offset: 16, Text: "[U:1:0]", Format: extra.FormatSteam3},
+			{SteamID: steamid.New("STEAM_0:0:0"), Line: 5, Column: 3, Offset: 43, Text: "STEAM_0:0:0", Format: extra.FormatSteam},
+		}
+	)
+
+	require.NoError(t, os.WriteFile(path, []byte(input), 0o600))
+
+	var invalid []extra.Match
+
+	hook := extra.WithInvalidHook(func(match extra.Match) {
+		invalid = append(invalid, match)
+	})
+
+	_, stats, errReader := extra.ScanReaderSteamIDStats(strings.NewReader(input), hook)
+	require.NoError(t, errReader)
+	require.Equal(t, expected, invalid)
+	require.Equal(t, 2, stats.Invalid)
+	require.Equal(t, 2, stats.LinesWithoutIDs)
+
+	invalid = nil
+
+	_, mappedStats, errMapped := extra.ScanFileSteamIDStats(path, hook, extra.WithMmap())
+	require.NoError(t, errMapped)
+	require.Equal(t, expected, invalid)
+	require.Equal(t, stats, mappedStats)
+
+	invalid = nil
+
+	_, errMatches := extra.FindReaderSteamIDMatches(strings.NewReader(input), hook)
+	require.NoError(t, errMatches)
+	require.Equal(t, expected, invalid)
+
+	// Overlong lines split into chunks are counted once, as is a last line without a line ending.
+	_, chunkedStats, errChunked := extra.ScanReaderSteamIDStats(strings.NewReader(strings.Repeat("x", 200)+"\n"+
+		strings.Repeat("y", 100)+" [U:1:5]\nz"), extra.WithScanMaxLineSize(64))
+	require.NoError(t, errChunked)
+	require.Equal(t, 2, chunkedStats.LinesWithoutIDs)
+}
+
+func TestScanReaderSteamIDsDedupe(t *testing.T) {
+	t.Parallel()
+
+	input := "[U:1:22202] 76561197960287930\n[U:1:22202] [g:1:22202]\n"
+
+	all, errAll := extra.ScanReaderSteamIDs(strings.NewReader(input), extra.WithAllOccurrences())
+	require.NoError(t, errAll)
+	require.Len(t, all, 4)
+
+	exact, errExact := extra.ScanReaderSteamIDs(strings.NewReader(input))
+	require.NoError(t, errExact)
+	require.Equal(t, []steamid.SteamID{steamid.New("[U:1:22202]"), steamid.New("[g:1:22202]")}, exact)
+
+	byAccount, errAccount := extra.ScanReaderSteamIDs(strings.NewReader(input), extra.WithUniqueByAccount())
+	require.NoError(t, errAccount)
+	require.Equal(t, []steamid.SteamID{steamid.New("[U:1:22202]")}, byAccount)
+}
+
+func TestParseFiles(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir   = t.TempDir()
+		paths []string
+	)
+
+	for idx, body := range []string{
+		"[U:1:172346362] STEAM_0:0:11101\n",
+		"76561197960287930\n[U:1:172346362]\n",
+		"STEAM_0:1:507127\n",
+	} {
+		path := filepath.Join(dir, fmt.Sprintf("%d.log", idx))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+
+		paths = append(paths, path)
+	}
+
+	for _, workers := range []int{0, 1, 8} {
+		found, errParse := extra.ParseFiles(context.Background(), paths, workers)
+		require.NoError(t, errParse)
+		require.Equal(t, []string{"76561197960287930", "76561198132612090", "76561197961279983"}, found.ToStringSlice())
+	}
+
+	all, errAll := extra.ParseFiles(context.Background(), paths, 2, extra.WithAllOccurrences())
+	require.NoError(t, errAll)
+	require.Len(t, all, 5)
+
+	missing := filepath.Join(dir, "missing.log")
+	partial, errMissing := extra.ParseFiles(context.Background(), append(paths, missing), 2)
+	require.ErrorIs(t, errMissing, extra.ErrReadFile)
+	require.ErrorContains(t, errMissing, missing)
+	require.Len(t, partial, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, errCancelled := extra.ParseFiles(ctx, paths, 2)
+	require.ErrorIs(t, errCancelled, context.Canceled)
+}
+
+func TestScanFileSteamIDStats(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir  = t.TempDir()
+		body = "[U:1:172346362] 76561197960287930\r\n" + strings.Repeat("x", 200_000) + " STEAM_0:1:507127 [U:1:172346362]"
+	)
+
+	for name, content := range map[string]string{"ids.log": body, "empty.log": ""} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		expected, expectedStats, errReader := extra.ScanReaderSteamIDStats(strings.NewReader(content))
+		require.NoError(t, errReader)
+
+		for _, opts := range [][]extra.ScanOption{nil, {extra.WithMmap()}, {extra.WithMmap(), extra.WithAllOccurrences()}} {
+			found, stats, errScan := extra.ScanFileSteamIDStats(path, opts...)
+			require.NoError(t, errScan)
+
+			if len(opts) == 2 {
+				require.Len(t, found, stats.Unique)
+				require.Equal(t, 0, stats.Duplicates)
+
+				continue
+			}
+
+			require.Equal(t, expected, found)
+			require.Equal(t, expectedStats, stats)
+		}
+	}
+
+	_, _, errMissing := extra.ScanFileSteamIDStats(filepath.Join(dir, "missing.log"), extra.WithMmap())
+	require.ErrorIs(t, errMissing, extra.ErrReadFile)
+}
@@ -0,0 +1,86 @@
+package extra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzStatusSeed is a real world status output, it is also truncated at every line to seed the
+// fuzzers with partial inputs.
+const fuzzStatusSeed = `hostname: Uncletopia | US West 2
+version : 5970214/24 5970214 secure
+udp/ip  : 23.239.22.163:27015  (public ip: 23.239.22.163)
+steamid : [G:1:3414356] (85568392923453780)
+account : not logged in  (No account specified)
+map     : pl_goldrush at: 0 x, 0 y, 0 z
+tags    : Uncletopia,nocrits,nodmgspread,payload
+players : 11 humans, 0 bots (32 max)
+edicts  : 1717 used of 2048 max
+# userid name                uniqueid            connected ping loss state  adr
+#   4247 "Dulahan"           [U:1:148883280]     55:09       74    0 active 1.2.64.84:27005
+#   4235 "Nox"               [U:1:186134686]      1:21:18   123    0 active 1.2.212.98:27005
+#      2 "WolfXine"          [U:1:166779318]     15:22       85    0 active
+#      3 "BOT"               BOT                                    active
+#   4246 "Frank"             [U:1:166415783]      1:01:59   133    0 spawning 169.254.1.1:12345
+`
+
+func fuzzStatusSeeds() []string {
+	lines := strings.SplitAfter(fuzzStatusSeed, "\n")
+	seeds := []string{"", "#", "# userid", `#   1 "`, "hostname:", "players : x humans"}
+
+	for idx := range lines {
+		seeds = append(seeds, strings.Join(lines[:idx+1], ""), lines[idx])
+	}
+
+	return seeds
+}
+
+func FuzzParseStatus(f *testing.F) {
+	for _, seed := range fuzzStatusSeeds() {
+		f.Add(seed, true)
+		f.Add(seed, false)
+	}
+
+	f.Fuzz(func(t *testing.T, status string, full bool) {
+		parsed, err := extra.ParseStatus(status, full, extra.WithPartial())
+		if err != nil {
+			return
+		}
+
+		for _, player := range parsed.Players {
+			require.True(t, player.SID.Valid() || player.SID.AccountID == 0)
+		}
+	})
+}
+
+func FuzzFindReaderSteamIDs(f *testing.F) {
+	for _, seed := range fuzzStatusSeeds() {
+		f.Add(seed)
+	}
+
+	for _, seed := range []string{
+		"L 01/01/2024 - 00:00:00: \"Dulahan<4247><[U:1:148883280]><Red>\" say \"gg\"",
+		"STEAM_0:0:86173181 STEAM_0:0:86173182[U:1:172346342]76561198132612090",
+		"https://steamcommunity.com/profiles/76561198084134025/", "7656119", "[U:1:", "STEAM_0:1:",
+		"banid 0 STEAM_0:1:507127", "\x00\xff[U:1:1]\n\r\n",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		for _, sid := range extra.FindReaderSteamIDs(strings.NewReader(text)) {
+			require.True(t, sid.Valid())
+		}
+
+		matches, errMatches := extra.FindReaderSteamIDMatches(strings.NewReader(text))
+		require.NoError(t, errMatches)
+
+		for _, match := range matches {
+			require.True(t, match.SteamID.Valid())
+			require.Equal(t, match.Text, text[match.Offset:match.Offset+int64(len(match.Text))])
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package extra
+
+import (
+	"html"
+	"strings"
+)
+
+// StripHTML removes the tags from a html document and decodes its entities, so ids embedded in
+// saved web pages can be scanned. Each tag is replaced by its attribute values separated by spaces,
+// keeping ids found in links such as href="https://steamcommunity.com/profiles/76561197960287930",
+// and comment markers are removed while their content is kept.
+func StripHTML(input string) string {
+	var (
+		out  strings.Builder
+		text strings.Builder
+	)
+
+	flush := func() {
+		out.WriteString(html.UnescapeString(text.String()))
+		text.Reset()
+	}
+
+	for idx := 0; idx < len(input); {
+		switch {
+		case strings.HasPrefix(input[idx:], "<!--"):
+			flush()
+
+			content, _, _ := strings.Cut(input[idx+4:], "-->")
+			out.WriteString(" " + html.UnescapeString(content) + " ")
+
+			idx += 4 + len(content) + len("-->")
+		case input[idx] == '<' && idx+1 < len(input) && isTagStart(input[idx+1]):
+			flush()
+
+			end := tagEnd(input, idx)
+			out.WriteString(" ")
+
+			for _, value := range attributeValues(input[idx+1 : end]) {
+				out.WriteString(html.UnescapeString(value) + " ")
+			}
+
+			idx = end + 1
+		default:
+			text.WriteByte(input[idx])
+			idx++
+		}
+	}
+
+	flush()
+
+	return out.String()
+}
+
+func isTagStart(char byte) bool {
+	return char == '/' || char == '!' || char == '?' || (char|0x20 >= 'a' && char|0x20 <= 'z')
+}
+
+// tagEnd returns the index of the > closing the tag starting at start, ignoring any inside quoted
+// attribute values, or the end of the input when the tag is not closed.
+func tagEnd(input string, start int) int {
+	var quote byte
+
+	for idx := start + 1; idx < len(input); idx++ {
+		switch char := input[idx]; {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == '>':
+			return idx
+		}
+	}
+
+	return len(input)
+}
+
+// attributeValues returns the values of the attributes of the tag, given without its angle brackets.
+func attributeValues(tag string) []string {
+	var values []string
+
+	for {
+		eq := strings.IndexByte(tag, '=')
+		if eq < 0 {
+			return values
+		}
+
+		tag = strings.TrimLeft(tag[eq+1:], " \t\r\n")
+		if tag == "" {
+			return values
+		}
+
+		if quote := tag[0]; quote == '"' || quote == '\'' {
+			value, rest, _ := strings.Cut(tag[1:], string(quote))
+			values = append(values, value)
+			tag = rest
+
+			continue
+		}
+
+		end := strings.IndexAny(tag, " \t\r\n/")
+		if end < 0 {
+			end = len(tag)
+		}
+
+		values = append(values, tag[:end])
+		tag = tag[end:]
+	}
+}
@@ -0,0 +1,40 @@
+package extra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripHTML(t *testing.T) {
+	t.Parallel()
+
+	page := `<html><head><title>Bans &amp; Appeals</title></head>
+<body>
+<table><tr><td>76561198132612090</td></tr>
+<tr><td>STEAM_0:0:86173182</td></tr></table>
+<p>Reported by <a href="https://steamcommunity.com/profiles/76561198084134025" title='x > y'>player</a>
+as &#91;U:1:166779318&#93;.</p>
+<!-- [U:1:361821288] --><img src=/avatar.png alt=[U:1:79002518]>
+</body></html>`
+
+	stripped := extra.StripHTML(page)
+	require.NotContains(t, stripped, "<")
+	require.Contains(t, stripped, "Bans & Appeals")
+
+	ids, errScan := extra.ScanReaderSteamIDs(strings.NewReader(stripped))
+	require.NoError(t, errScan)
+	require.Equal(t, []steamid.SteamID{
+		steamid.New(76561198132612090),
+		steamid.New("STEAM_0:0:86173182"),
+		steamid.New(76561198084134025),
+		steamid.New("[U:1:166779318]"),
+		steamid.New("[U:1:361821288]"),
+		steamid.New("[U:1:79002518]"),
+	}, ids)
+
+	require.Equal(t, "a < b", strings.TrimSpace(extra.StripHTML("a &lt; b")))
+}
@@ -0,0 +1,69 @@
+package extra
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// ErrSteamNotFound is returned when no steam client installation could be found.
+var ErrSteamNotFound = errors.New("steam installation not found")
+
+// SteamDir returns the installation directory of the local steam client. The locations checked
+// depend on the OS:
+//
+//   - Windows: the SteamPath and InstallPath registry values, then %ProgramFiles(x86)%\Steam.
+//   - macOS: ~/Library/Application Support/Steam.
+//   - Others: ~/.steam/steam, ~/.steam/root, $XDG_DATA_HOME/Steam, ~/.local/share/Steam and the
+//     flatpak data directory.
+//
+// The first of these containing a config directory is returned, otherwise ErrSteamNotFound.
+func SteamDir() (string, error) {
+	for _, dir := range steamDirCandidates() {
+		if dir == "" {
+			continue
+		}
+
+		if info, errStat := os.Stat(filepath.Join(dir, "config")); errStat == nil && info.IsDir() {
+			return filepath.Clean(dir), nil
+		}
+	}
+
+	return "", ErrSteamNotFound
+}
+
+// LocalAccounts returns the accounts that have signed in to the local steam client, read from the
+// config/loginusers.vdf file of the installation found by SteamDir. No accounts are returned when
+// the client is installed but has never been signed in to.
+func LocalAccounts() ([]LocalAccount, error) {
+	dir, errDir := SteamDir()
+	if errDir != nil {
+		return nil, errDir
+	}
+
+	file, errOpen := os.Open(filepath.Join(dir, "config", "loginusers.vdf"))
+	if errOpen != nil {
+		if errors.Is(errOpen, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, errors.Join(errOpen, ErrReadVDF)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return ReadLocalAccounts(file)
+}
+
+// homeDir returns the path joined to the home directory of the user, or an empty string when it
+// is unknown.
+func homeDir(elem ...string) string {
+	home, errHome := os.UserHomeDir()
+	if errHome != nil || home == "" {
+		return ""
+	}
+
+	return filepath.Join(append([]string{home}, elem...)...)
+}
@@ -0,0 +1,8 @@
+//go:build darwin
+
+package extra
+
+// steamDirCandidates returns the default install location of the steam client.
+func steamDirCandidates() []string {
+	return []string{homeDir("Library", "Application Support", "Steam")}
+}
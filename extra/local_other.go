@@ -0,0 +1,22 @@
+//go:build !windows && !darwin
+
+package extra
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// steamDirCandidates returns the symlinks the steam client maintains to its installation, followed
+// by the native and flatpak install locations.
+func steamDirCandidates() []string {
+	candidates := []string{homeDir(".steam", "steam"), homeDir(".steam", "root")}
+
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		candidates = append(candidates, filepath.Join(dataHome, "Steam"))
+	}
+
+	return append(candidates,
+		homeDir(".local", "share", "Steam"),
+		homeDir(".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam"))
+}
@@ -0,0 +1,41 @@
+//go:build linux
+
+package extra_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalAccounts(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+
+	_, errMissing := extra.LocalAccounts()
+	require.ErrorIs(t, errMissing, extra.ErrSteamNotFound)
+
+	install := filepath.Join(home, ".local", "share", "Steam")
+	require.NoError(t, os.MkdirAll(filepath.Join(install, "config"), 0o755))
+
+	dir, errDir := extra.SteamDir()
+	require.NoError(t, errDir)
+	require.Equal(t, install, dir)
+
+	none, errNone := extra.LocalAccounts()
+	require.NoError(t, errNone)
+	require.Empty(t, none)
+
+	require.NoError(t, os.WriteFile(filepath.Join(install, "config", "loginusers.vdf"), []byte(testLoginUsers), 0o600))
+
+	accounts, errAccounts := extra.LocalAccounts()
+	require.NoError(t, errAccounts)
+	require.Len(t, accounts, 2)
+	require.Equal(t, steamid.New(76561198132612090), accounts[0].SteamID)
+	require.True(t, accounts[0].MostRecent)
+}
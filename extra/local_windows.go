@@ -0,0 +1,46 @@
+//go:build windows
+
+package extra
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// steamDirCandidates returns the registry locations of the steam client, followed by its default
+// install location.
+func steamDirCandidates() []string {
+	candidates := []string{
+		registryString(registry.CURRENT_USER, `Software\Valve\Steam`, "SteamPath"),
+		registryString(registry.LOCAL_MACHINE, `SOFTWARE\WOW6432Node\Valve\Steam`, "InstallPath"),
+		registryString(registry.LOCAL_MACHINE, `SOFTWARE\Valve\Steam`, "InstallPath"),
+	}
+
+	if programFiles := os.Getenv("ProgramFiles(x86)"); programFiles != "" {
+		candidates = append(candidates, filepath.Join(programFiles, "Steam"))
+	}
+
+	return candidates
+}
+
+// registryString returns a string value of the registry key, or an empty string if it does not
+// exist.
+func registryString(root registry.Key, path string, name string) string {
+	key, errOpen := registry.OpenKey(root, path, registry.QUERY_VALUE)
+	if errOpen != nil {
+		return ""
+	}
+
+	defer func() {
+		_ = key.Close()
+	}()
+
+	value, _, errValue := key.GetStringValue(name)
+	if errValue != nil {
+		return ""
+	}
+
+	return filepath.FromSlash(value)
+}
@@ -0,0 +1,114 @@
+package extra
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"unsafe"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// mmapCheckInterval is how many bytes of a mapped file are scanned between checks of the context.
+const mmapCheckInterval = 1 << 20
+
+var errMmapUnsupported = errors.New("mmap is not supported")
+
+// WithMmap makes the functions scanning files, ScanFileSteamIDStats and ParseFiles, map each file
+// into memory instead of reading it. Lines are found in place without being copied or split into
+// chunks, which is faster on multi-gigabyte files. The files are read normally on platforms
+// without mmap support, or when they cannot be mapped, such as pipes.
+//
+// A mapped file must not be truncated while it is scanned.
+func WithMmap() ScanOption {
+	return func(o *scanOptions) {
+		o.mmap = true
+	}
+}
+
+// ScanFileSteamIDStats works like ScanReaderSteamIDStats, reading the file at path.
+func ScanFileSteamIDStats(path string, opts ...ScanOption) ([]steamid.SteamID, ScanStats, error) {
+	return scanFile(context.Background(), path, newScanOptions(opts))
+}
+
+// contextReader stops reading once its context is done.
+type contextReader struct {
+	ctx    context.Context //nolint:containedctx
+	reader io.Reader
+}
+
+func (r contextReader) Read(data []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	return r.reader.Read(data) //nolint:wrapcheck
+}
+
+// scanFile finds the ids in the file at path, mapping it into memory when enabled.
+func scanFile(ctx context.Context, path string, options scanOptions) ([]steamid.SteamID, ScanStats, error) {
+	file, errOpen := os.Open(path)
+	if errOpen != nil {
+		return nil, ScanStats{}, errors.Join(errOpen, ErrReadFile)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if options.mmap {
+		data, unmap, errMap := mmapFile(file)
+		if errMap == nil {
+			defer func() {
+				_ = unmap()
+			}()
+
+			return scanMapped(ctx, data, options)
+		}
+	}
+
+	found, stats, errScan := scanReader(contextReader{ctx: ctx, reader: file}, options)
+	if errScan != nil {
+		return found, stats, errors.Join(errScan, ErrReadFile)
+	}
+
+	return found, stats, nil
+}
+
+// scanMapped finds the ids in the lines of a mapped file.
+func scanMapped(ctx context.Context, data []byte, options scanOptions) ([]steamid.SteamID, ScanStats, error) {
+	var (
+		scan = newIDScan(options)
+		// The ids found are parsed into values, so no string refers to the mapping once it is unmapped.
+		text    = unsafe.String(unsafe.SliceData(data), len(data))
+		checked int
+	)
+
+	for offset := 0; offset < len(text); {
+		end := strings.IndexByte(text[offset:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += offset + 1
+		}
+
+		scan.scanLine(text[offset:end])
+		offset = end
+
+		if offset-checked >= mmapCheckInterval {
+			checked = offset
+
+			if errCtx := ctx.Err(); errCtx != nil {
+				uniq, stats := scan.result(options.dedupe)
+
+				return uniq, stats, errors.Join(errCtx, ErrScan, ErrReadFile)
+			}
+		}
+	}
+
+	uniq, stats := scan.result(options.dedupe)
+
+	return uniq, stats, nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package extra
+
+import "os"
+
+// mmapFile is not supported on this platform, so files are always read instead.
+func mmapFile(_ *os.File) ([]byte, func() error, error) {
+	return nil, nil, errMmapUnsupported
+}
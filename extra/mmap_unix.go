@@ -0,0 +1,32 @@
+//go:build unix
+
+package extra
+
+import (
+	"math"
+	"os"
+	"syscall"
+)
+
+// mmapFile maps the regular file into memory, returning the mapping and the function unmapping it.
+func mmapFile(file *os.File) ([]byte, func() error, error) {
+	info, errStat := file.Stat()
+	if errStat != nil {
+		return nil, nil, errStat //nolint:wrapcheck
+	}
+
+	if !info.Mode().IsRegular() || info.Size() > math.MaxInt {
+		return nil, nil, errMmapUnsupported
+	}
+
+	if info.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+
+	data, errMmap := syscall.Mmap(int(file.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if errMmap != nil {
+		return nil, nil, errMmap //nolint:wrapcheck
+	}
+
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,154 @@
+package extra
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// PlayerListSchema is the schema url written to playerlist.json files by WritePlayerList when the
+// list does not define one.
+const PlayerListSchema = "https://raw.githubusercontent.com/PazerOP/tf2_bot_detector/master/schemas/v3/playerlist.schema.json"
+
+var (
+	ErrDecodePlayerList = errors.New("failed to decode player list")
+	ErrEncodePlayerList = errors.New("failed to encode player list")
+)
+
+// PlayerAttribute is a tag applied to a player list entry.
+type PlayerAttribute string
+
+const (
+	AttributeCheater    PlayerAttribute = "cheater"
+	AttributeSuspicious PlayerAttribute = "suspicious"
+	AttributeExploiter  PlayerAttribute = "exploiter"
+	AttributeRacist     PlayerAttribute = "racist"
+)
+
+// PlayerListFileInfo describes the origin of a player list.
+type PlayerListFileInfo struct {
+	Authors     []string `json:"authors,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	UpdateURL   string   `json:"update_url,omitempty"`
+}
+
+// PlayerListLastSeen records the name and unix time a player was last seen with.
+type PlayerListLastSeen struct {
+	PlayerName string `json:"player_name,omitempty"`
+	Time       int64  `json:"time,omitempty"`
+}
+
+// PlayerListEntry is a single player in a player list.
+type PlayerListEntry struct {
+	SteamID    steamid.SteamID     `json:"steamid"`
+	Attributes []PlayerAttribute   `json:"attributes"`
+	LastSeen   *PlayerListLastSeen `json:"last_seen,omitempty"`
+	Proof      []string            `json:"proof,omitempty"`
+}
+
+type playerListEntryJSON struct {
+	SteamID    json.RawMessage     `json:"steamid"`
+	Attributes []PlayerAttribute   `json:"attributes"`
+	LastSeen   *PlayerListLastSeen `json:"last_seen,omitempty"`
+	Proof      []string            `json:"proof,omitempty"`
+}
+
+// HasAttribute returns true if the entry is tagged with the attribute.
+func (e PlayerListEntry) HasAttribute(attribute PlayerAttribute) bool {
+	return slices.Contains(e.Attributes, attribute)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The steamid may be either a steam3 string or
+// a SID64 number, both of which are used by published lists.
+func (e *PlayerListEntry) UnmarshalJSON(data []byte) error {
+	var entry playerListEntryJSON
+	if errUnmarshal := json.Unmarshal(data, &entry); errUnmarshal != nil {
+		return errors.Join(errUnmarshal, ErrDecodePlayerList)
+	}
+
+	var sid steamid.SteamID
+
+	if value := bytes.Trim(entry.SteamID, `"`); len(value) > 0 {
+		sid = steamid.New(string(value))
+	}
+
+	if !sid.Valid() {
+		return fmt.Errorf("%w: %s", steamid.ErrInvalidSID, entry.SteamID)
+	}
+
+	*e = PlayerListEntry{SteamID: sid, Attributes: entry.Attributes, LastSeen: entry.LastSeen, Proof: entry.Proof}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, writing the steamid in steam3 format as tf2_bot_detector does.
+func (e PlayerListEntry) MarshalJSON() ([]byte, error) {
+	attributes := e.Attributes
+	if attributes == nil {
+		attributes = []PlayerAttribute{}
+	}
+
+	return json.Marshal(playerListEntryJSON{ //nolint:wrapcheck
+		SteamID:    json.RawMessage(`"` + string(e.SteamID.Steam3()) + `"`),
+		Attributes: attributes,
+		LastSeen:   e.LastSeen,
+		Proof:      e.Proof,
+	})
+}
+
+// PlayerList is a playerlist.json file as used by tf2_bot_detector and compatible tools.
+type PlayerList struct {
+	Schema   string              `json:"$schema"`
+	FileInfo *PlayerListFileInfo `json:"file_info,omitempty"`
+	Players  []PlayerListEntry   `json:"players"`
+}
+
+// SteamIDs returns the steam ids of the entries tagged with any of the attributes, or all entries when
+// no attributes are provided.
+func (l PlayerList) SteamIDs(attributes ...PlayerAttribute) steamid.Collection {
+	var steamIDs steamid.Collection
+
+	for _, entry := range l.Players {
+		if len(attributes) == 0 || slices.ContainsFunc(attributes, entry.HasAttribute) {
+			steamIDs = append(steamIDs, entry.SteamID)
+		}
+	}
+
+	return steamIDs
+}
+
+// ReadPlayerList decodes a playerlist.json file.
+func ReadPlayerList(reader io.Reader) (PlayerList, error) {
+	var list PlayerList
+	if errDecode := json.NewDecoder(reader).Decode(&list); errDecode != nil {
+		return PlayerList{}, errors.Join(errDecode, ErrDecodePlayerList)
+	}
+
+	return list, nil
+}
+
+// WritePlayerList encodes the list as an indented playerlist.json file.
+func WritePlayerList(writer io.Writer, list PlayerList) error {
+	if list.Schema == "" {
+		list.Schema = PlayerListSchema
+	}
+
+	if list.Players == nil {
+		list.Players = []PlayerListEntry{}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+
+	if errEncode := encoder.Encode(list); errEncode != nil {
+		return errors.Join(errEncode, ErrEncodePlayerList)
+	}
+
+	return nil
+}
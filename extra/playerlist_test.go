@@ -0,0 +1,52 @@
+package extra_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+const testPlayerList = `{
+	"$schema": "https://raw.githubusercontent.com/PazerOP/tf2_bot_detector/master/schemas/v3/playerlist.schema.json",
+	"file_info": {"authors": ["test"], "title": "test list"},
+	"players": [
+		{"attributes": ["cheater"], "steamid": "[U:1:148883280]", "last_seen": {"player_name": "Dulahan", "time": 1600000000}},
+		{"attributes": ["suspicious", "racist"], "steamid": 76561198146400414, "proof": ["chat log"]}
+	]
+}`
+
+func TestReadPlayerList(t *testing.T) {
+	t.Parallel()
+
+	list, err := extra.ReadPlayerList(strings.NewReader(testPlayerList))
+	require.NoError(t, err)
+	require.Equal(t, "test list", list.FileInfo.Title)
+	require.Len(t, list.Players, 2)
+	require.Equal(t, steamid.New("[U:1:148883280]"), list.Players[0].SteamID)
+	require.Equal(t, "Dulahan", list.Players[0].LastSeen.PlayerName)
+	require.True(t, list.Players[1].HasAttribute(extra.AttributeRacist))
+	require.Equal(t, steamid.Collection{steamid.New(76561198146400414)}, list.SteamIDs(extra.AttributeSuspicious))
+	require.Len(t, list.SteamIDs(), 2)
+
+	_, errInvalid := extra.ReadPlayerList(strings.NewReader(`{"players": [{"steamid": "nope"}]}`))
+	require.ErrorIs(t, errInvalid, steamid.ErrInvalidSID)
+}
+
+func TestWritePlayerList(t *testing.T) {
+	t.Parallel()
+
+	list, err := extra.ReadPlayerList(strings.NewReader(testPlayerList))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, extra.WritePlayerList(&buf, list))
+	require.Contains(t, buf.String(), `"steamid": "[U:1:186134686]"`)
+
+	roundTrip, errRoundTrip := extra.ReadPlayerList(&buf)
+	require.NoError(t, errRoundTrip)
+	require.Equal(t, list, roundTrip)
+}
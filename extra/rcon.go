@@ -0,0 +1,227 @@
+package extra
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	rconTypeResponse = 0
+	rconTypeCommand  = 2
+	rconTypeAuthResp = 2
+	rconTypeAuth     = 3
+	// rconMaxPacketSize is the largest packet accepted from the server. Responses are split into
+	// packets of at most 4096 bytes of body, so this leaves plenty of room.
+	rconMaxPacketSize = 1 << 16
+	// rconHeaderSize is the size of the id and type fields along with the two terminating nulls.
+	rconHeaderSize     = 10
+	defaultRCONTimeout = time.Second * 10
+)
+
+var (
+	ErrRCONAuth   = errors.New("rcon authentication failed")
+	ErrRCONPacket = errors.New("invalid rcon packet")
+	ErrRCONDial   = errors.New("failed to connect to rcon server")
+)
+
+// RCONOption configures optional behaviour of DialRCON.
+type RCONOption func(*rconOptions)
+
+type rconOptions struct {
+	timeout time.Duration
+}
+
+// WithRCONTimeout sets how long a single command may take when the context has no deadline.
+// Defaults to 10 seconds.
+func WithRCONTimeout(timeout time.Duration) RCONOption {
+	return func(opts *rconOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// RCON is a client for the Source RCON protocol. Commands are executed one at a time, it is safe
+// to use from multiple goroutines.
+type RCON struct {
+	mu      sync.Mutex
+	conn    net.Conn
+	reader  *bufio.Reader
+	timeout time.Duration
+	lastID  int32
+}
+
+type rconPacket struct {
+	id         int32
+	packetType int32
+	body       string
+}
+
+// DialRCON connects to the server at addr and authenticates with the password.
+func DialRCON(ctx context.Context, addr string, password string, opts ...RCONOption) (*RCON, error) {
+	options := rconOptions{timeout: defaultRCONTimeout}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var dialer net.Dialer
+
+	conn, errDial := dialer.DialContext(ctx, "tcp", addr)
+	if errDial != nil {
+		return nil, errors.Join(errDial, ErrRCONDial)
+	}
+
+	client := &RCON{conn: conn, reader: bufio.NewReader(conn), timeout: options.timeout}
+
+	if errAuth := client.auth(ctx, password); errAuth != nil {
+		_ = conn.Close()
+
+		return nil, errAuth
+	}
+
+	return client, nil
+}
+
+// Close closes the connection to the server.
+func (r *RCON) Close() error {
+	return r.conn.Close() //nolint:wrapcheck
+}
+
+func (r *RCON) nextID() int32 {
+	r.lastID++
+	if r.lastID <= 0 {
+		r.lastID = 1
+	}
+
+	return r.lastID
+}
+
+func (r *RCON) setDeadline(ctx context.Context) error {
+	deadline, found := ctx.Deadline()
+	if !found {
+		deadline = time.Now().Add(r.timeout)
+	}
+
+	return r.conn.SetDeadline(deadline) //nolint:wrapcheck
+}
+
+func (r *RCON) write(packet rconPacket) error {
+	buf := make([]byte, 4, 4+rconHeaderSize+len(packet.body))
+	binary.LittleEndian.PutUint32(buf, uint32(rconHeaderSize+len(packet.body)))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(packet.id))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(packet.packetType))
+	buf = append(buf, packet.body...)
+	buf = append(buf, 0, 0)
+
+	_, errWrite := r.conn.Write(buf)
+
+	return errWrite //nolint:wrapcheck
+}
+
+func (r *RCON) read() (rconPacket, error) {
+	var size int32
+	if errSize := binary.Read(r.reader, binary.LittleEndian, &size); errSize != nil {
+		return rconPacket{}, errSize //nolint:wrapcheck
+	}
+
+	if size < rconHeaderSize || size > rconMaxPacketSize {
+		return rconPacket{}, fmt.Errorf("%w: size %d", ErrRCONPacket, size)
+	}
+
+	buf := make([]byte, size)
+	if _, errRead := io.ReadFull(r.reader, buf); errRead != nil {
+		return rconPacket{}, errRead //nolint:wrapcheck
+	}
+
+	return rconPacket{
+		id:         int32(binary.LittleEndian.Uint32(buf[0:4])),
+		packetType: int32(binary.LittleEndian.Uint32(buf[4:8])),
+		body:       string(bytes.TrimRight(buf[8:], "\x00")),
+	}, nil
+}
+
+func (r *RCON) auth(ctx context.Context, password string) error {
+	if errDeadline := r.setDeadline(ctx); errDeadline != nil {
+		return errDeadline
+	}
+
+	authID := r.nextID()
+	if errWrite := r.write(rconPacket{id: authID, packetType: rconTypeAuth, body: password}); errWrite != nil {
+		return errWrite
+	}
+
+	// The server sends an empty response value before the auth response.
+	for {
+		packet, errRead := r.read()
+		if errRead != nil {
+			return errRead
+		}
+
+		if packet.packetType != rconTypeAuthResp {
+			continue
+		}
+
+		if packet.id != authID {
+			return ErrRCONAuth
+		}
+
+		return nil
+	}
+}
+
+// Exec runs the command on the server and returns its output. Responses split over multiple
+// packets are joined together.
+func (r *RCON) Exec(ctx context.Context, command string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if errDeadline := r.setDeadline(ctx); errDeadline != nil {
+		return "", errDeadline
+	}
+
+	commandID := r.nextID()
+	if errWrite := r.write(rconPacket{id: commandID, packetType: rconTypeCommand, body: command}); errWrite != nil {
+		return "", errWrite
+	}
+
+	// The server answers packets in order, so the response to this empty packet marks the end of
+	// the command output.
+	endID := r.nextID()
+	if errWrite := r.write(rconPacket{id: endID, packetType: rconTypeResponse}); errWrite != nil {
+		return "", errWrite
+	}
+
+	var output strings.Builder
+
+	for {
+		packet, errRead := r.read()
+		if errRead != nil {
+			return "", errRead
+		}
+
+		switch packet.id {
+		case commandID:
+			output.WriteString(packet.body)
+		case endID:
+			return output.String(), nil
+		}
+	}
+}
+
+// Status runs the status command and parses its output, including the player addresses. Player
+// rows that fail to parse are recorded in Status.Warnings.
+func (r *RCON) Status(ctx context.Context) (Status, error) {
+	output, errExec := r.Exec(ctx, "status")
+	if errExec != nil {
+		return Status{}, errExec
+	}
+
+	return ParseStatus(output, true, WithPartial())
+}
@@ -0,0 +1,128 @@
+package extra_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestPacket(conn net.Conn, id int32, packetType int32, body string) {
+	buf := binary.LittleEndian.AppendUint32(nil, uint32(10+len(body)))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(id))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(packetType))
+	buf = append(buf, body...)
+	buf = append(buf, 0, 0)
+
+	_, _ = conn.Write(buf)
+}
+
+// newTestRCONServer starts a server which answers the status command with statusText, split
+// over multiple packets like the real server does for long responses.
+func newTestRCONServer(t *testing.T, password string, statusText string) string {
+	t.Helper()
+
+	listener, errListen := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, errListen)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		conn, errAccept := listener.Accept()
+		if errAccept != nil {
+			return
+		}
+
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		for {
+			header := make([]byte, 12)
+			if _, errRead := io.ReadFull(conn, header); errRead != nil {
+				return
+			}
+
+			body := make([]byte, binary.LittleEndian.Uint32(header[0:4])-8)
+			if _, errRead := io.ReadFull(conn, body); errRead != nil {
+				return
+			}
+
+			var (
+				id      = int32(binary.LittleEndian.Uint32(header[4:8]))
+				command = strings.TrimRight(string(body), "\x00")
+			)
+
+			switch binary.LittleEndian.Uint32(header[8:12]) {
+			case 3:
+				writeTestPacket(conn, id, 0, "")
+
+				if command != password {
+					id = -1
+				}
+
+				writeTestPacket(conn, id, 2, "")
+			case 2:
+				if command == "status" {
+					half := len(statusText) / 2
+					writeTestPacket(conn, id, 0, statusText[:half])
+					writeTestPacket(conn, id, 0, statusText[half:])
+				}
+			case 0:
+				writeTestPacket(conn, id, 0, "")
+				writeTestPacket(conn, id, 0, "\x00\x01\x00\x00")
+			}
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestRCON(t *testing.T) {
+	t.Parallel()
+
+	statusText := `hostname: Uncletopia | US West 2
+udp/ip  : 23.239.22.163:27015  (public ip: 23.239.22.163)
+map     : pl_goldrush at: 0 x, 0 y, 0 z
+players : 2 humans, 0 bots (32 max)
+# userid name                uniqueid            connected ping loss state  adr
+#   4247 "Dulahan"           [U:1:148883280]     55:09       74    0 active 1.2.64.84:27005
+#   4235 "Nox"               [U:1:186134686]      1:21:18   123    0 active 1.2.212.98:27005
+`
+
+	ctx := context.Background()
+	addr := newTestRCONServer(t, "secret", statusText)
+
+	client, errDial := extra.DialRCON(ctx, addr, "secret")
+	require.NoError(t, errDial)
+
+	defer func() {
+		require.NoError(t, client.Close())
+	}()
+
+	output, errExec := client.Exec(ctx, "status")
+	require.NoError(t, errExec)
+	require.Equal(t, statusText, output)
+
+	status, errStatus := client.Status(ctx)
+	require.NoError(t, errStatus)
+	require.Equal(t, "Uncletopia | US West 2", status.ServerName)
+	require.Len(t, status.Players, 2)
+	require.Equal(t, "1.2.212.98", status.Players[1].IP.String())
+}
+
+func TestRCONAuth(t *testing.T) {
+	t.Parallel()
+
+	addr := newTestRCONServer(t, "secret", "")
+
+	_, errDial := extra.DialRCON(context.Background(), addr, "wrong")
+	require.ErrorIs(t, errDial, extra.ErrRCONAuth)
+}
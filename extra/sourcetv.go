@@ -0,0 +1,170 @@
+package extra
+
+import (
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	reTVProxy     = regexp.MustCompile(`^(?:SourceTV|GOTV) (Master|Relay) "(.*)", (?:delay ([\d.]+)|(connected|not connect(?:ed)?))`)
+	reTVAddress   = regexp.MustCompile(`^IP (\S+):(\d+), Online ([\d.:]+), Version (\d+) \((\w+)\)`)
+	reTVRecording = regexp.MustCompile(`^Recording to "(.+)", length ([\d.:]+?)\.?$`)
+	reTVSlots     = regexp.MustCompile(`^(Local|Total) Slots (\d+), Spectators (\d+), Proxies (\d+)`)
+	reTVGame      = regexp.MustCompile(`^Game Time ([\d.:]+), Mod "(.*)", Map "(.*)", Players (\d+)`)
+)
+
+var ErrParseTVStatus = errors.New("failed to parse tv_status")
+
+// TVSlots holds the slot and spectator counts for either the local proxy or the entire relay network.
+type TVSlots struct {
+	Slots      int
+	Spectators int
+	Proxies    int
+}
+
+// TVStatus represents the output of the `tv_status` console command on a SourceTV/GOTV master
+// or relay proxy.
+type TVStatus struct {
+	Name string
+	// Master is true for the master proxy running on the game server, false for relays.
+	Master bool
+	// Connected reports whether a relay is currently connected to its upstream proxy. Masters
+	// are always considered connected.
+	Connected bool
+	// Delay is only reported by the master proxy.
+	Delay   time.Duration
+	IP      net.IP
+	Port    int
+	Online  time.Duration
+	Version int
+	OS      string
+	// RecordingFile and RecordingLength are only set when a demo is being recorded.
+	RecordingFile   string
+	RecordingLength time.Duration
+	// Local holds the counts for this proxy, Total includes all downstream relays.
+	Local TVSlots
+	Total TVSlots
+	// The remaining fields are only set when the proxy is connected to a game server.
+	GameTime time.Duration
+	Mod      string
+	Map      string
+	Players  int
+}
+
+func atoiFields(values ...string) ([]int, error) {
+	results := make([]int, len(values))
+
+	for idx, value := range values {
+		parsed, errParse := strconv.ParseUint(value, 10, 32)
+		if errParse != nil {
+			return nil, errors.Join(errParse, ErrParseTVStatus)
+		}
+
+		results[idx] = int(parsed)
+	}
+
+	return results, nil
+}
+
+// ParseTVStatus parses the output of the `tv_status` console command. eg:
+//
+//	--- SourceTV Status ---
+//	SourceTV Master "Uncletopia TV", delay 90
+//	IP 23.239.22.163:27020, Online 01:23:45, Version 8622567 (Linux)
+//	Recording to "auto-20261016-1200-pl_goldrush.dem", length 23:01.
+//	Local Slots 128, Spectators 3, Proxies 1
+//	Total Slots 256, Spectators 20, Proxies 1
+//	Game Time 01:23:40, Mod "tf", Map "pl_goldrush", Players 24
+//
+// An error is returned if the output does not contain a master or relay header line.
+func ParseTVStatus(text string) (TVStatus, error) {
+	var (
+		tvStatus TVStatus
+		found    bool
+	)
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+
+		if match := reTVProxy.FindStringSubmatch(line); match != nil {
+			found = true
+			tvStatus.Name = match[2]
+			tvStatus.Master = match[1] == "Master"
+			tvStatus.Connected = tvStatus.Master || match[4] == "connected"
+
+			if match[3] != "" {
+				delay, errDelay := strconv.ParseFloat(match[3], 64)
+				if errDelay != nil {
+					return TVStatus{}, errors.Join(errDelay, ErrParseDuration)
+				}
+
+				tvStatus.Delay = time.Duration(delay * float64(time.Second))
+			}
+		} else if match = reTVAddress.FindStringSubmatch(line); match != nil {
+			tvStatus.IP = net.ParseIP(match[1])
+			if tvStatus.IP == nil {
+				return TVStatus{}, ErrParseIP
+			}
+
+			online, errOnline := parseConnected(match[3])
+			if errOnline != nil {
+				return TVStatus{}, errOnline
+			}
+
+			values, errValues := atoiFields(match[2], match[4])
+			if errValues != nil {
+				return TVStatus{}, errValues
+			}
+
+			tvStatus.Port = values[0]
+			tvStatus.Online = online
+			tvStatus.Version = values[1]
+			tvStatus.OS = match[5]
+		} else if match = reTVRecording.FindStringSubmatch(line); match != nil {
+			length, errLength := parseConnected(match[2])
+			if errLength != nil {
+				return TVStatus{}, errLength
+			}
+
+			tvStatus.RecordingFile = match[1]
+			tvStatus.RecordingLength = length
+		} else if match = reTVSlots.FindStringSubmatch(line); match != nil {
+			values, errValues := atoiFields(match[2], match[3], match[4])
+			if errValues != nil {
+				return TVStatus{}, errValues
+			}
+
+			slots := TVSlots{Slots: values[0], Spectators: values[1], Proxies: values[2]}
+			if match[1] == "Local" {
+				tvStatus.Local = slots
+			} else {
+				tvStatus.Total = slots
+			}
+		} else if match = reTVGame.FindStringSubmatch(line); match != nil {
+			gameTime, errGameTime := parseConnected(match[1])
+			if errGameTime != nil {
+				return TVStatus{}, errGameTime
+			}
+
+			values, errValues := atoiFields(match[4])
+			if errValues != nil {
+				return TVStatus{}, errValues
+			}
+
+			tvStatus.GameTime = gameTime
+			tvStatus.Mod = match[2]
+			tvStatus.Map = match[3]
+			tvStatus.Players = values[0]
+		}
+	}
+
+	if !found {
+		return TVStatus{}, ErrParseTVStatus
+	}
+
+	return tvStatus, nil
+}
@@ -0,0 +1,87 @@
+package extra_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTVStatus(t *testing.T) {
+	t.Parallel()
+
+	master, err := extra.ParseTVStatus(`--- SourceTV Status ---
+SourceTV Master "Uncletopia TV", delay 90
+IP 23.239.22.163:27020, Online 01:23:45, Version 8622567 (Linux)
+Recording to "auto-20261016-1200-pl_goldrush.dem", length 23:01.
+Local Slots 128, Spectators 3, Proxies 1
+Total Slots 256, Spectators 20, Proxies 1
+Game Time 01:23:40, Mod "tf", Map "pl_goldrush", Players 24
+`)
+	require.NoError(t, err)
+	require.Equal(t, extra.TVStatus{
+		Name:            "Uncletopia TV",
+		Master:          true,
+		Connected:       true,
+		Delay:           90 * time.Second,
+		IP:              net.ParseIP("23.239.22.163"),
+		Port:            27020,
+		Online:          time.Hour + 23*time.Minute + 45*time.Second,
+		Version:         8622567,
+		OS:              "Linux",
+		RecordingFile:   "auto-20261016-1200-pl_goldrush.dem",
+		RecordingLength: 23*time.Minute + time.Second,
+		Local:           extra.TVSlots{Slots: 128, Spectators: 3, Proxies: 1},
+		Total:           extra.TVSlots{Slots: 256, Spectators: 20, Proxies: 1},
+		GameTime:        time.Hour + 23*time.Minute + 40*time.Second,
+		Mod:             "tf",
+		Map:             "pl_goldrush",
+		Players:         24,
+	}, master)
+
+	relay, errRelay := extra.ParseTVStatus(`GOTV Relay "relay-1", not connect.
+IP 10.0.0.2:27021, Online 00:10, Version 8622567 (Win32)
+Local Slots 64, Spectators 0, Proxies 0
+Total Slots 64, Spectators 0, Proxies 0
+Not connected to server.
+`)
+	require.NoError(t, errRelay)
+	require.False(t, relay.Master)
+	require.False(t, relay.Connected)
+	require.Equal(t, "relay-1", relay.Name)
+	require.Equal(t, 10*time.Second, relay.Online)
+	require.Empty(t, relay.Map)
+
+	_, errInvalid := extra.ParseTVStatus("hostname: not tv_status")
+	require.ErrorIs(t, errInvalid, extra.ErrParseTVStatus)
+}
+
+func TestParseStatusSourceTV(t *testing.T) {
+	t.Parallel()
+
+	statusText := `hostname: Uncletopia | US West 2
+sourcetv:  23.239.22.163:27020, delay 30.0s  (local: 10.0.0.5:27020)
+players : 1 humans, 1 bots (32 max)
+# userid name                uniqueid            connected ping loss state  adr
+#      2 "SourceTV"          BOT                                     active
+#   4247 "Dulahan"           [U:1:148883280]     55:09       74    0 active 1.2.64.84:27005
+`
+
+	parsedStatus, err := extra.ParseStatus(statusText, true)
+	require.NoError(t, err)
+	require.Equal(t, 1, parsedStatus.PlayersCount)
+	require.Equal(t, []extra.Bot{{UserID: 2, Name: "SourceTV", State: "active", SourceTV: true}}, parsedStatus.Bots)
+	require.Equal(t, &extra.SourceTV{
+		IP:        net.ParseIP("23.239.22.163"),
+		Port:      27020,
+		Delay:     30 * time.Second,
+		LocalIP:   net.ParseIP("10.0.0.5"),
+		LocalPort: 27020,
+	}, parsedStatus.SourceTV)
+
+	oldFormat, errOld := extra.ParseStatus("sourcetv:  port 27020, delay 90.0s\n", false)
+	require.NoError(t, errOld)
+	require.Equal(t, &extra.SourceTV{Port: 27020, Delay: 90 * time.Second}, oldFormat.SourceTV)
+}
@@ -1,8 +1,10 @@
 package extra
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"regexp"
 	"strconv"
@@ -15,7 +17,11 @@ import (
 var (
 	reStatusID         = regexp.MustCompile(`"(.+?)"\s+(\[U:\d+:\d+]|STEAM_\d:\d:\d+)`)
 	reStatusPlayerFull = regexp.MustCompile(`^#\s+(\d+)\s+"(.+?)"\s+(\[U:\d:\d+])\s+(.+?)\s+(\d+)\s+(\d+)\s+(.+?)\s(.+?):(.+?)$`)
-	reStatusPlayer     = regexp.MustCompile(`^#\s+(\d+)\s+"(.+?)"\s+(\[U:\d:\d+])\s+(\d+:\d+)\s+(\d+)\s+(\d+)\s+(.+?)$`)
+	reStatusPlayer     = regexp.MustCompile(`^#\s+(\d+)\s+"(.+?)"\s+(\[U:\d:\d+])\s+(.+?)\s+(\d+)\s+(\d+)\s+(.+?)$`)
+	reStatusBot        = regexp.MustCompile(`^#\s+(\d+)\s+"(.+?)"\s+BOT\s+(\S+)`)
+	reStatusSourceTV   = regexp.MustCompile(`^(?:port (\d+)|(\S+):(\d+)),\s*delay\s+([\d.]+)s?(?:\s+\(local:\s*(\S+):(\d+)\))?`)
+	reStatusPublicIP   = regexp.MustCompile(`public ip(?: from Steam)?:\s*([\d.]+)`)
+	reConnectedOver    = regexp.MustCompile(`^>\s*(\d+)\s*(d|days?|h|hours?)$`)
 )
 
 var (
@@ -29,11 +35,20 @@ var (
 	ErrParseDuration   = errors.New("failed to parse duration")
 	ErrParseIP         = errors.New("failed to parse ip")
 	ErrParsePort       = errors.New("failed to parse port")
+	ErrParseSourceTV   = errors.New("failed to parse sourcetv")
 )
 
 // Status represents the data from the `status` rcon/console command.
 
 type Status struct {
+	// IP and Port are the server address from the udp/ip line.
+	IP   net.IP
+	Port int
+	// PublicIP is set when the server reports a separate public address.
+	PublicIP net.IP
+	// SDR is true when the server is behind the Steam Datagram Relay, in which case IP is a relay
+	// address rather than the real server address.
+	SDR          bool
 	PlayersCount int
 	PlayersMax   int
 	ServerName   string
@@ -42,6 +57,12 @@ type Status struct {
 	Tags         []string
 	Map          string
 	Players      []Player
+	// Bots contains the rows of any bots, such as SourceTV, which are not included in Players.
+	Bots []Bot
+	// SourceTV is populated when the server has SourceTV/GOTV enabled.
+	SourceTV *SourceTV
+	// Warnings contains the player rows that were skipped when parsing with WithPartial.
+	Warnings []LineError
 }
 
 // Player represents all the available data for a player in a `status` output table.
@@ -55,6 +76,47 @@ type Player struct {
 	State         string
 	IP            net.IP
 	Port          int
+	// SDR is true when the player is connecting through the Steam Datagram Relay. IP and Port are
+	// then the relay address assigned to the player rather than their real address.
+	SDR bool
+}
+
+// RealIP returns the players IP address, if known. The address is not known when not parsing
+// with full enabled or when the player is connected via SDR.
+func (p Player) RealIP() (net.IP, bool) {
+	if p.IP == nil || p.SDR {
+		return nil, false
+	}
+
+	return p.IP, true
+}
+
+// sdrNetwork is the link-local range that Steam Datagram Relay uses to assign fake addresses to
+// servers and clients.
+var sdrNetwork = net.IPNet{IP: net.IPv4(169, 254, 0, 0), Mask: net.CIDRMask(16, 32)} //nolint:gochecknoglobals
+
+func isSDRAddress(ip net.IP) bool {
+	return ip != nil && sdrNetwork.Contains(ip)
+}
+
+// Bot represents a bot entry in a `status` output table.
+type Bot struct {
+	UserID int
+	Name   string
+	State  string
+	// SourceTV is true when the bot is the SourceTV/GOTV bot.
+	SourceTV bool
+}
+
+// SourceTV represents the `sourcetv` (or `GOTV`) line from the `status` command.
+type SourceTV struct {
+	// IP is only available on newer builds which report the full address instead of only the port.
+	IP    net.IP
+	Port  int
+	Delay time.Duration
+	// LocalIP and LocalPort are set when the server reports a separate local address.
+	LocalIP   net.IP
+	LocalPort int
 }
 
 // SIDSFromStatus will parse the output of the console command `status` and return a
@@ -77,6 +139,9 @@ func SIDSFromStatus(text string) []steamid.SteamID {
 
 func parseMaxPlayers(part string) int {
 	ps := strings.Split(strings.ReplaceAll(part, "(", ""), " ")
+	if len(ps) < 5 {
+		return -1
+	}
 
 	m, errPlayers := strconv.ParseUint(ps[4], 10, 64)
 	if errPlayers != nil {
@@ -88,6 +153,9 @@ func parseMaxPlayers(part string) int {
 
 func parseEdits(part string) []int {
 	ed := strings.Split(part, " ")
+	if len(ed) < 4 {
+		return []int{-1, -1}
+	}
 
 	l, errEdictCount := strconv.ParseUint(ed[0], 10, 64)
 	if errEdictCount != nil {
@@ -102,19 +170,282 @@ func parseEdits(part string) []int {
 	return []int{int(l), int(m)}
 }
 
+// StatusOption configures optional behaviour of ParseStatus.
+type StatusOption func(*statusOptions)
+
+type statusOptions struct {
+	partial     bool
+	maxLineSize int
+}
+
+// WithPartial makes ParseStatus continue past player rows that fail to parse. The rows that were
+// skipped are recorded in Status.Warnings instead of aborting the whole parse.
+func WithPartial() StatusOption {
+	return func(opts *statusOptions) {
+		opts.partial = true
+	}
+}
+
+// WithMaxLineSize sets the maximum length of a single line of status output. Lines longer than
+// bufio.MaxScanTokenSize, such as very long hostname or tags values, otherwise cause
+// parsing to fail with ErrScan.
+func WithMaxLineSize(size int) StatusOption {
+	return func(opts *statusOptions) {
+		opts.maxLineSize = size
+	}
+}
+
+// LineError describes a single status line that failed to parse. It matches steamid.ErrParse with
+// errors.Is, along with the cause.
+type LineError struct {
+	// Line is the 1-indexed line number within the status output.
+	Line int
+	// Text is the raw contents of the line.
+	Text string
+	Err  error
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e LineError) Unwrap() []error {
+	return []error{steamid.ErrParse, e.Err}
+}
+
+// parseServerAddress parses the udp/ip line into the address fields of the status. eg:
+//
+//	udp/ip  : 23.239.22.163:27015  (public ip: 23.239.22.163)
+//	udp/ip  : 169.254.187.96:34123  (public ip: 23.239.22.163)
+//
+// Malformed values are ignored as they are not essential to the rest of the output.
+func parseServerAddress(status *Status, value string) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return
+	}
+
+	host, portStr, errSplit := net.SplitHostPort(fields[0])
+	if errSplit != nil {
+		return
+	}
+
+	port, errPort := strconv.ParseUint(portStr, 10, 16)
+	if errPort != nil {
+		return
+	}
+
+	status.IP = net.ParseIP(host)
+	status.Port = int(port)
+	status.SDR = isSDRAddress(status.IP)
+
+	if match := reStatusPublicIP.FindStringSubmatch(value); match != nil {
+		status.PublicIP = net.ParseIP(match[1])
+	}
+}
+
+// parseSourceTV parses the value of the sourcetv status line. Both the older port only
+// form and the newer address form are supported:
+//
+//	sourcetv:  port 27020, delay 90.0s
+//	sourcetv:  23.239.22.163:27020, delay 30.0s  (local: 10.0.0.5:27020)
+func parseSourceTV(value string) (SourceTV, error) {
+	match := reStatusSourceTV.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return SourceTV{}, ErrParseSourceTV
+	}
+
+	var tv SourceTV
+
+	portStr := match[1]
+	if portStr == "" {
+		tv.IP = net.ParseIP(match[2])
+		if tv.IP == nil {
+			return SourceTV{}, ErrParseIP
+		}
+
+		portStr = match[3]
+	}
+
+	port, errPort := strconv.ParseUint(portStr, 10, 16)
+	if errPort != nil {
+		return SourceTV{}, errors.Join(errPort, ErrParsePort)
+	}
+
+	tv.Port = int(port)
+
+	delay, errDelay := strconv.ParseFloat(match[4], 64)
+	if errDelay != nil {
+		return SourceTV{}, errors.Join(errDelay, ErrParseDuration)
+	}
+
+	tv.Delay = time.Duration(delay * float64(time.Second))
+
+	if match[5] != "" {
+		localPort, errLocalPort := strconv.ParseUint(match[6], 10, 16)
+		if errLocalPort != nil {
+			return SourceTV{}, errors.Join(errLocalPort, ErrParsePort)
+		}
+
+		tv.LocalIP = net.ParseIP(match[5])
+		tv.LocalPort = int(localPort)
+	}
+
+	return tv, nil
+}
+
+// parseConnected parses the connected column of a status player row. The following forms are
+// supported:
+//
+//	55:09         mm:ss
+//	1:21:18       hh:mm:ss
+//	2.03:14:55    d.hh:mm:ss
+//	>1 day        overflow values, also ">2 days", ">1d", ">12 hours" and ">12h"
+//
+// Overflow values only report a lower bound, so the returned duration is the minimum
+// amount of time they represent.
+func parseConnected(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	if over := reConnectedOver.FindStringSubmatch(value); over != nil {
+		count, errCount := strconv.ParseUint(over[1], 10, 32)
+		if errCount != nil {
+			return 0, errors.Join(errCount, ErrParseDuration)
+		}
+
+		unit := time.Hour
+		if strings.HasPrefix(over[2], "d") {
+			unit = time.Hour * 24
+		}
+
+		return time.Duration(count) * unit, nil
+	}
+
+	var days uint64
+
+	if dayStr, clock, found := strings.Cut(value, "."); found {
+		parsedDays, errDays := strconv.ParseUint(dayStr, 10, 32)
+		if errDays != nil {
+			return 0, errors.Join(errDays, ErrParseDuration)
+		}
+
+		days = parsedDays
+		value = clock
+	}
+
+	tp := strings.Split(value, ":")
+	if len(tp) < 2 || len(tp) > 3 || (days > 0 && len(tp) != 3) {
+		return 0, fmt.Errorf("%w: %s", ErrParseDuration, value)
+	}
+
+	for i, j := 0, len(tp)-1; i < j; i, j = i+1, j-1 {
+		tp[i], tp[j] = tp[j], tp[i]
+	}
+
+	totalSec := days * 86400
+
+	for i, vStr := range tp {
+		v, errUint := strconv.ParseUint(vStr, 10, 32)
+		if errUint != nil {
+			return 0, errors.Join(errUint, ErrParseSeconds)
+		}
+
+		totalSec += v * []uint64{1, 60, 3600}[i]
+	}
+
+	return time.Duration(totalSec) * time.Second, nil
+}
+
+func parsePlayer(match []string, full bool) (Player, error) {
+	userID, errUserID := strconv.ParseUint(match[1], 10, 64)
+	if errUserID != nil {
+		return Player{}, errors.Join(errUserID, ErrParseUserID)
+	}
+
+	ping, err2 := strconv.ParseUint(match[5], 10, 64)
+	if err2 != nil {
+		return Player{}, errors.Join(err2, ErrParsePing)
+	}
+
+	loss, err3 := strconv.ParseUint(match[6], 10, 64)
+	if err3 != nil {
+		return Player{}, errors.Join(err3, ErrParseLoss)
+	}
+
+	dur, errDur := parseConnected(match[4])
+	if errDur != nil {
+		return Player{}, errDur
+	}
+
+	player := Player{
+		UserID:        int(userID),
+		Name:          match[2],
+		SID:           steamid.New(match[3]),
+		ConnectedTime: dur,
+		Ping:          int(ping),
+		Loss:          int(loss),
+		State:         match[7],
+	}
+
+	if full {
+		port, errFull := strconv.ParseUint(match[9], 10, 64)
+		if errFull != nil {
+			return Player{}, errors.Join(errFull, ErrParsePort)
+		}
+
+		ip := net.ParseIP(match[8])
+		if ip == nil {
+			return Player{}, ErrParseIP
+		}
+
+		player.IP = ip
+		player.Port = int(port)
+		player.SDR = isSDRAddress(ip)
+	}
+
+	return player, nil
+}
+
 // ParseStatus will parse a status command output into a struct
 // If full is true, it will also parse the address/port of the player.
 // This only works for status commands via RCON/CLI.
-func ParseStatus(status string, full bool) (Status, error) {
-	var s Status
+//
+// By default, the first player row that fails to parse aborts parsing and the error is returned
+// as a LineError. Use WithPartial to collect these errors in Status.Warnings instead.
+func ParseStatus(status string, full bool, opts ...StatusOption) (Status, error) {
+	return ParseStatusFrom(strings.NewReader(status), full, opts...)
+}
+
+// ParseStatusFrom works like ParseStatus, but reads the status output from a reader, such as the
+// output of a RCON client or a file, instead of requiring it to be read into a string first.
+func ParseStatusFrom(reader io.Reader, full bool, opts ...StatusOption) (Status, error) {
+	var (
+		s       Status
+		options statusOptions
+		lineNum int
+	)
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	scanner := bufio.NewScanner(reader)
+	if options.maxLineSize > 0 {
+		scanner.Buffer(make([]byte, 0, min(options.maxLineSize, bufio.MaxScanTokenSize)), options.maxLineSize)
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNum++
 
-	for _, line := range strings.Split(status, "\n") {
 		parts := strings.SplitN(line, ": ", 2)
 
 		if len(parts) == 2 {
 			switch strings.TrimRight(parts[0], " ") {
 			case "hostname":
 				s.ServerName = parts[1]
+			case "udp/ip":
+				parseServerAddress(&s, parts[1])
 			case "version":
 				s.Version = parts[1]
 			case "map":
@@ -129,85 +460,60 @@ func ParseStatus(status string, full bool) (Status, error) {
 				if ed := parseEdits(parts[1]); ed[0] > 0 && ed[1] > 0 {
 					s.Edicts = ed
 				}
+			case "sourcetv", "gotv", "GOTV":
+				if tv, errTV := parseSourceTV(parts[1]); errTV == nil {
+					s.SourceTV = &tv
+				}
 			}
 
 			continue
-		} else {
-			var m []string
+		}
 
-			if full {
-				m = reStatusPlayerFull.FindStringSubmatch(line)
-			} else {
-				m = reStatusPlayer.FindStringSubmatch(line)
+		if bot := reStatusBot.FindStringSubmatch(line); bot != nil {
+			userID, errUserID := strconv.ParseUint(bot[1], 10, 64)
+			if errUserID != nil {
+				continue
 			}
 
-			if (!full && len(m) == 8) || (full && len(m) == 10) {
-				userID, errUserID := strconv.ParseUint(m[1], 10, 64)
-				if errUserID != nil {
-					return Status{}, errors.Join(errUserID, ErrParseUserID)
-				}
-
-				ping, err2 := strconv.ParseUint(m[5], 10, 64)
-				if err2 != nil {
-					return Status{}, errors.Join(err2, ErrParsePing)
-				}
-
-				loss, err3 := strconv.ParseUint(m[6], 10, 64)
-				if err3 != nil {
-					return Status{}, errors.Join(err3, ErrParseLoss)
-				}
-
-				tp := strings.Split(m[4], ":")
-
-				for i, j := 0, len(tp)-1; i < j; i, j = i+1, j-1 {
-					tp[i], tp[j] = tp[j], tp[i]
-				}
-
-				var totalSec int
+			s.Bots = append(s.Bots, Bot{
+				UserID:   int(userID),
+				Name:     bot[2],
+				State:    bot[3],
+				SourceTV: strings.EqualFold(bot[2], "SourceTV") || strings.EqualFold(bot[2], "GOTV"),
+			})
 
-				for i, vStr := range tp {
-					v, errUint := strconv.ParseUint(vStr, 10, 64)
-					if errUint != nil {
-						return Status{}, errors.Join(errUint, ErrParseSeconds)
-					}
+			continue
+		}
 
-					totalSec += int(v) * []int{1, 60, 3600}[i]
-				}
+		var m []string
 
-				dur, errDur := time.ParseDuration(fmt.Sprintf("%ds", totalSec))
+		if full {
+			m = reStatusPlayerFull.FindStringSubmatch(line)
+		} else {
+			m = reStatusPlayer.FindStringSubmatch(line)
+		}
 
-				if errDur != nil {
-					return Status{}, errors.Join(errDur, ErrParseDuration)
-				}
+		if (!full && len(m) != 8) || (full && len(m) != 10) {
+			continue
+		}
 
-				p := Player{
-					UserID:        int(userID),
-					Name:          m[2],
-					SID:           steamid.New(m[3]),
-					ConnectedTime: dur,
-					Ping:          int(ping),
-					Loss:          int(loss),
-					State:         m[7],
-				}
+		player, errPlayer := parsePlayer(m, full)
+		if errPlayer != nil {
+			lineErr := LineError{Line: lineNum, Text: line, Err: errPlayer}
+			if !options.partial {
+				return Status{}, lineErr
+			}
 
-				if full {
-					port, errFull := strconv.ParseUint(m[9], 10, 64)
-					if errFull != nil {
-						return Status{}, errors.Join(errFull, ErrParsePort)
-					}
+			s.Warnings = append(s.Warnings, lineErr)
 
-					ip := net.ParseIP(m[8])
-					if ip == nil {
-						return Status{}, ErrParseIP
-					}
+			continue
+		}
 
-					p.IP = ip
-					p.Port = int(port)
-				}
+		s.Players = append(s.Players, player)
+	}
 
-				s.Players = append(s.Players, p)
-			}
-		}
+	if errScan := scanner.Err(); errScan != nil {
+		return Status{}, errors.Join(errScan, ErrScan)
 	}
 
 	s.PlayersCount = len(s.Players)
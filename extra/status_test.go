@@ -1,9 +1,14 @@
 package extra_test
 
 import (
+	"bufio"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,3 +52,105 @@ edicts  : 1717 used of 2048 max
 	require.Equal(t, []string{"Uncletopia", "nocrits", "nodmgspread", "payload"}, parsedStatus.Tags)
 	require.Equal(t, "5970214/24 5970214 secure", parsedStatus.Version)
 }
+
+func TestParseStatusPartial(t *testing.T) {
+	t.Parallel()
+
+	statusText := `hostname: Uncletopia | US West 2
+# userid name                uniqueid            connected ping loss state  adr
+#   4247 "Dulahan"           [U:1:148883280]     55:09       74    0 active 1.2.64.84:27005
+#   4235 "Nox"               [U:1:186134686]      1:21:18   99999999999999999999    0 active 1.2.212.98:27005
+#   4262 "George Scrumpus"   [U:1:64274886]      17:09      118    0 active 1.2.121.68:27005
+`
+
+	_, errStrict := extra.ParseStatus(statusText, true)
+	require.ErrorIs(t, errStrict, extra.ErrParsePing)
+	require.ErrorIs(t, errStrict, steamid.ErrParse)
+
+	var lineErr extra.LineError
+	require.ErrorAs(t, errStrict, &lineErr)
+	require.Equal(t, 4, lineErr.Line)
+
+	parsedStatus, err := extra.ParseStatus(statusText, true, extra.WithPartial())
+	require.NoError(t, err)
+	require.Equal(t, 2, parsedStatus.PlayersCount)
+	require.Len(t, parsedStatus.Warnings, 1)
+	require.Equal(t, 4, parsedStatus.Warnings[0].Line)
+	require.ErrorIs(t, parsedStatus.Warnings[0], extra.ErrParsePing)
+}
+
+func TestParseStatusConnectedTime(t *testing.T) {
+	t.Parallel()
+
+	statusText := `# userid name                uniqueid            connected ping loss state  adr
+#      2 "mm:ss"             [U:1:148883280]     55:09       74    0 active 1.2.64.84:27005
+#      3 "hh:mm:ss"          [U:1:186134686]      1:21:18   123    0 active 1.2.212.98:27005
+#      4 "d.hh:mm:ss"        [U:1:64274886]      2.03:14:55  118    0 active 1.2.121.68:27005
+#      5 "over day"          [U:1:190163035]     >1 day      72    0 active 1.2.246.238:27005
+#      6 "over days"         [U:1:119851869]     >3 days     53    0 active 1.2.110.66:27005
+#      7 "over hours"        [U:1:191380023]     >12h       105    0 active 1.2.67.76:27005
+`
+
+	expected := []time.Duration{
+		55*time.Minute + 9*time.Second,
+		time.Hour + 21*time.Minute + 18*time.Second,
+		51*time.Hour + 14*time.Minute + 55*time.Second,
+		24 * time.Hour,
+		72 * time.Hour,
+		12 * time.Hour,
+	}
+
+	for _, full := range []bool{true, false} {
+		parsedStatus, err := extra.ParseStatus(statusText, full)
+		require.NoError(t, err)
+		require.Len(t, parsedStatus.Players, len(expected))
+
+		for i, player := range parsedStatus.Players {
+			require.Equal(t, expected[i], player.ConnectedTime, player.Name)
+		}
+	}
+}
+
+func TestParseStatusSDR(t *testing.T) {
+	t.Parallel()
+
+	statusText := `hostname: Uncletopia | US West 2
+udp/ip  : 169.254.187.96:34123  (public ip: 23.239.22.163)
+# userid name                uniqueid            connected ping loss state  adr
+#   4247 "Dulahan"           [U:1:148883280]     55:09       74    0 active 169.254.44.150:49321
+#   4235 "Nox"               [U:1:186134686]      1:21:18   123    0 active 1.2.212.98:27005
+`
+
+	parsedStatus, err := extra.ParseStatus(statusText, true)
+	require.NoError(t, err)
+	require.True(t, parsedStatus.SDR)
+	require.Equal(t, 34123, parsedStatus.Port)
+	require.Equal(t, net.ParseIP("23.239.22.163"), parsedStatus.PublicIP)
+
+	require.True(t, parsedStatus.Players[0].SDR)
+	_, known := parsedStatus.Players[0].RealIP()
+	require.False(t, known)
+
+	require.False(t, parsedStatus.Players[1].SDR)
+	realIP, knownReal := parsedStatus.Players[1].RealIP()
+	require.True(t, knownReal)
+	require.Equal(t, net.ParseIP("1.2.212.98"), realIP)
+}
+
+func TestParseStatusFrom(t *testing.T) {
+	t.Parallel()
+
+	hostname := strings.Repeat("x", bufio.MaxScanTokenSize+1)
+	statusText := "hostname: " + hostname + "\r\n" +
+		"# userid name                uniqueid            connected ping loss state  adr\r\n" +
+		"#   4247 \"Dulahan\"           [U:1:148883280]     55:09       74    0 active 1.2.64.84:27005\r\n"
+
+	_, errTooLong := extra.ParseStatusFrom(strings.NewReader(statusText), true)
+	require.ErrorIs(t, errTooLong, extra.ErrScan)
+
+	parsedStatus, err := extra.ParseStatusFrom(strings.NewReader(statusText), true, extra.WithMaxLineSize(1024*1024))
+	require.NoError(t, err)
+	require.Equal(t, hostname, parsedStatus.ServerName)
+	require.Equal(t, 1, parsedStatus.PlayersCount)
+	require.Equal(t, 27005, parsedStatus.Players[0].Port)
+}
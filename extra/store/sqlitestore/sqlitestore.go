@@ -0,0 +1,228 @@
+// Package sqlitestore provides a store.Store persisted in a SQLite database, using a pure go
+// driver so cgo is not required. Times are stored as unix seconds.
+//
+//	db, err := sqlitestore.Open(ctx, "steamid.db")
+//	defer db.Close()
+package sqlitestore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	_ "github.com/glebarez/go-sqlite" // registers the sqlite database/sql driver
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/extra/store"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// schema is the schema of the store. The tables are created when missing, so a database may be
+// shared with other tables.
+const schema = `
+CREATE TABLE IF NOT EXISTS steamid_resolutions (
+    query      TEXT NOT NULL,
+    steam64    INTEGER NOT NULL,
+    first_seen INTEGER NOT NULL,
+    last_seen  INTEGER NOT NULL,
+    PRIMARY KEY (query, steam64)
+);
+CREATE INDEX IF NOT EXISTS steamid_resolutions_steam64 ON steamid_resolutions (steam64);
+CREATE TABLE IF NOT EXISTS steamid_aliases (
+    steam64    INTEGER NOT NULL,
+    name       TEXT NOT NULL,
+    first_seen INTEGER NOT NULL,
+    last_seen  INTEGER NOT NULL,
+    PRIMARY KEY (steam64, name)
+);
+CREATE INDEX IF NOT EXISTS steamid_aliases_name ON steamid_aliases (name);`
+
+var _ store.Store = (*Store)(nil)
+
+// Store is a store.Store persisted in a SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens or creates the database at path and its tables.
+func Open(ctx context.Context, path string) (*Store, error) {
+	db, errOpen := sql.Open("sqlite", path)
+	if errOpen != nil {
+		return nil, errors.Join(errOpen, store.ErrStore)
+	}
+
+	// Writes are serialized by sqlite, a single connection avoids busy errors between them.
+	db.SetMaxOpenConns(1)
+
+	if _, errSchema := db.ExecContext(ctx, schema); errSchema != nil {
+		_ = db.Close()
+
+		return nil, errors.Join(errSchema, store.ErrStore)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// AddResolution implements store.Store.
+func (s *Store) AddResolution(ctx context.Context, query string, sid steamid.SteamID, seen time.Time) error {
+	if !sid.Valid() || strings.TrimSpace(query) == "" {
+		return nil
+	}
+
+	return s.exec(ctx, `INSERT INTO steamid_resolutions (query, steam64, first_seen, last_seen) VALUES (?, ?, ?, ?)
+		ON CONFLICT (query, steam64) DO UPDATE SET first_seen = min(first_seen, excluded.first_seen),
+		last_seen = max(last_seen, excluded.last_seen)`, query, sid, seen.Unix(), seen.Unix())
+}
+
+// Resolution implements store.Store.
+func (s *Store) Resolution(ctx context.Context, query string) (store.Resolution, error) {
+	resolutions, errQuery := s.resolutions(ctx, `SELECT query, steam64, first_seen, last_seen FROM steamid_resolutions
+		WHERE query = ? ORDER BY last_seen DESC LIMIT 1`, query)
+	if errQuery != nil {
+		return store.Resolution{}, errQuery
+	}
+
+	if len(resolutions) == 0 {
+		return store.Resolution{}, store.ErrNotFound
+	}
+
+	return resolutions[0], nil
+}
+
+// Resolutions implements store.Store.
+func (s *Store) Resolutions(ctx context.Context, sid steamid.SteamID) ([]store.Resolution, error) {
+	return s.resolutions(ctx, `SELECT query, steam64, first_seen, last_seen FROM steamid_resolutions
+		WHERE steam64 = ? ORDER BY first_seen, rowid`, sid)
+}
+
+// AddAlias implements store.Store.
+func (s *Store) AddAlias(ctx context.Context, sid steamid.SteamID, name string, seen time.Time) error {
+	if !sid.Valid() || name == "" {
+		return nil
+	}
+
+	return s.exec(ctx, `INSERT INTO steamid_aliases (steam64, name, first_seen, last_seen) VALUES (?, ?, ?, ?)
+		ON CONFLICT (steam64, name) DO UPDATE SET first_seen = min(first_seen, excluded.first_seen),
+		last_seen = max(last_seen, excluded.last_seen)`, sid, name, seen.Unix(), seen.Unix())
+}
+
+// Aliases implements store.Store.
+func (s *Store) Aliases(ctx context.Context, sid steamid.SteamID) ([]extra.Alias, error) {
+	rows, errQuery := s.db.QueryContext(ctx, `SELECT name, first_seen, last_seen FROM steamid_aliases
+		WHERE steam64 = ? ORDER BY first_seen, rowid`, sid)
+	if errQuery != nil {
+		return nil, errors.Join(errQuery, store.ErrStore)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var aliases []extra.Alias
+
+	for rows.Next() {
+		var (
+			alias     extra.Alias
+			firstSeen int64
+			lastSeen  int64
+		)
+
+		if errScan := rows.Scan(&alias.Name, &firstSeen, &lastSeen); errScan != nil {
+			return nil, errors.Join(errScan, store.ErrStore)
+		}
+
+		alias.FirstSeen = time.Unix(firstSeen, 0)
+		alias.LastSeen = time.Unix(lastSeen, 0)
+		aliases = append(aliases, alias)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errors.Join(errRows, store.ErrStore)
+	}
+
+	return aliases, nil
+}
+
+// SteamIDs implements store.Store.
+func (s *Store) SteamIDs(ctx context.Context, name string) (steamid.Collection, error) {
+	rows, errQuery := s.db.QueryContext(ctx, `SELECT steam64 FROM steamid_aliases WHERE name = ? ORDER BY steam64`, name)
+	if errQuery != nil {
+		return nil, errors.Join(errQuery, store.ErrStore)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var steamIDs steamid.Collection
+
+	for rows.Next() {
+		var sid steamid.SteamID
+		if errScan := rows.Scan(&sid); errScan != nil {
+			return nil, errors.Join(errScan, store.ErrStore)
+		}
+
+		steamIDs = append(steamIDs, sid)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errors.Join(errRows, store.ErrStore)
+	}
+
+	return steamIDs, nil
+}
+
+// Close implements store.Store.
+func (s *Store) Close() error {
+	if errClose := s.db.Close(); errClose != nil {
+		return errors.Join(errClose, store.ErrStore)
+	}
+
+	return nil
+}
+
+// exec performs a statement.
+func (s *Store) exec(ctx context.Context, query string, args ...any) error {
+	if _, errExec := s.db.ExecContext(ctx, query, args...); errExec != nil {
+		return errors.Join(errExec, store.ErrStore)
+	}
+
+	return nil
+}
+
+// resolutions performs a query of the resolutions table.
+func (s *Store) resolutions(ctx context.Context, query string, args ...any) ([]store.Resolution, error) {
+	rows, errQuery := s.db.QueryContext(ctx, query, args...)
+	if errQuery != nil {
+		return nil, errors.Join(errQuery, store.ErrStore)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var resolutions []store.Resolution
+
+	for rows.Next() {
+		var (
+			resolution store.Resolution
+			firstSeen  int64
+			lastSeen   int64
+		)
+
+		if errScan := rows.Scan(&resolution.Query, &resolution.SteamID, &firstSeen, &lastSeen); errScan != nil {
+			return nil, errors.Join(errScan, store.ErrStore)
+		}
+
+		resolution.FirstSeen = time.Unix(firstSeen, 0)
+		resolution.LastSeen = time.Unix(lastSeen, 0)
+		resolutions = append(resolutions, resolution)
+	}
+
+	if errRows := rows.Err(); errRows != nil {
+		return nil, errors.Join(errRows, store.ErrStore)
+	}
+
+	return resolutions, nil
+}
@@ -0,0 +1,72 @@
+package sqlitestore_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra/store"
+	"github.com/leighmacdonald/steamid/v4/extra/store/sqlitestore"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		path  = filepath.Join(t.TempDir(), "steamid.db")
+		sid   = steamid.New(76561198132612090)
+		other = steamid.New(76561197960287930)
+		first = time.Unix(1700000000, 0)
+		later = first.Add(time.Hour)
+	)
+
+	db, errOpen := sqlitestore.Open(ctx, path)
+	require.NoError(t, errOpen)
+
+	require.NoError(t, db.AddResolution(ctx, "SQUIRRELLY", sid, first))
+	require.NoError(t, db.AddResolution(ctx, "SQUIRRELLY", sid, later))
+	require.NoError(t, db.AddResolution(ctx, "dulahan", sid, later))
+	require.NoError(t, db.AddResolution(ctx, "", sid, later))
+	require.NoError(t, db.AddResolution(ctx, "invalid", steamid.SteamID{}, later))
+	require.NoError(t, db.AddAlias(ctx, sid, "Dulahan", later))
+	require.NoError(t, db.AddAlias(ctx, sid, "Dula", first))
+	require.NoError(t, db.AddAlias(ctx, other, "Dula", later))
+	require.NoError(t, db.AddAlias(ctx, other, "", later))
+	require.NoError(t, db.Close())
+
+	// The mappings are kept when the database is opened again.
+	db, errOpen = sqlitestore.Open(ctx, path)
+	require.NoError(t, errOpen)
+
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	resolution, errResolution := db.Resolution(ctx, "SQUIRRELLY")
+	require.NoError(t, errResolution)
+	require.Equal(t, store.Resolution{Query: "SQUIRRELLY", SteamID: sid, FirstSeen: first, LastSeen: later}, resolution)
+
+	_, errMissing := db.Resolution(ctx, "invalid")
+	require.ErrorIs(t, errMissing, store.ErrNotFound)
+
+	resolutions, errResolutions := db.Resolutions(ctx, sid)
+	require.NoError(t, errResolutions)
+	require.Len(t, resolutions, 2)
+	require.Equal(t, "SQUIRRELLY", resolutions[0].Query)
+	require.Equal(t, "dulahan", resolutions[1].Query)
+
+	aliases, errAliases := db.Aliases(ctx, sid)
+	require.NoError(t, errAliases)
+	require.Len(t, aliases, 2)
+	require.Equal(t, "Dula", aliases[0].Name)
+	require.Equal(t, first, aliases[0].FirstSeen)
+	require.Equal(t, "Dulahan", aliases[1].Name)
+
+	steamIDs, errSteamIDs := db.SteamIDs(ctx, "Dula")
+	require.NoError(t, errSteamIDs)
+	require.Equal(t, steamid.Collection{other, sid}, steamIDs)
+}
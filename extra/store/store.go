@@ -0,0 +1,169 @@
+// Package store defines a persistence layer for the mappings between steam ids, the vanity names
+// and profile urls they were resolved from, and the persona names they have used. It is used by
+// the watchlist package and the CLI, so applications can share one schema instead of inventing
+// their own.
+//
+// MemoryStore is a Store that is lost when the process exits. The sqlitestore package provides a
+// Store persisted in a SQLite database.
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+var (
+	// ErrNotFound is returned by Store.Resolution when the query has not been resolved before.
+	ErrNotFound = errors.New("mapping not found")
+	// ErrStore is returned when the underlying storage fails.
+	ErrStore = errors.New("failed to access store")
+)
+
+// Resolution records that a query, such as a vanity name or profile url, resolved to a steam id.
+// Repeated resolutions to the same id extend the period it was seen over, so the history of a
+// query shows each id it has pointed to.
+type Resolution struct {
+	Query     string
+	SteamID   steamid.SteamID
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// Store persists resolutions and persona name aliases. Implementations are safe for concurrent
+// use. Invalid steam ids and empty queries or names are ignored when adding.
+type Store interface {
+	// AddResolution records that the query resolved to the steam id at the time seen.
+	AddResolution(ctx context.Context, query string, sid steamid.SteamID, seen time.Time) error
+	// Resolution returns the most recently seen resolution of the query, or ErrNotFound.
+	Resolution(ctx context.Context, query string) (Resolution, error)
+	// Resolutions returns the queries that resolved to the steam id, ordered by when they were
+	// first seen, e.g. the vanity names an account has used.
+	Resolutions(ctx context.Context, sid steamid.SteamID) ([]Resolution, error)
+	// AddAlias records that the steam id was seen using the name at the time seen.
+	AddAlias(ctx context.Context, sid steamid.SteamID, name string, seen time.Time) error
+	// Aliases returns the names the steam id has used, ordered by when they were first seen.
+	Aliases(ctx context.Context, sid steamid.SteamID) ([]extra.Alias, error)
+	// SteamIDs returns the steam ids that have used the name.
+	SteamIDs(ctx context.Context, name string) (steamid.Collection, error)
+	// Close releases the resources of the store.
+	Close() error
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore is a Store held in memory.
+type MemoryStore struct {
+	aliases *extra.AliasTable
+
+	mu          sync.Mutex
+	resolutions []Resolution
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{aliases: extra.NewAliasTable()}
+}
+
+// AddResolution implements Store.
+func (m *MemoryStore) AddResolution(_ context.Context, query string, sid steamid.SteamID, seen time.Time) error {
+	if !sid.Valid() || strings.TrimSpace(query) == "" {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for idx := range m.resolutions {
+		resolution := &m.resolutions[idx]
+		if resolution.Query != query || resolution.SteamID != sid {
+			continue
+		}
+
+		if seen.Before(resolution.FirstSeen) {
+			resolution.FirstSeen = seen
+		}
+
+		if seen.After(resolution.LastSeen) {
+			resolution.LastSeen = seen
+		}
+
+		return nil
+	}
+
+	m.resolutions = append(m.resolutions, Resolution{Query: query, SteamID: sid, FirstSeen: seen, LastSeen: seen})
+
+	return nil
+}
+
+// Resolution implements Store.
+func (m *MemoryStore) Resolution(_ context.Context, query string) (Resolution, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var (
+		latest Resolution
+		found  bool
+	)
+
+	for _, resolution := range m.resolutions {
+		if resolution.Query == query && (!found || resolution.LastSeen.After(latest.LastSeen)) {
+			latest = resolution
+			found = true
+		}
+	}
+
+	if !found {
+		return Resolution{}, ErrNotFound
+	}
+
+	return latest, nil
+}
+
+// Resolutions implements Store.
+func (m *MemoryStore) Resolutions(_ context.Context, sid steamid.SteamID) ([]Resolution, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var resolutions []Resolution
+
+	for _, resolution := range m.resolutions {
+		if resolution.SteamID == sid {
+			resolutions = append(resolutions, resolution)
+		}
+	}
+
+	slices.SortStableFunc(resolutions, func(a, b Resolution) int {
+		return a.FirstSeen.Compare(b.FirstSeen)
+	})
+
+	return resolutions, nil
+}
+
+// AddAlias implements Store.
+func (m *MemoryStore) AddAlias(_ context.Context, sid steamid.SteamID, name string, seen time.Time) error {
+	m.aliases.Add(sid, name, seen)
+
+	return nil
+}
+
+// Aliases implements Store.
+func (m *MemoryStore) Aliases(_ context.Context, sid steamid.SteamID) ([]extra.Alias, error) {
+	return m.aliases.Aliases(sid), nil
+}
+
+// SteamIDs implements Store.
+func (m *MemoryStore) SteamIDs(_ context.Context, name string) (steamid.Collection, error) {
+	return m.aliases.SteamIDs(name), nil
+}
+
+// Close implements Store.
+func (m *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra/store"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx   = context.Background()
+		db    = store.NewMemoryStore()
+		sid   = steamid.New(76561198132612090)
+		other = steamid.New(76561197960287930)
+		first = time.Unix(1700000000, 0)
+		later = first.Add(time.Hour)
+	)
+
+	require.NoError(t, db.AddResolution(ctx, "SQUIRRELLY", sid, later))
+	require.NoError(t, db.AddResolution(ctx, "SQUIRRELLY", sid, first))
+	require.NoError(t, db.AddResolution(ctx, "SQUIRRELLY", other, first))
+	require.NoError(t, db.AddResolution(ctx, "dulahan", sid, later))
+	require.NoError(t, db.AddResolution(ctx, "invalid", steamid.SteamID{}, later))
+
+	resolution, errResolution := db.Resolution(ctx, "SQUIRRELLY")
+	require.NoError(t, errResolution)
+	require.Equal(t, store.Resolution{Query: "SQUIRRELLY", SteamID: sid, FirstSeen: first, LastSeen: later}, resolution)
+
+	_, errMissing := db.Resolution(ctx, "invalid")
+	require.ErrorIs(t, errMissing, store.ErrNotFound)
+
+	resolutions, errResolutions := db.Resolutions(ctx, sid)
+	require.NoError(t, errResolutions)
+	require.Len(t, resolutions, 2)
+	require.Equal(t, "SQUIRRELLY", resolutions[0].Query)
+
+	require.NoError(t, db.AddAlias(ctx, sid, "Dulahan", later))
+	require.NoError(t, db.AddAlias(ctx, sid, "Dula", first))
+	require.NoError(t, db.AddAlias(ctx, other, "Dula", later))
+
+	aliases, errAliases := db.Aliases(ctx, sid)
+	require.NoError(t, errAliases)
+	require.Len(t, aliases, 2)
+	require.Equal(t, "Dula", aliases[0].Name)
+
+	steamIDs, errSteamIDs := db.SteamIDs(ctx, "Dula")
+	require.NoError(t, errSteamIDs)
+	require.Equal(t, steamid.Collection{other, sid}, steamIDs)
+	require.NoError(t, db.Close())
+}
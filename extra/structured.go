@@ -0,0 +1,171 @@
+package extra
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+var (
+	ErrReadCSV       = errors.New("failed to read csv")
+	ErrDecodeJSON    = errors.New("failed to decode json")
+	ErrUnknownColumn = errors.New("unknown csv column")
+)
+
+// FieldMatch is a steam id found within a structured document.
+type FieldMatch struct {
+	SteamID steamid.SteamID
+	// Path identifies the field the id was found in. For CSV input this takes the form `[row].column`
+	// where row is the 0-indexed data row, excluding the header. For JSON it is the path
+	// to the value, e.g. `players[2].steamid`.
+	Path string
+	// Text is the original text the id was parsed from.
+	Text string
+}
+
+func isDigits(value string) bool {
+	return value != "" && strings.IndexFunc(value, func(r rune) bool {
+		return r < '0' || r > '9'
+	}) == -1
+}
+
+// findValueSteamIDs returns the steam ids within a single value. If the entire value is an
+// id it is used directly, otherwise the value is searched like a line of free text.
+//
+// Plain 32bit account ids are only accepted when allowAccountID is true, as any small
+// number would otherwise be treated as a steam id.
+func findValueSteamIDs(value string, allowAccountID bool) []steamid.SteamID {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	if allowAccountID || !isDigits(value) || len(value) >= 17 {
+		if sid := steamid.New(value); scannable(sid) {
+			return []steamid.SteamID{sid}
+		}
+	}
+
+	return appendMatches(nil, value)
+}
+
+// FindCSVSteamIDs reads a CSV document and returns every steam id found within it. The first
+// record is treated as the header. When columns are provided, only the columns with
+// those header names are searched, otherwise all columns are.
+//
+// Values in the selected columns may also be plain 32bit account ids, which are not
+// detected when searching every column.
+func FindCSVSteamIDs(reader io.Reader, columns ...string) ([]FieldMatch, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+
+	header, errHeader := csvReader.Read()
+	if errHeader != nil {
+		if errors.Is(errHeader, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, errors.Join(errHeader, ErrReadCSV)
+	}
+
+	for _, column := range columns {
+		if !slices.Contains(header, column) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, column)
+		}
+	}
+
+	var matches []FieldMatch
+
+	for row := 0; ; row++ {
+		record, errRecord := csvReader.Read()
+		if errRecord != nil {
+			if errors.Is(errRecord, io.EOF) {
+				break
+			}
+
+			return matches, errors.Join(errRecord, ErrReadCSV)
+		}
+
+		for idx, value := range record {
+			name := strconv.Itoa(idx)
+			if idx < len(header) {
+				name = header[idx]
+			}
+
+			if len(columns) > 0 && !slices.Contains(columns, name) {
+				continue
+			}
+
+			for _, sid := range findValueSteamIDs(value, len(columns) > 0) {
+				matches = append(matches, FieldMatch{
+					SteamID: sid,
+					Path:    fmt.Sprintf("[%d].%s", row, name),
+					Text:    value,
+				})
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// FindJSONSteamIDs decodes an arbitrary JSON document and returns every steam id found within its
+// values and object keys. Numbers are decoded without loss of precision so unquoted
+// steam64 values are supported. Object members are visited in key order.
+func FindJSONSteamIDs(reader io.Reader) ([]FieldMatch, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+
+	var document any
+	if errDecode := decoder.Decode(&document); errDecode != nil {
+		return nil, errors.Join(errDecode, ErrDecodeJSON)
+	}
+
+	return walkJSON(nil, "", document), nil
+}
+
+func walkJSON(matches []FieldMatch, path string, value any) []FieldMatch {
+	switch typed := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+
+			for _, sid := range findValueSteamIDs(key, false) {
+				matches = append(matches, FieldMatch{SteamID: sid, Path: childPath, Text: key})
+			}
+
+			matches = walkJSON(matches, childPath, typed[key])
+		}
+	case []any:
+		for idx, child := range typed {
+			matches = walkJSON(matches, fmt.Sprintf("%s[%d]", path, idx), child)
+		}
+	case string:
+		for _, sid := range findValueSteamIDs(typed, false) {
+			matches = append(matches, FieldMatch{SteamID: sid, Path: path, Text: typed})
+		}
+	case json.Number:
+		for _, sid := range findValueSteamIDs(typed.String(), false) {
+			matches = append(matches, FieldMatch{SteamID: sid, Path: path, Text: typed.String()})
+		}
+	}
+
+	return matches
+}
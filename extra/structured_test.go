@@ -0,0 +1,66 @@
+package extra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCSVSteamIDs(t *testing.T) {
+	t.Parallel()
+
+	body := `name,id,notes,count
+player1,172346362,"alt of STEAM_0:1:61934148",5
+player2,[U:1:166779318],,10
+`
+
+	all, err := extra.FindCSVSteamIDs(strings.NewReader(body))
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, "[0].notes", all[0].Path)
+	require.Equal(t, steamid.New(76561198084134025), all[0].SteamID)
+	require.Equal(t, "[1].id", all[1].Path)
+
+	selected, errSelected := extra.FindCSVSteamIDs(strings.NewReader(body), "id")
+	require.NoError(t, errSelected)
+	require.Len(t, selected, 2)
+	require.Equal(t, "[0].id", selected[0].Path)
+	require.Equal(t, "172346362", selected[0].Text)
+	require.Equal(t, steamid.New(76561198132612090), selected[0].SteamID)
+
+	_, errColumn := extra.FindCSVSteamIDs(strings.NewReader(body), "steamid")
+	require.ErrorIs(t, errColumn, extra.ErrUnknownColumn)
+}
+
+func TestFindJSONSteamIDs(t *testing.T) {
+	t.Parallel()
+
+	body := `{
+	"server": {"steamid": "[G:1:3414356]", "players": 2},
+	"players": [
+		{"name": "a", "steamid": 76561198132612090},
+		{"name": "b", "steamid": "STEAM_0:1:61934148", "profile": "https://steamcommunity.com/profiles/76561198084134025"}
+	],
+	"76561197970669109": {"banned": true}
+}`
+
+	matches, err := extra.FindJSONSteamIDs(strings.NewReader(body))
+	require.NoError(t, err)
+
+	var paths []string
+	for _, match := range matches {
+		paths = append(paths, match.Path)
+	}
+
+	require.Equal(t, []string{
+		"76561197970669109", "players[0].steamid", "players[1].profile", "players[1].steamid", "server.steamid",
+	}, paths)
+	require.Equal(t, steamid.New(76561198132612090), matches[1].SteamID)
+	require.Equal(t, steamid.New("[G:1:3414356]"), matches[4].SteamID)
+
+	_, errDecode := extra.FindJSONSteamIDs(strings.NewReader("{"))
+	require.ErrorIs(t, errDecode, extra.ErrDecodeJSON)
+}
@@ -0,0 +1,124 @@
+package extra
+
+import (
+	"sync"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// StatusEventType describes the kind of change a StatusEvent represents.
+type StatusEventType int
+
+const (
+	PlayerJoined StatusEventType = iota + 1
+	PlayerLeft
+	NameChanged
+	PingSpike
+)
+
+func (e StatusEventType) String() string {
+	switch e {
+	case PlayerJoined:
+		return "Player Joined"
+	case PlayerLeft:
+		return "Player Left"
+	case NameChanged:
+		return "Name Changed"
+	case PingSpike:
+		return "Ping Spike"
+	default:
+		return "Unknown"
+	}
+}
+
+// StatusEvent is a change between two successive status snapshots.
+type StatusEvent struct {
+	Type StatusEventType
+	SID  steamid.SteamID
+	// Player is the current state of the player. For PlayerLeft events it is the last known state.
+	Player Player
+	// Previous is the prior state of the player for NameChanged and PingSpike events.
+	Previous Player
+}
+
+// StatusTracker diffs successive ParseStatus results into join, leave, name change and ping
+// spike events keyed by steam id. It is safe for concurrent use.
+type StatusTracker struct {
+	mu      sync.Mutex
+	players []Player
+	// pingSpike is the increase in ping between two updates that triggers a PingSpike event.
+	pingSpike int
+}
+
+// NewStatusTracker returns a tracker with no known players. A PingSpike event is emitted when a players
+// ping increases by at least pingSpike between updates. A value of 0 disables ping spike events.
+func NewStatusTracker(pingSpike int) *StatusTracker {
+	return &StatusTracker{pingSpike: pingSpike}
+}
+
+// Update records a new status snapshot and returns the events that occurred since the previous
+// one. As the tracker starts empty, every player in the first snapshot generates a PlayerJoined
+// event. Players without a valid steam id are ignored.
+//
+// Events are ordered with the changes to current players first, in the order they appear in the
+// status, followed by the players that left.
+func (t *StatusTracker) Update(status Status) []StatusEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous := make(map[steamid.SteamID]Player, len(t.players))
+	for _, player := range t.players {
+		previous[player.SID] = player
+	}
+
+	var (
+		events  []StatusEvent
+		current []Player
+		seen    = map[steamid.SteamID]bool{}
+	)
+
+	for _, player := range status.Players {
+		if !player.SID.Valid() || seen[player.SID] {
+			continue
+		}
+
+		seen[player.SID] = true
+		current = append(current, player)
+
+		prev, found := previous[player.SID]
+		if !found {
+			events = append(events, StatusEvent{Type: PlayerJoined, SID: player.SID, Player: player})
+
+			continue
+		}
+
+		if prev.Name != player.Name {
+			events = append(events, StatusEvent{Type: NameChanged, SID: player.SID, Player: player, Previous: prev})
+		}
+
+		if t.pingSpike > 0 && player.Ping-prev.Ping >= t.pingSpike {
+			events = append(events, StatusEvent{Type: PingSpike, SID: player.SID, Player: player, Previous: prev})
+		}
+	}
+
+	for _, player := range t.players {
+		if !seen[player.SID] {
+			events = append(events, StatusEvent{Type: PlayerLeft, SID: player.SID, Player: player})
+		}
+	}
+
+	t.players = current
+
+	return events
+}
+
+// Players returns the players from the most recent update.
+func (t *StatusTracker) Players() []Player {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	players := make([]Player, len(t.players))
+	copy(players, t.players)
+
+	return players
+}
@@ -0,0 +1,50 @@
+package extra_test
+
+import (
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusTracker(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sidA    = steamid.New("[U:1:148883280]")
+		sidB    = steamid.New("[U:1:186134686]")
+		sidC    = steamid.New("[U:1:64274886]")
+		tracker = extra.NewStatusTracker(100)
+	)
+
+	first := tracker.Update(extra.Status{Players: []extra.Player{
+		{Name: "Dulahan", SID: sidA, Ping: 50},
+		{Name: "Nox", SID: sidB, Ping: 60},
+		{Name: "no sid", Ping: 60},
+	}})
+	require.Len(t, first, 2)
+	require.Equal(t, extra.PlayerJoined, first[0].Type)
+	require.Equal(t, sidA, first[0].SID)
+	require.Equal(t, sidB, first[1].SID)
+
+	second := tracker.Update(extra.Status{Players: []extra.Player{
+		{Name: "Dulahan2", SID: sidA, Ping: 200},
+		{Name: "George Scrumpus", SID: sidC, Ping: 60},
+	}})
+
+	var types []extra.StatusEventType
+	for _, event := range second {
+		types = append(types, event.Type)
+	}
+
+	require.Equal(t, []extra.StatusEventType{extra.NameChanged, extra.PingSpike, extra.PlayerJoined, extra.PlayerLeft}, types)
+	require.Equal(t, "Dulahan", second[0].Previous.Name)
+	require.Equal(t, "Dulahan2", second[0].Player.Name)
+	require.Equal(t, sidC, second[2].SID)
+	require.Equal(t, sidB, second[3].SID)
+	require.Equal(t, "Nox", second[3].Player.Name)
+
+	require.Empty(t, tracker.Update(extra.Status{Players: tracker.Players()}))
+	require.Equal(t, "Player Left", extra.PlayerLeft.String())
+}
@@ -0,0 +1,375 @@
+package extra
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// vdfMaxDepth limits the nesting of VDF objects so malformed input can not exhaust the stack.
+const vdfMaxDepth = 64
+
+var (
+	ErrParseVDF    = errors.New("failed to parse vdf")
+	ErrReadVDF     = errors.New("failed to read vdf")
+	ErrVDFAccounts = errors.New("not a loginusers.vdf or config.vdf file")
+)
+
+// VDFNode is a key of a Valve KeyValues (VDF) text file, such as loginusers.vdf, config.vdf or
+// the appmanifest_*.acf files of installed apps. A node holds either a string Value, or the
+// Children of an object.
+type VDFNode struct {
+	Key      string
+	Value    string
+	Children []VDFNode
+}
+
+// Child returns the first child with the key. Keys are compared case-insensitively, as the steam
+// client does.
+func (n VDFNode) Child(key string) (VDFNode, bool) {
+	for _, child := range n.Children {
+		if strings.EqualFold(child.Key, key) {
+			return child, true
+		}
+	}
+
+	return VDFNode{}, false
+}
+
+// Path returns the node found by following the keys from n, e.g.
+// root.Path("InstallConfigStore", "Software", "Valve", "Steam", "Accounts").
+func (n VDFNode) Path(keys ...string) (VDFNode, bool) {
+	node := n
+
+	for _, key := range keys {
+		child, found := node.Child(key)
+		if !found {
+			return VDFNode{}, false
+		}
+
+		node = child
+	}
+
+	return node, true
+}
+
+// SteamIDs returns the unique SID64 formatted steam ids used as a key or value anywhere within the
+// node, in the order they are first found. e.g. the users of loginusers.vdf or the LastOwner of
+// an appmanifest_*.acf file.
+func (n VDFNode) SteamIDs() steamid.Collection {
+	var (
+		found []steamid.SteamID
+		seen  = map[uint64]bool{}
+	)
+
+	var walk func(node VDFNode)
+	walk = func(node VDFNode) {
+		for _, text := range []string{node.Key, node.Value} {
+			sid, isSID := vdfSteamID(text)
+			if isSID && !seen[sid.Uint64()] {
+				seen[sid.Uint64()] = true
+				found = append(found, sid)
+			}
+		}
+
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+
+	walk(n)
+
+	return found
+}
+
+// vdfSteamID parses SID64 formatted text. Only 17 digit values are accepted so that other numbers
+// in the file, such as timestamps and app ids, are not mistaken for account ids.
+func vdfSteamID(text string) (steamid.SteamID, bool) {
+	const sid64Len = 17
+
+	if len(text) != sid64Len {
+		return steamid.SteamID{}, false
+	}
+
+	value, errParse := strconv.ParseUint(text, 10, 64)
+	if errParse != nil {
+		return steamid.SteamID{}, false
+	}
+
+	sid := steamid.New(value)
+
+	return sid, sid.Valid()
+}
+
+// LocalAccount is a steam account that has signed in to the steam client on a machine.
+type LocalAccount struct {
+	SteamID     steamid.SteamID
+	AccountName string
+	// PersonaName, MostRecent, RememberPassword and Timestamp are only known when reading
+	// loginusers.vdf.
+	PersonaName string
+	// MostRecent is set for the account the client last signed in with.
+	MostRecent       bool
+	RememberPassword bool
+	// Timestamp is the time the account last signed in.
+	Timestamp time.Time
+}
+
+// ReadLocalAccounts returns the accounts listed in a steam client loginusers.vdf or config.vdf
+// file, both of which are found in the config directory of the steam installation. Accounts
+// without a valid steam id are skipped, and ErrVDFAccounts is returned for other files.
+func ReadLocalAccounts(reader io.Reader) ([]LocalAccount, error) {
+	root, errParse := ParseVDF(reader)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	if users, found := root.Child("users"); found {
+		return loginUsers(users), nil
+	}
+
+	if accounts, found := root.Path("InstallConfigStore", "Software", "Valve", "Steam", "Accounts"); found {
+		return configAccounts(accounts), nil
+	}
+
+	return nil, ErrVDFAccounts
+}
+
+// loginUsers reads the accounts of loginusers.vdf, which are keyed by their SID64.
+func loginUsers(users VDFNode) []LocalAccount {
+	var accounts []LocalAccount
+
+	for _, user := range users.Children {
+		sid, isSID := vdfSteamID(user.Key)
+		if !isSID {
+			continue
+		}
+
+		account := LocalAccount{SteamID: sid}
+
+		for _, field := range user.Children {
+			switch strings.ToLower(field.Key) {
+			case "accountname":
+				account.AccountName = field.Value
+			case "personaname":
+				account.PersonaName = field.Value
+			case "mostrecent":
+				account.MostRecent = field.Value == "1"
+			case "rememberpassword":
+				account.RememberPassword = field.Value == "1"
+			case "timestamp":
+				if unix, errUnix := strconv.ParseInt(field.Value, 10, 64); errUnix == nil && unix > 0 {
+					account.Timestamp = time.Unix(unix, 0)
+				}
+			}
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts
+}
+
+// configAccounts reads the accounts of config.vdf, which are keyed by their account name.
+func configAccounts(node VDFNode) []LocalAccount {
+	var accounts []LocalAccount
+
+	for _, account := range node.Children {
+		field, found := account.Child("SteamID")
+		if !found {
+			continue
+		}
+
+		sid, isSID := vdfSteamID(field.Value)
+		if !isSID {
+			continue
+		}
+
+		accounts = append(accounts, LocalAccount{SteamID: sid, AccountName: account.Key})
+	}
+
+	return accounts
+}
+
+// ParseVDF parses a KeyValues text file, returning a root node whose children are the top level
+// keys of the file. Quoted and unquoted tokens, escape sequences, // comments and platform
+// conditionals such as [$WIN32] are supported. Syntax errors are returned as a LineError.
+func ParseVDF(reader io.Reader) (VDFNode, error) {
+	data, errRead := io.ReadAll(reader)
+	if errRead != nil {
+		return VDFNode{}, errors.Join(errRead, ErrReadVDF)
+	}
+
+	parser := vdfParser{input: string(data), line: 1}
+
+	children, errParse := parser.object(0)
+	if errParse != nil {
+		return VDFNode{}, errParse
+	}
+
+	return VDFNode{Children: children}, nil
+}
+
+type vdfParser struct {
+	input string
+	pos   int
+	line  int
+}
+
+// object reads key value pairs until the closing brace of the object, or the end of the input
+// for the top level.
+func (p *vdfParser) object(depth int) ([]VDFNode, error) {
+	if depth > vdfMaxDepth {
+		return nil, p.error("", "too deeply nested")
+	}
+
+	var nodes []VDFNode
+
+	for {
+		key, quoted, errKey := p.token()
+		if errKey != nil {
+			return nil, errKey
+		}
+
+		switch {
+		case key == "" && !quoted && p.pos >= len(p.input):
+			if depth > 0 {
+				return nil, p.error("", "unexpected end of input")
+			}
+
+			return nodes, nil
+		case key == "}" && !quoted:
+			if depth == 0 {
+				return nil, p.error(key, "unexpected closing brace")
+			}
+
+			return nodes, nil
+		case key == "{" && !quoted:
+			return nil, p.error(key, "missing key")
+		}
+
+		value, valueQuoted, errValue := p.token()
+		if errValue != nil {
+			return nil, errValue
+		}
+
+		switch {
+		case value == "{" && !valueQuoted:
+			children, errChildren := p.object(depth + 1)
+			if errChildren != nil {
+				return nil, errChildren
+			}
+
+			nodes = append(nodes, VDFNode{Key: key, Children: children})
+		case value == "}" && !valueQuoted, value == "" && !valueQuoted && p.pos >= len(p.input):
+			return nil, p.error(key, "missing value")
+		default:
+			nodes = append(nodes, VDFNode{Key: key, Value: value})
+		}
+
+		p.skipConditional()
+	}
+}
+
+// token returns the next token, skipping whitespace and comments. An empty unquoted token is
+// returned at the end of the input.
+func (p *vdfParser) token() (string, bool, error) {
+	p.skipSpace()
+
+	if p.pos >= len(p.input) {
+		return "", false, nil
+	}
+
+	switch char := p.input[p.pos]; char {
+	case '{', '}':
+		p.pos++
+
+		return string(char), false, nil
+	case '"':
+		return p.quoted()
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(" \t\r\n{}\"", rune(p.input[p.pos])) {
+		p.pos++
+	}
+
+	return p.input[start:p.pos], false, nil
+}
+
+// quoted reads a quoted token, replacing the escape sequences used by the steam client.
+func (p *vdfParser) quoted() (string, bool, error) {
+	var (
+		builder strings.Builder
+		line    = p.line
+	)
+
+	for p.pos++; p.pos < len(p.input); p.pos++ {
+		char := p.input[p.pos]
+
+		switch {
+		case char == '"':
+			p.pos++
+
+			return builder.String(), true, nil
+		case char == '\\' && p.pos+1 < len(p.input):
+			p.pos++
+
+			switch escaped := p.input[p.pos]; escaped {
+			case 'n':
+				builder.WriteByte('\n')
+			case 't':
+				builder.WriteByte('\t')
+			default:
+				builder.WriteByte(escaped)
+			}
+		default:
+			if char == '\n' {
+				p.line++
+			}
+
+			builder.WriteByte(char)
+		}
+	}
+
+	return "", false, LineError{Line: line, Text: builder.String(), Err: fmt.Errorf("%w: unterminated string", ErrParseVDF)}
+}
+
+// skipSpace skips whitespace and // comments, counting lines.
+func (p *vdfParser) skipSpace() {
+	for p.pos < len(p.input) {
+		switch {
+		case p.input[p.pos] == '\n':
+			p.line++
+			p.pos++
+		case p.input[p.pos] == ' ', p.input[p.pos] == '\t', p.input[p.pos] == '\r':
+			p.pos++
+		case strings.HasPrefix(p.input[p.pos:], "//"):
+			for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// skipConditional skips a platform conditional following a value, e.g. "key" "value" [$WIN32].
+func (p *vdfParser) skipConditional() {
+	p.skipSpace()
+
+	if p.pos < len(p.input) && p.input[p.pos] == '[' {
+		if end := strings.IndexAny(p.input[p.pos:], "]\n"); end >= 0 && p.input[p.pos+end] == ']' {
+			p.pos += end + 1
+		}
+	}
+}
+
+func (p *vdfParser) error(text string, reason string) error {
+	return LineError{Line: p.line, Text: text, Err: fmt.Errorf("%w: %s", ErrParseVDF, reason)}
+}
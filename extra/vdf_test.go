@@ -0,0 +1,115 @@
+package extra_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+const testLoginUsers = `"users"
+{
+	"76561198132612090"
+	{
+		"AccountName"		"dulahan"
+		"PersonaName"		"Dula \"han\""
+		"RememberPassword"		"1"
+		"WantsOfflineMode"		"0"
+		"MostRecent"		"1"
+		"Timestamp"		"1700000000"
+	}
+	// Removed accounts keep their entry.
+	"76561197960287930"
+	{
+		"AccountName"		"gabe"
+		"PersonaName"		"Rabscuttle"
+		"mostrecent"		"0"
+	}
+}
+`
+
+const testConfig = `"InstallConfigStore"
+{
+	"Software"
+	{
+		"Valve"
+		{
+			"Steam"
+			{
+				"AutoUpdateWindowEnabled"		"0" [$WIN32]
+				"Accounts"
+				{
+					"dulahan"
+					{
+						"SteamID"		"76561198132612090"
+					}
+					"broken"
+					{
+						"SteamID"		"0"
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+func TestReadLocalAccounts(t *testing.T) {
+	t.Parallel()
+
+	accounts, errAccounts := extra.ReadLocalAccounts(strings.NewReader(testLoginUsers))
+	require.NoError(t, errAccounts)
+	require.Equal(t, []extra.LocalAccount{
+		{
+			SteamID:          steamid.New(76561198132612090),
+			AccountName:      "dulahan",
+			PersonaName:      `Dula "han"`,
+			MostRecent:       true,
+			RememberPassword: true,
+			Timestamp:        time.Unix(1700000000, 0),
+		},
+		{SteamID: steamid.New(76561197960287930), AccountName: "gabe", PersonaName: "Rabscuttle"},
+	}, accounts)
+
+	config, errConfig := extra.ReadLocalAccounts(strings.NewReader(testConfig))
+	require.NoError(t, errConfig)
+	require.Equal(t, []extra.LocalAccount{{SteamID: steamid.New(76561198132612090), AccountName: "dulahan"}}, config)
+
+	_, errOther := extra.ReadLocalAccounts(strings.NewReader(`"AppState" { "appid" "440" }`))
+	require.ErrorIs(t, errOther, extra.ErrVDFAccounts)
+}
+
+func TestParseVDF(t *testing.T) {
+	t.Parallel()
+
+	const manifest = `"AppState"
+{
+	"appid"		"440"
+	"name"		"Team Fortress 2"
+	"LastOwner"		"76561198132612090"
+	"InstalledDepots" { "441" { "manifest" "7707612755043576065" } }
+}`
+
+	root, errParse := extra.ParseVDF(strings.NewReader(manifest))
+	require.NoError(t, errParse)
+
+	name, found := root.Path("appstate", "NAME")
+	require.True(t, found)
+	require.Equal(t, "Team Fortress 2", name.Value)
+	require.Equal(t, steamid.Collection{steamid.New(76561198132612090)}, root.SteamIDs())
+
+	for _, input := range []string{`"a" { "b" "c"`, `"a" "b" }`, `"a"`, `"a" "unterminated`, `{ "a" "b" }`} {
+		_, errInvalid := extra.ParseVDF(strings.NewReader(input))
+		require.ErrorIs(t, errInvalid, extra.ErrParseVDF, input)
+		require.ErrorIs(t, errInvalid, steamid.ErrParse, input)
+	}
+
+	_, errLine := extra.ParseVDF(strings.NewReader("\"a\"\n{\n\t\"b\" }"))
+
+	var lineErr extra.LineError
+	require.ErrorAs(t, errLine, &lineErr)
+	require.Equal(t, 3, lineErr.Line)
+}
@@ -0,0 +1,289 @@
+// Package watchlist monitors the profiles of a set of steam ids for persona name, avatar and
+// visibility changes, recording the names each account has used in an extra.AliasTable and
+// optionally a store.Store.
+//
+//	list := watchlist.New(extra.NewPlayerDataCache(client, time.Minute), steamIDs)
+//	list.Run(ctx, func(event watchlist.Event) {
+//		fmt.Println(event.SID, event.Type, event.Current.PersonaName)
+//	})
+package watchlist
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/extra/store"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// DefaultInterval is the default period at which Run checks the profiles.
+const DefaultInterval = time.Minute * 5
+
+var ErrFetchSummaries = errors.New("failed to fetch player summaries")
+
+// Provider fetches the profile summaries of steam ids. It is implemented by *steamid.Client and
+// *extra.PlayerDataCache.
+type Provider interface {
+	PlayerSummaries(ctx context.Context, steamIDs steamid.Collection) ([]steamid.PlayerSummary, error)
+}
+
+var (
+	_ Provider = (*steamid.Client)(nil)
+	_ Provider = (*extra.PlayerDataCache)(nil)
+)
+
+// EventType describes the kind of change an Event represents.
+type EventType int
+
+const (
+	NameChanged EventType = iota + 1
+	AvatarChanged
+	// VisibilityChanged is emitted when a profile is made public or private.
+	VisibilityChanged
+)
+
+func (e EventType) String() string {
+	switch e {
+	case NameChanged:
+		return "Name Changed"
+	case AvatarChanged:
+		return "Avatar Changed"
+	case VisibilityChanged:
+		return "Visibility Changed"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is a change between two successive profile summaries of a watched player.
+type Event struct {
+	Type     EventType
+	SID      steamid.SteamID
+	Current  steamid.PlayerSummary
+	Previous steamid.PlayerSummary
+}
+
+// Watchlist monitors the profiles of a set of steam ids. It is safe for concurrent use.
+type Watchlist struct {
+	source       Provider
+	aliases      *extra.AliasTable
+	store        store.Store
+	interval     time.Duration
+	errorHandler func(error)
+
+	mu        sync.Mutex
+	steamIDs  steamid.Collection
+	summaries map[steamid.SteamID]steamid.PlayerSummary
+}
+
+// Option configures optional Watchlist settings.
+type Option func(*Watchlist)
+
+// WithAliasTable sets the table the persona names are recorded in, e.g. to combine them with the
+// names seen in chat logs and status output. A new table is used by default.
+func WithAliasTable(table *extra.AliasTable) Option {
+	return func(w *Watchlist) {
+		w.aliases = table
+	}
+}
+
+// WithStore sets a store the persona names are also persisted to, so the alias history outlives
+// the process. Only the alias table is used by default.
+func WithStore(aliasStore store.Store) Option {
+	return func(w *Watchlist) {
+		w.store = aliasStore
+	}
+}
+
+// WithInterval sets the period at which Run checks the profiles, DefaultInterval by default or
+// when not positive.
+func WithInterval(interval time.Duration) Option {
+	return func(w *Watchlist) {
+		w.interval = interval
+	}
+}
+
+// WithErrorHandler sets the function called with the errors of the checks performed by Run, which
+// are otherwise ignored.
+func WithErrorHandler(handler func(error)) Option {
+	return func(w *Watchlist) {
+		w.errorHandler = handler
+	}
+}
+
+// New returns a watchlist for the steam ids, fetching their profiles from the source. Wrap the
+// client with extra.NewPlayerDataCache to share summaries with other lookups.
+func New(source Provider, steamIDs steamid.Collection, opts ...Option) *Watchlist {
+	list := &Watchlist{
+		source:       source,
+		aliases:      extra.NewAliasTable(),
+		interval:     DefaultInterval,
+		errorHandler: func(error) {},
+		summaries:    map[steamid.SteamID]steamid.PlayerSummary{},
+	}
+
+	for _, opt := range opts {
+		opt(list)
+	}
+
+	if list.interval <= 0 {
+		list.interval = DefaultInterval
+	}
+
+	list.Add(steamIDs...)
+
+	return list
+}
+
+// Add starts watching the steam ids. Invalid ids and those already watched are ignored.
+func (w *Watchlist) Add(steamIDs ...steamid.SteamID) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, sid := range steamIDs {
+		if sid.Valid() && !w.steamIDs.Contains(sid) {
+			w.steamIDs = append(w.steamIDs, sid)
+		}
+	}
+}
+
+// Remove stops watching the steam ids. Their alias history is kept.
+func (w *Watchlist) Remove(steamIDs ...steamid.SteamID) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var remaining steamid.Collection
+
+	for _, sid := range w.steamIDs {
+		if !steamid.Collection(steamIDs).Contains(sid) {
+			remaining = append(remaining, sid)
+		}
+	}
+
+	for _, sid := range steamIDs {
+		delete(w.summaries, sid)
+	}
+
+	w.steamIDs = remaining
+}
+
+// SteamIDs returns the watched steam ids, in the order they were added.
+func (w *Watchlist) SteamIDs() steamid.Collection {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append(steamid.Collection(nil), w.steamIDs...)
+}
+
+// Summary returns the most recent profile summary of a watched steam id.
+func (w *Watchlist) Summary(sid steamid.SteamID) (steamid.PlayerSummary, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	summary, found := w.summaries[sid]
+
+	return summary, found
+}
+
+// Aliases returns the persona names the steam id has been seen using, ordered by when they were
+// first seen.
+func (w *Watchlist) Aliases(sid steamid.SteamID) []extra.Alias {
+	return w.aliases.Aliases(sid)
+}
+
+// Check fetches the profiles of the watched ids and returns the changes since the previous check.
+// The first summary of each id is recorded without an event. Profiles which are not returned,
+// e.g. deleted accounts, keep their previous summary. When persisting the persona names to the
+// store fails, the events are returned along with the error.
+func (w *Watchlist) Check(ctx context.Context) ([]Event, error) {
+	summaries, errSummaries := w.source.PlayerSummaries(ctx, w.SteamIDs())
+	if errSummaries != nil {
+		return nil, errors.Join(errSummaries, ErrFetchSummaries)
+	}
+
+	var (
+		events  []Event
+		current []steamid.PlayerSummary
+		now     = time.Now()
+	)
+
+	w.mu.Lock()
+	for _, summary := range summaries {
+		if !w.steamIDs.Contains(summary.SteamID) {
+			continue
+		}
+
+		w.aliases.Add(summary.SteamID, summary.PersonaName, now)
+
+		if previous, found := w.summaries[summary.SteamID]; found {
+			events = append(events, diff(previous, summary)...)
+		}
+
+		w.summaries[summary.SteamID] = summary
+		current = append(current, summary)
+	}
+	w.mu.Unlock()
+
+	if w.store == nil {
+		return events, nil
+	}
+
+	for _, summary := range current {
+		if errAlias := w.store.AddAlias(ctx, summary.SteamID, summary.PersonaName, now); errAlias != nil {
+			return events, errAlias //nolint:wrapcheck
+		}
+	}
+
+	return events, nil
+}
+
+// Run checks the profiles immediately and then once per interval, calling the handler with every
+// event, until the context is cancelled. Check errors are passed to the error handler.
+func (w *Watchlist) Run(ctx context.Context, handler func(Event)) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		events, errCheck := w.Check(ctx)
+		for _, event := range events {
+			handler(event)
+		}
+
+		if errCheck != nil && ctx.Err() == nil {
+			w.errorHandler(errCheck)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// diff returns the events for the changes between two summaries of a profile.
+func diff(previous steamid.PlayerSummary, current steamid.PlayerSummary) []Event {
+	var types []EventType
+
+	if current.PersonaName != previous.PersonaName {
+		types = append(types, NameChanged)
+	}
+
+	if current.AvatarHash != previous.AvatarHash {
+		types = append(types, AvatarChanged)
+	}
+
+	if current.CommunityVisibilityState != previous.CommunityVisibilityState {
+		types = append(types, VisibilityChanged)
+	}
+
+	events := make([]Event, len(types))
+	for idx, eventType := range types {
+		events[idx] = Event{Type: eventType, SID: current.SteamID, Current: current, Previous: previous}
+	}
+
+	return events
+}
@@ -0,0 +1,155 @@
+package watchlist_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/extra"
+	"github.com/leighmacdonald/steamid/v4/extra/store"
+	"github.com/leighmacdonald/steamid/v4/extra/watchlist"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestProvider = errors.New("provider failed")
+
+type testProvider struct {
+	mu        sync.Mutex
+	summaries map[steamid.SteamID]steamid.PlayerSummary
+	fail      bool
+}
+
+func (p *testProvider) set(summary steamid.PlayerSummary) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.summaries[summary.SteamID] = summary
+}
+
+func (p *testProvider) PlayerSummaries(_ context.Context, steamIDs steamid.Collection) ([]steamid.PlayerSummary, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.fail {
+		return nil, errTestProvider
+	}
+
+	var summaries []steamid.PlayerSummary
+
+	for _, sid := range steamIDs {
+		if summary, found := p.summaries[sid]; found {
+			summaries = append(summaries, summary)
+		}
+	}
+
+	return summaries, nil
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx      = context.Background()
+		sid      = steamid.New(76561198132612090)
+		other    = steamid.New(76561197960287930)
+		provider = &testProvider{summaries: map[steamid.SteamID]steamid.PlayerSummary{}}
+		aliases  = extra.NewAliasTable()
+		// aliasStore persists the same names as the alias table.
+		aliasStore = store.NewMemoryStore()
+	)
+
+	provider.set(steamid.PlayerSummary{SteamID: sid, PersonaName: "Dulahan", AvatarHash: "a", CommunityVisibilityState: 3})
+	provider.set(steamid.PlayerSummary{SteamID: other, PersonaName: "Rabscuttle"})
+
+	list := watchlist.New(provider, steamid.Collection{sid, sid, {}},
+		watchlist.WithAliasTable(aliases), watchlist.WithStore(aliasStore))
+	require.Equal(t, steamid.Collection{sid}, list.SteamIDs())
+
+	events, errCheck := list.Check(ctx)
+	require.NoError(t, errCheck)
+	require.Empty(t, events)
+
+	provider.set(steamid.PlayerSummary{SteamID: sid, PersonaName: "Dula", AvatarHash: "b", CommunityVisibilityState: 1})
+
+	events, errCheck = list.Check(ctx)
+	require.NoError(t, errCheck)
+	require.Len(t, events, 3)
+	require.Equal(t, watchlist.NameChanged, events[0].Type)
+	require.Equal(t, "Dulahan", events[0].Previous.PersonaName)
+	require.Equal(t, "Dula", events[0].Current.PersonaName)
+	require.Equal(t, watchlist.AvatarChanged, events[1].Type)
+	require.Equal(t, "Visibility Changed", events[2].Type.String())
+
+	names := make([]string, 0, 2)
+	for _, alias := range list.Aliases(sid) {
+		names = append(names, alias.Name)
+	}
+
+	require.Equal(t, []string{"Dulahan", "Dula"}, names)
+	require.Equal(t, list.Aliases(sid), aliases.Aliases(sid))
+
+	stored, errStored := aliasStore.Aliases(ctx, sid)
+	require.NoError(t, errStored)
+	require.Equal(t, list.Aliases(sid), stored)
+
+	summary, found := list.Summary(sid)
+	require.True(t, found)
+	require.Equal(t, "Dula", summary.PersonaName)
+
+	list.Add(other)
+
+	events, errCheck = list.Check(ctx)
+	require.NoError(t, errCheck)
+	require.Empty(t, events)
+
+	list.Remove(sid)
+	require.Equal(t, steamid.Collection{other}, list.SteamIDs())
+
+	_, found = list.Summary(sid)
+	require.False(t, found)
+
+	provider.fail = true
+
+	_, errFail := list.Check(ctx)
+	require.ErrorIs(t, errFail, watchlist.ErrFetchSummaries)
+	require.ErrorIs(t, errFail, errTestProvider)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sid      = steamid.New(76561198132612090)
+		provider = &testProvider{summaries: map[steamid.SteamID]steamid.PlayerSummary{}}
+		events   = make(chan watchlist.Event, 1)
+	)
+
+	provider.set(steamid.PlayerSummary{SteamID: sid, PersonaName: "Dulahan"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	list := watchlist.New(provider, steamid.Collection{sid}, watchlist.WithInterval(time.Millisecond))
+
+	go list.Run(ctx, func(event watchlist.Event) {
+		events <- event
+	})
+
+	require.Eventually(t, func() bool {
+		_, found := list.Summary(sid)
+
+		return found
+	}, time.Second, time.Millisecond)
+
+	provider.set(steamid.PlayerSummary{SteamID: sid, PersonaName: "Dula"})
+
+	select {
+	case event := <-events:
+		require.Equal(t, watchlist.NameChanged, event.Type)
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+	}
+}
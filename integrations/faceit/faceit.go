@@ -0,0 +1,193 @@
+// Package faceit maps steam ids to FACEIT players using the FACEIT Data API, returning their FACEIT
+// id and skill level. A server side api key is required, see https://developers.faceit.com.
+//
+//	client, err := faceit.NewClient(apiKey)
+//	player, err := client.Player(ctx, sid)
+package faceit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+const baseURL = "https://open.faceit.com/data/v4"
+
+// The games players can be looked up for.
+const (
+	GameCS2  = "cs2"
+	GameCSGO = "csgo"
+)
+
+var (
+	// ErrNoAPIKey is returned by NewClient when the api key is empty.
+	ErrNoAPIKey = errors.New("no faceit api key, to obtain one see: https://developers.faceit.com")
+	// ErrRequest is returned when the api can not be reached or returns an unexpected response.
+	ErrRequest = errors.New("faceit request failed")
+	// ErrNotFound is returned when no FACEIT player is linked to the steam id for the game.
+	ErrNotFound = errors.New("faceit player not found")
+)
+
+// Player is the FACEIT player linked to a steam id, along with their rating in the game.
+type Player struct {
+	// ID is the FACEIT player id, a uuid.
+	ID       string
+	Nickname string
+	Country  string
+	// URL is the FACEIT profile url.
+	URL     string
+	SteamID steamid.SteamID
+	Game    string
+	Region  string
+	// SkillLevel is the FACEIT level of the player in the game, from 1 to 10.
+	SkillLevel int
+	Elo        int
+}
+
+// playerResponse is the response of the /players endpoint.
+type playerResponse struct {
+	PlayerID  string `json:"player_id"`
+	Nickname  string `json:"nickname"`
+	Country   string `json:"country"`
+	FaceitURL string `json:"faceit_url"`
+	Games     map[string]struct {
+		Region     string `json:"region"`
+		SkillLevel int    `json:"skill_level"`
+		FaceitElo  int    `json:"faceit_elo"`
+	} `json:"games"`
+}
+
+// Client performs FACEIT Data API requests. It is safe for concurrent use.
+type Client struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+	game       string
+}
+
+// Option configures optional Client settings.
+type Option func(*Client)
+
+// WithHTTPClient sets the http client used to perform requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL overrides the Data API base url, e.g. to use a test server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithGame sets the game players are looked up for, GameCS2 by default. Players of CS:GO that have
+// not played CS2 on FACEIT are only found with GameCSGO.
+func WithGame(game string) Option {
+	return func(c *Client) {
+		c.game = game
+	}
+}
+
+// NewClient returns a client using the server side api key. ErrNoAPIKey is returned when it is
+// empty.
+func NewClient(apiKey string, opts ...Option) (*Client, error) {
+	apiKey = strings.TrimSpace(apiKey)
+	if apiKey == "" {
+		return nil, ErrNoAPIKey
+	}
+
+	client := &Client{
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: time.Second * 10},
+		baseURL:    baseURL,
+		game:       GameCS2,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client, nil
+}
+
+// Player returns the FACEIT player linked to the steam id. ErrNotFound is returned when the id has
+// no FACEIT account, or has not played the client's game on FACEIT.
+func (c *Client) Player(ctx context.Context, sid steamid.SteamID) (Player, error) {
+	if !sid.Valid() {
+		return Player{}, steamid.Error{Kind: steamid.ErrParse, Input: sid.String(), Err: steamid.ErrInvalidSID}
+	}
+
+	var (
+		resp   playerResponse
+		values = url.Values{"game": {c.game}, "game_player_id": {sid.String()}}
+	)
+
+	if errGet := c.get(ctx, "/players?"+values.Encode(), sid, &resp); errGet != nil {
+		return Player{}, errGet
+	}
+
+	game, found := resp.Games[c.game]
+	if !found {
+		return Player{}, steamid.Error{Kind: steamid.ErrResolve, Input: sid.String(), Err: ErrNotFound}
+	}
+
+	return Player{
+		ID:         resp.PlayerID,
+		Nickname:   resp.Nickname,
+		Country:    resp.Country,
+		URL:        strings.ReplaceAll(resp.FaceitURL, "{lang}", "en"),
+		SteamID:    sid,
+		Game:       c.game,
+		Region:     game.Region,
+		SkillLevel: game.SkillLevel,
+		Elo:        game.FaceitElo,
+	}, nil
+}
+
+// get performs an authenticated GET request, decoding the JSON response into out. ErrNotFound is
+// returned for 404 responses.
+func (c *Client) get(ctx context.Context, path string, sid steamid.SteamID, out any) error {
+	input := sid.String()
+
+	req, errReq := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if errReq != nil {
+		return steamid.Error{Kind: steamid.ErrAPI, Input: input, Err: errors.Join(errReq, ErrRequest)}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, errDo := c.httpClient.Do(req)
+	if errDo != nil {
+		return steamid.Error{Kind: steamid.ErrAPI, Input: input, Err: errors.Join(errDo, ErrRequest)}
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return steamid.Error{Kind: steamid.ErrResolve, Code: resp.StatusCode, Input: input, Err: ErrNotFound}
+	default:
+		return steamid.Error{
+			Kind: steamid.ErrAPI, Code: resp.StatusCode, Input: input,
+			Err: errors.Join(steamid.ErrInvalidStatusCode, ErrRequest),
+		}
+	}
+
+	if errDecode := json.NewDecoder(resp.Body).Decode(out); errDecode != nil {
+		return steamid.Error{Kind: steamid.ErrAPI, Code: resp.StatusCode, Input: input, Err: errors.Join(errDecode, ErrRequest)}
+	}
+
+	return nil
+}
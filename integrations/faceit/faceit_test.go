@@ -0,0 +1,76 @@
+package faceit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/integrations/faceit"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testKey      = "00000000-0000-0000-0000-000000000000"
+	testResponse = `{"player_id":"5ea07280-2399-4c7e-88ab-f2f7db0c449f","nickname":"player","country":"ca",
+"faceit_url":"https://www.faceit.com/{lang}/players/player","games":{"cs2":{"region":"NA","game_player_id":"76561198132612090",
+"skill_level":8,"faceit_elo":1712}}}`
+)
+
+func TestPlayer(t *testing.T) {
+	t.Parallel()
+
+	sid := steamid.New(76561198132612090)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") != "Bearer "+testKey:
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path != "/players" || r.URL.Query().Get("game_player_id") != sid.String():
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			_, _ = w.Write([]byte(testResponse))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ctx := context.Background()
+
+	client, errClient := faceit.NewClient(testKey, faceit.WithBaseURL(server.URL))
+	require.NoError(t, errClient)
+
+	player, errPlayer := client.Player(ctx, sid)
+	require.NoError(t, errPlayer)
+	require.Equal(t, faceit.Player{
+		ID:         "5ea07280-2399-4c7e-88ab-f2f7db0c449f",
+		Nickname:   "player",
+		Country:    "ca",
+		URL:        "https://www.faceit.com/en/players/player",
+		SteamID:    sid,
+		Game:       faceit.GameCS2,
+		Region:     "NA",
+		SkillLevel: 8,
+		Elo:        1712,
+	}, player)
+
+	_, errMissing := client.Player(ctx, steamid.New(76561197960287930))
+	require.ErrorIs(t, errMissing, faceit.ErrNotFound)
+	require.ErrorIs(t, errMissing, steamid.ErrResolve)
+
+	csgo, errClient := faceit.NewClient(testKey, faceit.WithBaseURL(server.URL), faceit.WithGame(faceit.GameCSGO))
+	require.NoError(t, errClient)
+
+	_, errGame := csgo.Player(ctx, sid)
+	require.ErrorIs(t, errGame, faceit.ErrNotFound)
+
+	badKey, errClient := faceit.NewClient("bad", faceit.WithBaseURL(server.URL))
+	require.NoError(t, errClient)
+
+	_, errUnauthorized := badKey.Player(ctx, sid)
+	require.ErrorIs(t, errUnauthorized, faceit.ErrRequest)
+	require.ErrorIs(t, errUnauthorized, steamid.ErrAPI)
+
+	_, errClient = faceit.NewClient(" ")
+	require.ErrorIs(t, errClient, faceit.ErrNoAPIKey)
+}
@@ -0,0 +1,91 @@
+package leagues
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// etf2lCompetition is a competition a ETF2L team took part in.
+type etf2lCompetition struct {
+	Division struct {
+		Name string `json:"name"`
+	} `json:"division"`
+}
+
+// etf2lPlayer is the response of the ETF2L /player/{id} endpoint.
+type etf2lPlayer struct {
+	Player struct {
+		Name string `json:"name"`
+		Bans []struct {
+			Start  int64  `json:"start"`
+			End    int64  `json:"end"`
+			Reason string `json:"reason"`
+		} `json:"bans"`
+		Teams []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+			Type string `json:"type"`
+			// Competitions is an object keyed by competition id, or an empty array when the team
+			// has not played any.
+			Competitions json.RawMessage `json:"competitions"`
+		} `json:"teams"`
+	} `json:"player"`
+}
+
+// latestDivision returns the division of the competition with the highest id, which is the most
+// recent one.
+func latestDivision(raw json.RawMessage) string {
+	var competitions map[string]etf2lCompetition
+	if json.Unmarshal(raw, &competitions) != nil {
+		return ""
+	}
+
+	var (
+		division string
+		latest   int64 = -1
+	)
+
+	for key, competition := range competitions {
+		id, errID := strconv.ParseInt(key, 10, 64)
+		if errID == nil && id > latest && competition.Division.Name != "" {
+			latest, division = id, competition.Division.Name
+		}
+	}
+
+	return division
+}
+
+// ETF2LProfile fetches the ETF2L profile of the player, including past teams and bans.
+func (c *Client) ETF2LProfile(ctx context.Context, sid steamid.SteamID) (Profile, error) {
+	var resp etf2lPlayer
+	if errGet := c.get(ctx, ETF2L, c.etf2lURL+"/player/"+sid.String(), sid, &resp); errGet != nil {
+		return Profile{}, errGet
+	}
+
+	var (
+		profile = Profile{League: ETF2L, SteamID: sid, Name: resp.Player.Name}
+		now     = time.Now()
+	)
+
+	for _, team := range resp.Player.Teams {
+		profile.Teams = append(profile.Teams, Team{
+			ID: team.ID, Name: team.Name, Format: team.Type, Division: latestDivision(team.Competitions),
+		})
+	}
+
+	for _, ban := range resp.Player.Bans {
+		entry := Ban{Start: time.Unix(ban.Start, 0), Reason: ban.Reason}
+		if ban.End > 0 {
+			entry.End = time.Unix(ban.End, 0)
+		}
+
+		profile.Bans = append(profile.Bans, entry)
+		profile.Banned = profile.Banned || entry.Active(now)
+	}
+
+	return profile, nil
+}
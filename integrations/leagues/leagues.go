@@ -0,0 +1,204 @@
+// Package leagues looks up the profiles of players in the RGL and ETF2L competitive TF2 leagues by
+// steam id, returning their teams, divisions and bans as a Profile common to both leagues.
+//
+//	client := leagues.NewClient()
+//	profiles, err := client.Profiles(ctx, "[U:1:172346362]")
+package leagues
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+const (
+	rglBaseURL   = "https://api.rgl.gg"
+	etf2lBaseURL = "https://api-v2.etf2l.org"
+)
+
+// League is the name of a league.
+type League string
+
+const (
+	RGL   League = "RGL"
+	ETF2L League = "ETF2L"
+)
+
+var (
+	// ErrRequest is returned when a league api can not be reached or returns an unexpected response.
+	ErrRequest = errors.New("league request failed")
+	// ErrNotFound is returned when the player has no profile in the league.
+	ErrNotFound = errors.New("player not found in league")
+)
+
+// Team is a team the player is, or was, rostered on.
+type Team struct {
+	ID   int64
+	Name string
+	// Format is the game mode of the team, such as sixes or highlander.
+	Format string
+	// Division is the division of the team's latest competition, if known.
+	Division string
+}
+
+// Ban is a league ban. End is the zero time for permanent bans.
+type Ban struct {
+	Start  time.Time
+	End    time.Time
+	Reason string
+}
+
+// Active returns true when the ban has not ended at the time.
+func (b Ban) Active(now time.Time) bool {
+	return b.End.IsZero() || b.End.After(now)
+}
+
+// Profile is the profile of a player in a league.
+type Profile struct {
+	League  League
+	SteamID steamid.SteamID
+	Name    string
+	Teams   []Team
+	Bans    []Ban
+	// Banned is true when the player is currently banned from the league.
+	Banned bool
+}
+
+// Client performs RGL and ETF2L api requests. It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+	rglURL     string
+	etf2lURL   string
+}
+
+// Option configures optional Client settings.
+type Option func(*Client)
+
+// WithHTTPClient sets the http client used to perform requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRGLURL overrides the RGL api base url, e.g. to use a test server.
+func WithRGLURL(baseURL string) Option {
+	return func(c *Client) {
+		c.rglURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithETF2LURL overrides the ETF2L api base url, e.g. to use a test server.
+func WithETF2LURL(baseURL string) Option {
+	return func(c *Client) {
+		c.etf2lURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// NewClient returns a client for the public RGL and ETF2L apis.
+func NewClient(opts ...Option) *Client {
+	client := &Client{
+		httpClient: &http.Client{Timeout: time.Second * 10},
+		rglURL:     rglBaseURL,
+		etf2lURL:   etf2lBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// Profiles looks up the player in every league. The id may be in any format accepted by steamid.New,
+// or a friend code. Leagues the player has no profile in are left out; the first error other than
+// ErrNotFound is returned along with the profiles found.
+func (c *Client) Profiles(ctx context.Context, id string) ([]Profile, error) {
+	sid := steamid.New(strings.TrimSpace(id))
+	if !sid.Valid() {
+		friend, errFriend := steamid.FromFriendCode(id)
+		if errFriend != nil {
+			return nil, steamid.Error{Kind: steamid.ErrParse, Input: id, Err: steamid.ErrInvalidSID}
+		}
+
+		sid = friend
+	}
+
+	var (
+		lookups  = []func(context.Context, steamid.SteamID) (Profile, error){c.RGLProfile, c.ETF2LProfile}
+		profiles = make([]Profile, len(lookups))
+		errs     = make([]error, len(lookups))
+		wg       sync.WaitGroup
+	)
+
+	for idx, lookup := range lookups {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			profiles[idx], errs[idx] = lookup(ctx, sid)
+		}()
+	}
+
+	wg.Wait()
+
+	var (
+		found    []Profile
+		errFirst error
+	)
+
+	for idx, profile := range profiles {
+		switch {
+		case errs[idx] == nil:
+			found = append(found, profile)
+		case errFirst == nil && !errors.Is(errs[idx], ErrNotFound):
+			errFirst = errs[idx]
+		}
+	}
+
+	return found, errFirst
+}
+
+// get performs a GET request, decoding the JSON response into out. ErrNotFound is returned for 404
+// responses.
+func (c *Client) get(ctx context.Context, league League, url string, sid steamid.SteamID, out any) error {
+	input := string(league) + " " + sid.String()
+
+	req, errReq := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if errReq != nil {
+		return steamid.Error{Kind: steamid.ErrAPI, Input: input, Err: errors.Join(errReq, ErrRequest)}
+	}
+
+	resp, errDo := c.httpClient.Do(req)
+	if errDo != nil {
+		return steamid.Error{Kind: steamid.ErrAPI, Input: input, Err: errors.Join(errDo, ErrRequest)}
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return steamid.Error{Kind: steamid.ErrResolve, Code: resp.StatusCode, Input: input, Err: ErrNotFound}
+	default:
+		return steamid.Error{
+			Kind: steamid.ErrAPI, Code: resp.StatusCode, Input: input,
+			Err: errors.Join(steamid.ErrInvalidStatusCode, ErrRequest),
+		}
+	}
+
+	if errDecode := json.NewDecoder(resp.Body).Decode(out); errDecode != nil {
+		return steamid.Error{Kind: steamid.ErrAPI, Code: resp.StatusCode, Input: input, Err: errors.Join(errDecode, ErrRequest)}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package leagues_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/integrations/leagues"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rglProfile = `{"steamId":"76561198132612090","name":"player","status":{"isVerified":true,"isBanned":true},
+"banInformation":{"endsAt":"2099-01-01T00:00:00Z","reason":"Cheating"},
+"currentTeams":{"sixes":null,"highlander":{"id":12,"name":"Highlanders","divisionName":"Advanced"},
+"prolander":{"id":34,"name":"Prolanders","divisionName":"Main"}}}`
+	etf2lPlayer = `{"player":{"name":"player","bans":[{"start":1500000000,"end":1500100000,"reason":"VAC"}],
+"teams":[{"id":56,"name":"Sixers","type":"6v6","competitions":{"100":{"division":{"name":"Division 2"}},
+"120":{"division":{"name":"Division 1"}}}},{"id":78,"name":"New team","type":"Highlander","competitions":[]}]},
+"status":{"code":200,"message":"OK"}}`
+)
+
+func newTestClient(t *testing.T, rglStatus int, etf2lStatus int) *leagues.Client {
+	t.Helper()
+
+	handler := func(status int, path string, body string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if status != http.StatusOK || r.URL.Path != path {
+				w.WriteHeader(status)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	rgl := handler(rglStatus, "/v0/profile/76561198132612090", rglProfile)
+	etf2l := handler(etf2lStatus, "/player/76561198132612090", etf2lPlayer)
+
+	return leagues.NewClient(leagues.WithRGLURL(rgl.URL), leagues.WithETF2LURL(etf2l.URL))
+}
+
+func TestProfiles(t *testing.T) {
+	t.Parallel()
+
+	var (
+		client = newTestClient(t, http.StatusOK, http.StatusOK)
+		sid    = steamid.New(76561198132612090)
+	)
+
+	profiles, errProfiles := client.Profiles(context.Background(), "[U:1:172346362]")
+	require.NoError(t, errProfiles)
+	require.Len(t, profiles, 2)
+
+	rgl := profiles[0]
+	require.Equal(t, leagues.RGL, rgl.League)
+	require.Equal(t, sid, rgl.SteamID)
+	require.True(t, rgl.Banned)
+	require.Equal(t, []leagues.Team{
+		{ID: 12, Name: "Highlanders", Format: "highlander", Division: "Advanced"},
+		{ID: 34, Name: "Prolanders", Format: "prolander", Division: "Main"},
+	}, rgl.Teams)
+	require.Equal(t, "Cheating", rgl.Bans[0].Reason)
+
+	etf2l := profiles[1]
+	require.Equal(t, leagues.ETF2L, etf2l.League)
+	require.Equal(t, "player", etf2l.Name)
+	require.False(t, etf2l.Banned)
+	require.Equal(t, []leagues.Team{
+		{ID: 56, Name: "Sixers", Format: "6v6", Division: "Division 1"},
+		{ID: 78, Name: "New team", Format: "Highlander"},
+	}, etf2l.Teams)
+	require.Equal(t, time.Unix(1500100000, 0), etf2l.Bans[0].End)
+}
+
+func TestProfilesNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	profiles, errProfiles := newTestClient(t, http.StatusNotFound, http.StatusOK).Profiles(ctx, "76561198132612090")
+	require.NoError(t, errProfiles)
+	require.Len(t, profiles, 1)
+	require.Equal(t, leagues.ETF2L, profiles[0].League)
+
+	_, errRGL := newTestClient(t, http.StatusNotFound, http.StatusOK).RGLProfile(ctx, steamid.New(76561198132612090))
+	require.ErrorIs(t, errRGL, leagues.ErrNotFound)
+	require.ErrorIs(t, errRGL, steamid.ErrResolve)
+
+	profiles, errProfiles = newTestClient(t, http.StatusOK, http.StatusInternalServerError).Profiles(ctx, "STEAM_0:0:86173181")
+	require.ErrorIs(t, errProfiles, leagues.ErrRequest)
+	require.ErrorIs(t, errProfiles, steamid.ErrAPI)
+	require.Len(t, profiles, 1)
+
+	_, errInvalid := newTestClient(t, http.StatusOK, http.StatusOK).Profiles(ctx, "not an id")
+	require.ErrorIs(t, errInvalid, steamid.ErrParse)
+}
@@ -0,0 +1,52 @@
+package leagues
+
+import (
+	"context"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// rglTeam is a current team of a RGL profile.
+type rglTeam struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	DivisionName string `json:"divisionName"`
+}
+
+// rglProfile is the response of the RGL /v0/profile/{steamId} endpoint.
+type rglProfile struct {
+	Name   string `json:"name"`
+	Status struct {
+		IsBanned bool `json:"isBanned"`
+	} `json:"status"`
+	BanInformation *struct {
+		EndsAt time.Time `json:"endsAt"`
+		Reason string    `json:"reason"`
+	} `json:"banInformation"`
+	CurrentTeams map[string]*rglTeam `json:"currentTeams"`
+}
+
+// RGLProfile fetches the RGL profile of the player. Only current teams and the active ban, if any,
+// are included.
+func (c *Client) RGLProfile(ctx context.Context, sid steamid.SteamID) (Profile, error) {
+	var resp rglProfile
+	if errGet := c.get(ctx, RGL, c.rglURL+"/v0/profile/"+sid.String(), sid, &resp); errGet != nil {
+		return Profile{}, errGet
+	}
+
+	profile := Profile{League: RGL, SteamID: sid, Name: resp.Name, Banned: resp.Status.IsBanned}
+
+	// Map iteration is random, so the teams are added in a fixed order of formats.
+	for _, format := range []string{"sixes", "highlander", "prolander"} {
+		if team := resp.CurrentTeams[format]; team != nil {
+			profile.Teams = append(profile.Teams, Team{ID: team.ID, Name: team.Name, Format: format, Division: team.DivisionName})
+		}
+	}
+
+	if resp.BanInformation != nil {
+		profile.Bans = append(profile.Bans, Ban{End: resp.BanInformation.EndsAt, Reason: resp.BanInformation.Reason})
+	}
+
+	return profile, nil
+}
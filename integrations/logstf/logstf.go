@@ -0,0 +1,335 @@
+// Package logstf fetches the match logs of players from the logs.tf api, for competitive TF2 tools
+// that join players with their recent matches. Requests are rate limited and their results cached
+// in memory, so the same player can be looked up repeatedly without hammering the api.
+//
+//	client := logstf.NewClient()
+//	logs, err := client.PlayerLogs(ctx, sid, 10)
+package logstf
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+const (
+	baseURL = "https://logs.tf"
+	// DefaultLimit is the number of logs returned when no limit is given.
+	DefaultLimit = 25
+	// MaxLimit is the largest number of logs the api returns for a single request.
+	MaxLimit = 10000
+	// DefaultInterval is the minimum time between requests, see WithRateLimit.
+	DefaultInterval = time.Second
+	// DefaultCacheTTL is how long results are cached, see WithCacheTTL.
+	DefaultCacheTTL = 5 * time.Minute
+	// DefaultCacheSize is the number of results cached, see WithCacheSize.
+	DefaultCacheSize = 1000
+)
+
+var (
+	// ErrRequest is returned when the api can not be reached or returns an unexpected response.
+	ErrRequest = errors.New("logs.tf request failed")
+	// ErrResponse is returned when the api reports a failure, such as an invalid parameter.
+	ErrResponse = errors.New("logs.tf returned an error")
+)
+
+// Log is a match log summary as listed by the log search endpoint.
+type Log struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	Map   string `json:"map"`
+	// Date is the unix time the log was uploaded.
+	Date    int64 `json:"date"`
+	Views   int   `json:"views"`
+	Players int   `json:"players"`
+}
+
+// Time returns the time the log was uploaded.
+func (l Log) Time() time.Time {
+	return time.Unix(l.Date, 0)
+}
+
+// URL returns the address of the log on logs.tf.
+func (l Log) URL() string {
+	return baseURL + "/" + strconv.FormatInt(l.ID, 10)
+}
+
+// searchResponse is the response of the /api/v1/log endpoint.
+type searchResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Logs    []Log  `json:"logs"`
+}
+
+type cacheKey struct {
+	sid   steamid.SteamID
+	limit int
+}
+
+type cachedLogs struct {
+	key     cacheKey
+	logs    []Log
+	expires time.Time
+}
+
+// logCache holds results for a ttl, keeping at most maxEntries of them. When it is full the expired
+// entries are removed, then the least recently used ones. It is not safe for concurrent use.
+type logCache struct {
+	ttl        time.Duration
+	maxEntries int
+	entries    map[cacheKey]*list.Element
+	// recent orders the entries from the most to the least recently used.
+	recent *list.List
+}
+
+func newLogCache(ttl time.Duration, maxEntries int) *logCache {
+	return &logCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    map[cacheKey]*list.Element{},
+		recent:     list.New(),
+	}
+}
+
+func (c *logCache) get(key cacheKey, now time.Time) ([]Log, bool) {
+	elem, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+
+	entry, _ := elem.Value.(*cachedLogs)
+	if !now.Before(entry.expires) {
+		c.remove(elem)
+
+		return nil, false
+	}
+
+	c.recent.MoveToFront(elem)
+
+	return entry.logs, true
+}
+
+func (c *logCache) add(key cacheKey, logs []Log, now time.Time) {
+	if elem, found := c.entries[key]; found {
+		entry, _ := elem.Value.(*cachedLogs)
+		entry.logs = logs
+		entry.expires = now.Add(c.ttl)
+		c.recent.MoveToFront(elem)
+
+		return
+	}
+
+	if len(c.entries) >= c.maxEntries {
+		for elem := c.recent.Back(); elem != nil; {
+			prev := elem.Prev()
+
+			if entry, _ := elem.Value.(*cachedLogs); !now.Before(entry.expires) {
+				c.remove(elem)
+			}
+
+			elem = prev
+		}
+	}
+
+	for len(c.entries) >= c.maxEntries {
+		c.remove(c.recent.Back())
+	}
+
+	c.entries[key] = c.recent.PushFront(&cachedLogs{key: key, logs: logs, expires: now.Add(c.ttl)})
+}
+
+func (c *logCache) remove(elem *list.Element) {
+	entry, _ := c.recent.Remove(elem).(*cachedLogs)
+	delete(c.entries, entry.key)
+}
+
+// Client performs logs.tf api requests. It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	interval   time.Duration
+	ttl        time.Duration
+	cacheSize  int
+
+	mu    sync.Mutex
+	next  time.Time
+	cache *logCache
+}
+
+// Option configures optional Client settings.
+type Option func(*Client)
+
+// WithHTTPClient sets the http client used to perform requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL overrides the logs.tf base url, e.g. to use a proxy or a test server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithRateLimit sets the minimum time between requests, 0 for no limit. Requests made sooner wait
+// for their turn, or until their context is done.
+func WithRateLimit(interval time.Duration) Option {
+	return func(c *Client) {
+		c.interval = interval
+	}
+}
+
+// WithCacheTTL sets how long results are cached, 0 to disable the cache.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.ttl = ttl
+	}
+}
+
+// WithCacheSize sets the number of results cached, DefaultCacheSize by default. Once the cache is
+// full the expired and then the least recently used results are evicted.
+func WithCacheSize(size int) Option {
+	return func(c *Client) {
+		if size > 0 {
+			c.cacheSize = size
+		}
+	}
+}
+
+// NewClient returns a client using DefaultInterval, DefaultCacheTTL and DefaultCacheSize unless
+// configured otherwise.
+func NewClient(opts ...Option) *Client {
+	client := &Client{
+		httpClient: &http.Client{Timeout: time.Second * 10},
+		baseURL:    baseURL,
+		interval:   DefaultInterval,
+		ttl:        DefaultCacheTTL,
+		cacheSize:  DefaultCacheSize,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	client.cache = newLogCache(client.ttl, client.cacheSize)
+
+	return client
+}
+
+// wait blocks until the rate limit allows another request.
+func (c *Client) wait(ctx context.Context) error {
+	if c.interval <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	now := time.Now()
+	start := c.next
+	if start.Before(now) {
+		start = now
+	}
+
+	c.next = start.Add(c.interval)
+	c.mu.Unlock()
+
+	timer := time.NewTimer(start.Sub(now))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+		return nil
+	}
+}
+
+// PlayerLogs returns the most recent logs of the player, newest first. Limit is the number of logs
+// to return, DefaultLimit when 0, and at most MaxLimit.
+func (c *Client) PlayerLogs(ctx context.Context, sid steamid.SteamID, limit int) ([]Log, error) {
+	if !sid.Valid() {
+		return nil, steamid.Error{Kind: steamid.ErrParse, Input: sid.String(), Err: steamid.ErrInvalidSID}
+	}
+
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	limit = min(limit, MaxLimit)
+	key := cacheKey{sid: sid, limit: limit}
+
+	c.mu.Lock()
+	cached, found := c.cache.get(key, time.Now())
+	c.mu.Unlock()
+
+	if found {
+		return cached, nil
+	}
+
+	var resp searchResponse
+	if errGet := c.get(ctx, "/api/v1/log",
+		url.Values{"player": {sid.String()}, "limit": {strconv.Itoa(limit)}}, &resp); errGet != nil {
+		return nil, errGet
+	}
+
+	if !resp.Success {
+		return nil, steamid.Error{
+			Kind: steamid.ErrAPI, Input: sid.String(), Err: fmt.Errorf("%w: %s", ErrResponse, resp.Error),
+		}
+	}
+
+	if c.ttl > 0 {
+		c.mu.Lock()
+		c.cache.add(key, resp.Logs, time.Now())
+		c.mu.Unlock()
+	}
+
+	return resp.Logs, nil
+}
+
+// apiError returns a steamid.ErrAPI error for the request path.
+func apiError(path string, code int, err error) error {
+	return steamid.Error{Kind: steamid.ErrAPI, Code: code, Input: path, Err: errors.Join(err, ErrRequest)}
+}
+
+// get performs a rate limited GET request, decoding the JSON response into out.
+func (c *Client) get(ctx context.Context, path string, values url.Values, out any) error {
+	if errWait := c.wait(ctx); errWait != nil {
+		return apiError(path, 0, errWait)
+	}
+
+	req, errReq := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+values.Encode(), nil)
+	if errReq != nil {
+		return apiError(path, 0, errReq)
+	}
+
+	resp, errDo := c.httpClient.Do(req)
+	if errDo != nil {
+		return apiError(path, 0, errDo)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return apiError(path, resp.StatusCode, steamid.ErrInvalidStatusCode)
+	}
+
+	if errDecode := json.NewDecoder(resp.Body).Decode(out); errDecode != nil {
+		return apiError(path, resp.StatusCode, errDecode)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package logstf_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/integrations/logstf"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer returns a server answering log searches for the player with two logs, counting
+// the requests received.
+func newTestServer(t *testing.T, player steamid.SteamID) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	requests := &atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path != "/api/v1/log" || r.URL.Query().Get("player") != player.String() {
+			_ = json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "Invalid player"})
+
+			return
+		}
+
+		require.Equal(t, "2", r.URL.Query().Get("limit"))
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"results": 2,
+			"logs": []map[string]any{
+				{"id": 3456789, "title": "serveme.tf #1", "map": "cp_process_f12", "date": 1700000000, "views": 12, "players": 12},
+				{"id": 3456700, "title": "serveme.tf #2", "map": "koth_product_final", "date": 1699990000, "views": 3, "players": 13},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func TestPlayerLogs(t *testing.T) {
+	t.Parallel()
+
+	var (
+		player           = steamid.New(76561198132612090)
+		server, requests = newTestServer(t, player)
+		client           = logstf.NewClient(logstf.WithBaseURL(server.URL), logstf.WithRateLimit(0))
+		ctx              = context.Background()
+	)
+
+	logs, errLogs := client.PlayerLogs(ctx, player, 2)
+	require.NoError(t, errLogs)
+	require.Len(t, logs, 2)
+	require.Equal(t, int64(3456789), logs[0].ID)
+	require.Equal(t, "cp_process_f12", logs[0].Map)
+	require.Equal(t, 12, logs[0].Players)
+	require.Equal(t, time.Unix(1700000000, 0), logs[0].Time())
+	require.Equal(t, "https://logs.tf/3456789", logs[0].URL())
+
+	// Repeated lookups are answered from the cache.
+	_, errLogs = client.PlayerLogs(ctx, player, 2)
+	require.NoError(t, errLogs)
+	require.Equal(t, int32(1), requests.Load())
+
+	_, errFailed := client.PlayerLogs(ctx, steamid.New(76561197960287930), 2)
+	require.ErrorIs(t, errFailed, logstf.ErrResponse)
+	require.ErrorIs(t, errFailed, steamid.ErrAPI)
+	require.ErrorContains(t, errFailed, "Invalid player")
+
+	_, errInvalid := client.PlayerLogs(ctx, steamid.SteamID{}, 2)
+	require.ErrorIs(t, errInvalid, steamid.ErrInvalidSID)
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func TestPlayerLogsRateLimit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		player    = steamid.New(76561198132612090)
+		server, _ = newTestServer(t, player)
+		client    = logstf.NewClient(logstf.WithBaseURL(server.URL), logstf.WithCacheTTL(0),
+			logstf.WithRateLimit(50*time.Millisecond))
+		start = time.Now()
+	)
+
+	for range 3 {
+		_, errLogs := client.PlayerLogs(context.Background(), player, 2)
+		require.NoError(t, errLogs)
+	}
+
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, errCanceled := client.PlayerLogs(ctx, player, 2)
+	require.ErrorIs(t, errCanceled, context.Canceled)
+	require.ErrorIs(t, errCanceled, logstf.ErrRequest)
+}
+
+func TestPlayerLogsCacheSize(t *testing.T) {
+	t.Parallel()
+
+	requests := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Query().Get("player")]++
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": true, "logs": []map[string]any{{"id": 1}}})
+	}))
+	t.Cleanup(server.Close)
+
+	var (
+		client  = logstf.NewClient(logstf.WithBaseURL(server.URL), logstf.WithRateLimit(0), logstf.WithCacheSize(2))
+		players = []steamid.SteamID{
+			steamid.New(76561197960287930), steamid.New(76561198132612090), steamid.New(76561197961279983),
+		}
+	)
+
+	lookup := func(player steamid.SteamID) {
+		_, errLogs := client.PlayerLogs(context.Background(), player, 1)
+		require.NoError(t, errLogs)
+	}
+
+	// The first player is used again before the cache is filled past its limit, so the second one,
+	// the least recently used, is evicted.
+	lookup(players[0])
+	lookup(players[1])
+	lookup(players[0])
+	lookup(players[2])
+	lookup(players[0])
+	lookup(players[2])
+	require.Equal(t, map[string]int{players[0].String(): 1, players[1].String(): 1, players[2].String(): 1}, requests)
+
+	lookup(players[1])
+	require.Equal(t, 2, requests[players[1].String()])
+}
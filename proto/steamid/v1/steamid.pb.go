@@ -0,0 +1,668 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.1
+// source: steamid/v1/steamid.proto
+
+// Service definition for running the steamid resolver as a gRPC service. It mirrors the
+// endpoints of the `steamid serve` HTTP api and is served by `steamid serve --grpc`.
+//
+// Regenerate the go stubs with `make proto`.
+
+package steamidv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConvertRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_steamid_v1_steamid_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_steamid_v1_steamid_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_steamid_v1_steamid_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConvertRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResolveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *ResolveRequest) Reset() {
+	*x = ResolveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_steamid_v1_steamid_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveRequest) ProtoMessage() {}
+
+func (x *ResolveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_steamid_v1_steamid_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveRequest.ProtoReflect.Descriptor instead.
+func (*ResolveRequest) Descriptor() ([]byte, []int) {
+	return file_steamid_v1_steamid_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResolveRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type SteamIDsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Steam ids in any supported format.
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *SteamIDsRequest) Reset() {
+	*x = SteamIDsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_steamid_v1_steamid_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SteamIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SteamIDsRequest) ProtoMessage() {}
+
+func (x *SteamIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_steamid_v1_steamid_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SteamIDsRequest.ProtoReflect.Descriptor instead.
+func (*SteamIDsRequest) Descriptor() ([]byte, []int) {
+	return file_steamid_v1_steamid_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SteamIDsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type Conversion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Input   string `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+	Steam   string `protobuf:"bytes,2,opt,name=steam,proto3" json:"steam,omitempty"`
+	Steam3  string `protobuf:"bytes,3,opt,name=steam3,proto3" json:"steam3,omitempty"`
+	Steam32 uint32 `protobuf:"varint,4,opt,name=steam32,proto3" json:"steam32,omitempty"`
+	Steam64 uint64 `protobuf:"fixed64,5,opt,name=steam64,proto3" json:"steam64,omitempty"`
+}
+
+func (x *Conversion) Reset() {
+	*x = Conversion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_steamid_v1_steamid_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Conversion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conversion) ProtoMessage() {}
+
+func (x *Conversion) ProtoReflect() protoreflect.Message {
+	mi := &file_steamid_v1_steamid_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conversion.ProtoReflect.Descriptor instead.
+func (*Conversion) Descriptor() ([]byte, []int) {
+	return file_steamid_v1_steamid_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Conversion) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *Conversion) GetSteam() string {
+	if x != nil {
+		return x.Steam
+	}
+	return ""
+}
+
+func (x *Conversion) GetSteam3() string {
+	if x != nil {
+		return x.Steam3
+	}
+	return ""
+}
+
+func (x *Conversion) GetSteam32() uint32 {
+	if x != nil {
+		return x.Steam32
+	}
+	return 0
+}
+
+func (x *Conversion) GetSteam64() uint64 {
+	if x != nil {
+		return x.Steam64
+	}
+	return 0
+}
+
+type PlayerSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SteamId     *Conversion `protobuf:"bytes,1,opt,name=steam_id,json=steamId,proto3" json:"steam_id,omitempty"`
+	PersonaName string      `protobuf:"bytes,2,opt,name=persona_name,json=personaName,proto3" json:"persona_name,omitempty"`
+	RealName    string      `protobuf:"bytes,3,opt,name=real_name,json=realName,proto3" json:"real_name,omitempty"`
+	ProfileUrl  string      `protobuf:"bytes,4,opt,name=profile_url,json=profileUrl,proto3" json:"profile_url,omitempty"`
+	AvatarUrl   string      `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	CountryCode string      `protobuf:"bytes,6,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	Visibility  int32       `protobuf:"varint,7,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	// Unix timestamp, 0 when the profile is private.
+	TimeCreated int64 `protobuf:"varint,8,opt,name=time_created,json=timeCreated,proto3" json:"time_created,omitempty"`
+}
+
+func (x *PlayerSummary) Reset() {
+	*x = PlayerSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_steamid_v1_steamid_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerSummary) ProtoMessage() {}
+
+func (x *PlayerSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_steamid_v1_steamid_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerSummary.ProtoReflect.Descriptor instead.
+func (*PlayerSummary) Descriptor() ([]byte, []int) {
+	return file_steamid_v1_steamid_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PlayerSummary) GetSteamId() *Conversion {
+	if x != nil {
+		return x.SteamId
+	}
+	return nil
+}
+
+func (x *PlayerSummary) GetPersonaName() string {
+	if x != nil {
+		return x.PersonaName
+	}
+	return ""
+}
+
+func (x *PlayerSummary) GetRealName() string {
+	if x != nil {
+		return x.RealName
+	}
+	return ""
+}
+
+func (x *PlayerSummary) GetProfileUrl() string {
+	if x != nil {
+		return x.ProfileUrl
+	}
+	return ""
+}
+
+func (x *PlayerSummary) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *PlayerSummary) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *PlayerSummary) GetVisibility() int32 {
+	if x != nil {
+		return x.Visibility
+	}
+	return 0
+}
+
+func (x *PlayerSummary) GetTimeCreated() int64 {
+	if x != nil {
+		return x.TimeCreated
+	}
+	return 0
+}
+
+type PlayerBanState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SteamId          *Conversion `protobuf:"bytes,1,opt,name=steam_id,json=steamId,proto3" json:"steam_id,omitempty"`
+	CommunityBanned  bool        `protobuf:"varint,2,opt,name=community_banned,json=communityBanned,proto3" json:"community_banned,omitempty"`
+	VacBanned        bool        `protobuf:"varint,3,opt,name=vac_banned,json=vacBanned,proto3" json:"vac_banned,omitempty"`
+	NumberOfVacBans  int32       `protobuf:"varint,4,opt,name=number_of_vac_bans,json=numberOfVacBans,proto3" json:"number_of_vac_bans,omitempty"`
+	DaysSinceLastBan int32       `protobuf:"varint,5,opt,name=days_since_last_ban,json=daysSinceLastBan,proto3" json:"days_since_last_ban,omitempty"`
+	NumberOfGameBans int32       `protobuf:"varint,6,opt,name=number_of_game_bans,json=numberOfGameBans,proto3" json:"number_of_game_bans,omitempty"`
+	EconomyBan       string      `protobuf:"bytes,7,opt,name=economy_ban,json=economyBan,proto3" json:"economy_ban,omitempty"`
+}
+
+func (x *PlayerBanState) Reset() {
+	*x = PlayerBanState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_steamid_v1_steamid_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerBanState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerBanState) ProtoMessage() {}
+
+func (x *PlayerBanState) ProtoReflect() protoreflect.Message {
+	mi := &file_steamid_v1_steamid_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerBanState.ProtoReflect.Descriptor instead.
+func (*PlayerBanState) Descriptor() ([]byte, []int) {
+	return file_steamid_v1_steamid_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PlayerBanState) GetSteamId() *Conversion {
+	if x != nil {
+		return x.SteamId
+	}
+	return nil
+}
+
+func (x *PlayerBanState) GetCommunityBanned() bool {
+	if x != nil {
+		return x.CommunityBanned
+	}
+	return false
+}
+
+func (x *PlayerBanState) GetVacBanned() bool {
+	if x != nil {
+		return x.VacBanned
+	}
+	return false
+}
+
+func (x *PlayerBanState) GetNumberOfVacBans() int32 {
+	if x != nil {
+		return x.NumberOfVacBans
+	}
+	return 0
+}
+
+func (x *PlayerBanState) GetDaysSinceLastBan() int32 {
+	if x != nil {
+		return x.DaysSinceLastBan
+	}
+	return 0
+}
+
+func (x *PlayerBanState) GetNumberOfGameBans() int32 {
+	if x != nil {
+		return x.NumberOfGameBans
+	}
+	return 0
+}
+
+func (x *PlayerBanState) GetEconomyBan() string {
+	if x != nil {
+		return x.EconomyBan
+	}
+	return ""
+}
+
+var File_steamid_v1_steamid_proto protoreflect.FileDescriptor
+
+var file_steamid_v1_steamid_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x74, 0x65,
+	0x61, 0x6d, 0x69, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x74, 0x65, 0x61,
+	0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x22, 0x20, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x26, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x6f,
+	0x6c, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x22, 0x23, 0x0a, 0x0f, 0x53, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x65, 0x61, 0x6d,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x33, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x33, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x65, 0x61,
+	0x6d, 0x33, 0x32, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x74, 0x65, 0x61, 0x6d,
+	0x33, 0x32, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x36, 0x34, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x06, 0x52, 0x07, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x36, 0x34, 0x22, 0xa8, 0x02, 0x0a,
+	0x0d, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x31,
+	0x0a, 0x08, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x6c, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x61, 0x6c, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x55,
+	0x72, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x5f, 0x75, 0x72, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x55, 0x72,
+	0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0xb9, 0x02, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x42, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x73, 0x74,
+	0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73,
+	0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x29, 0x0a,
+	0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79, 0x5f, 0x62, 0x61, 0x6e, 0x6e, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69,
+	0x74, 0x79, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x63, 0x5f,
+	0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x76, 0x61,
+	0x63, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x12, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x76, 0x61, 0x63, 0x5f, 0x62, 0x61, 0x6e, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x56, 0x61, 0x63,
+	0x42, 0x61, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x64, 0x61, 0x79, 0x73, 0x5f, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x62, 0x61, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x10, 0x64, 0x61, 0x79, 0x73, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x4c, 0x61, 0x73, 0x74,
+	0x42, 0x61, 0x6e, 0x12, 0x2d, 0x0a, 0x13, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66,
+	0x5f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x62, 0x61, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x47, 0x61, 0x6d, 0x65, 0x42, 0x61,
+	0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x63, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x5f, 0x62, 0x61,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x63, 0x6f, 0x6e, 0x6f, 0x6d, 0x79,
+	0x42, 0x61, 0x6e, 0x32, 0x98, 0x02, 0x0a, 0x0e, 0x53, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x74, 0x12, 0x1a, 0x2e, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x07, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x12, 0x1a, 0x2e, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x6c, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x73,
+	0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x09, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x1b, 0x2e, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x04, 0x42,
+	0x61, 0x6e, 0x73, 0x12, 0x1b, 0x2e, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x42, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x30, 0x01, 0x42, 0x41,
+	0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x69,
+	0x67, 0x68, 0x6d, 0x61, 0x63, 0x64, 0x6f, 0x6e, 0x61, 0x6c, 0x64, 0x2f, 0x73, 0x74, 0x65, 0x61,
+	0x6d, 0x69, 0x64, 0x2f, 0x76, 0x34, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x74, 0x65,
+	0x61, 0x6d, 0x69, 0x64, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x74, 0x65, 0x61, 0x6d, 0x69, 0x64, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_steamid_v1_steamid_proto_rawDescOnce sync.Once
+	file_steamid_v1_steamid_proto_rawDescData = file_steamid_v1_steamid_proto_rawDesc
+)
+
+func file_steamid_v1_steamid_proto_rawDescGZIP() []byte {
+	file_steamid_v1_steamid_proto_rawDescOnce.Do(func() {
+		file_steamid_v1_steamid_proto_rawDescData = protoimpl.X.CompressGZIP(file_steamid_v1_steamid_proto_rawDescData)
+	})
+	return file_steamid_v1_steamid_proto_rawDescData
+}
+
+var file_steamid_v1_steamid_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_steamid_v1_steamid_proto_goTypes = []any{
+	(*ConvertRequest)(nil),  // 0: steamid.v1.ConvertRequest
+	(*ResolveRequest)(nil),  // 1: steamid.v1.ResolveRequest
+	(*SteamIDsRequest)(nil), // 2: steamid.v1.SteamIDsRequest
+	(*Conversion)(nil),      // 3: steamid.v1.Conversion
+	(*PlayerSummary)(nil),   // 4: steamid.v1.PlayerSummary
+	(*PlayerBanState)(nil),  // 5: steamid.v1.PlayerBanState
+}
+var file_steamid_v1_steamid_proto_depIdxs = []int32{
+	3, // 0: steamid.v1.PlayerSummary.steam_id:type_name -> steamid.v1.Conversion
+	3, // 1: steamid.v1.PlayerBanState.steam_id:type_name -> steamid.v1.Conversion
+	0, // 2: steamid.v1.SteamIDService.Convert:input_type -> steamid.v1.ConvertRequest
+	1, // 3: steamid.v1.SteamIDService.Resolve:input_type -> steamid.v1.ResolveRequest
+	2, // 4: steamid.v1.SteamIDService.Summaries:input_type -> steamid.v1.SteamIDsRequest
+	2, // 5: steamid.v1.SteamIDService.Bans:input_type -> steamid.v1.SteamIDsRequest
+	3, // 6: steamid.v1.SteamIDService.Convert:output_type -> steamid.v1.Conversion
+	3, // 7: steamid.v1.SteamIDService.Resolve:output_type -> steamid.v1.Conversion
+	4, // 8: steamid.v1.SteamIDService.Summaries:output_type -> steamid.v1.PlayerSummary
+	5, // 9: steamid.v1.SteamIDService.Bans:output_type -> steamid.v1.PlayerBanState
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_steamid_v1_steamid_proto_init() }
+func file_steamid_v1_steamid_proto_init() {
+	if File_steamid_v1_steamid_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_steamid_v1_steamid_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ConvertRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_steamid_v1_steamid_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ResolveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_steamid_v1_steamid_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*SteamIDsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_steamid_v1_steamid_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*Conversion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_steamid_v1_steamid_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*PlayerSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_steamid_v1_steamid_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*PlayerBanState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_steamid_v1_steamid_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_steamid_v1_steamid_proto_goTypes,
+		DependencyIndexes: file_steamid_v1_steamid_proto_depIdxs,
+		MessageInfos:      file_steamid_v1_steamid_proto_msgTypes,
+	}.Build()
+	File_steamid_v1_steamid_proto = out.File
+	file_steamid_v1_steamid_proto_rawDesc = nil
+	file_steamid_v1_steamid_proto_goTypes = nil
+	file_steamid_v1_steamid_proto_depIdxs = nil
+}
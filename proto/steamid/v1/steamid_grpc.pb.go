@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.1
+// source: steamid/v1/steamid.proto
+
+// Service definition for running the steamid resolver as a gRPC service. It mirrors the
+// endpoints of the `steamid serve` HTTP api and is served by `steamid serve --grpc`.
+//
+// Regenerate the go stubs with `make proto`.
+
+package steamidv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SteamIDService_Convert_FullMethodName   = "/steamid.v1.SteamIDService/Convert"
+	SteamIDService_Resolve_FullMethodName   = "/steamid.v1.SteamIDService/Resolve"
+	SteamIDService_Summaries_FullMethodName = "/steamid.v1.SteamIDService/Summaries"
+	SteamIDService_Bans_FullMethodName      = "/steamid.v1.SteamIDService/Bans"
+)
+
+// SteamIDServiceClient is the client API for SteamIDService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SteamIDServiceClient interface {
+	// Convert returns all representations of a steam id in any supported format.
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*Conversion, error)
+	// Resolve resolves a profile url, vanity name or steam id.
+	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*Conversion, error)
+	// Summaries streams the profile summaries of the requested steam ids.
+	Summaries(ctx context.Context, in *SteamIDsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlayerSummary], error)
+	// Bans streams the ban states of the requested steam ids.
+	Bans(ctx context.Context, in *SteamIDsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlayerBanState], error)
+}
+
+type steamIDServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSteamIDServiceClient(cc grpc.ClientConnInterface) SteamIDServiceClient {
+	return &steamIDServiceClient{cc}
+}
+
+func (c *steamIDServiceClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*Conversion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Conversion)
+	err := c.cc.Invoke(ctx, SteamIDService_Convert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *steamIDServiceClient) Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*Conversion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Conversion)
+	err := c.cc.Invoke(ctx, SteamIDService_Resolve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *steamIDServiceClient) Summaries(ctx context.Context, in *SteamIDsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlayerSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SteamIDService_ServiceDesc.Streams[0], SteamIDService_Summaries_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SteamIDsRequest, PlayerSummary]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SteamIDService_SummariesClient = grpc.ServerStreamingClient[PlayerSummary]
+
+func (c *steamIDServiceClient) Bans(ctx context.Context, in *SteamIDsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PlayerBanState], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SteamIDService_ServiceDesc.Streams[1], SteamIDService_Bans_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SteamIDsRequest, PlayerBanState]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SteamIDService_BansClient = grpc.ServerStreamingClient[PlayerBanState]
+
+// SteamIDServiceServer is the server API for SteamIDService service.
+// All implementations must embed UnimplementedSteamIDServiceServer
+// for forward compatibility.
+type SteamIDServiceServer interface {
+	// Convert returns all representations of a steam id in any supported format.
+	Convert(context.Context, *ConvertRequest) (*Conversion, error)
+	// Resolve resolves a profile url, vanity name or steam id.
+	Resolve(context.Context, *ResolveRequest) (*Conversion, error)
+	// Summaries streams the profile summaries of the requested steam ids.
+	Summaries(*SteamIDsRequest, grpc.ServerStreamingServer[PlayerSummary]) error
+	// Bans streams the ban states of the requested steam ids.
+	Bans(*SteamIDsRequest, grpc.ServerStreamingServer[PlayerBanState]) error
+	mustEmbedUnimplementedSteamIDServiceServer()
+}
+
+// UnimplementedSteamIDServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSteamIDServiceServer struct{}
+
+func (UnimplementedSteamIDServiceServer) Convert(context.Context, *ConvertRequest) (*Conversion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedSteamIDServiceServer) Resolve(context.Context, *ResolveRequest) (*Conversion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resolve not implemented")
+}
+func (UnimplementedSteamIDServiceServer) Summaries(*SteamIDsRequest, grpc.ServerStreamingServer[PlayerSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method Summaries not implemented")
+}
+func (UnimplementedSteamIDServiceServer) Bans(*SteamIDsRequest, grpc.ServerStreamingServer[PlayerBanState]) error {
+	return status.Errorf(codes.Unimplemented, "method Bans not implemented")
+}
+func (UnimplementedSteamIDServiceServer) mustEmbedUnimplementedSteamIDServiceServer() {}
+func (UnimplementedSteamIDServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeSteamIDServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SteamIDServiceServer will
+// result in compilation errors.
+type UnsafeSteamIDServiceServer interface {
+	mustEmbedUnimplementedSteamIDServiceServer()
+}
+
+func RegisterSteamIDServiceServer(s grpc.ServiceRegistrar, srv SteamIDServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSteamIDServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SteamIDService_ServiceDesc, srv)
+}
+
+func _SteamIDService_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SteamIDServiceServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SteamIDService_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SteamIDServiceServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SteamIDService_Resolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SteamIDServiceServer).Resolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SteamIDService_Resolve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SteamIDServiceServer).Resolve(ctx, req.(*ResolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SteamIDService_Summaries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SteamIDsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SteamIDServiceServer).Summaries(m, &grpc.GenericServerStream[SteamIDsRequest, PlayerSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SteamIDService_SummariesServer = grpc.ServerStreamingServer[PlayerSummary]
+
+func _SteamIDService_Bans_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SteamIDsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SteamIDServiceServer).Bans(m, &grpc.GenericServerStream[SteamIDsRequest, PlayerBanState]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SteamIDService_BansServer = grpc.ServerStreamingServer[PlayerBanState]
+
+// SteamIDService_ServiceDesc is the grpc.ServiceDesc for SteamIDService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SteamIDService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "steamid.v1.SteamIDService",
+	HandlerType: (*SteamIDServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Convert",
+			Handler:    _SteamIDService_Convert_Handler,
+		},
+		{
+			MethodName: "Resolve",
+			Handler:    _SteamIDService_Resolve_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Summaries",
+			Handler:       _SteamIDService_Summaries_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Bans",
+			Handler:       _SteamIDService_Bans_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "steamid/v1/steamid.proto",
+}
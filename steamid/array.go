@@ -0,0 +1,169 @@
+package steamid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Value implements driver.Valuer, encoding the ids as a postgres array literal such as
+// {76561198132612090,76561197960287930}, which can be stored in both bigint[] and text[] columns.
+// A nil collection is stored as NULL.
+func (c Collection) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	var builder strings.Builder
+
+	builder.WriteByte('{')
+
+	for idx, sid := range c {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+
+		builder.WriteString(sid.String())
+	}
+
+	builder.WriteByte('}')
+
+	return builder.String(), nil
+}
+
+// Scan implements sql.Scanner, decoding a one dimensional postgres array of ids in any format,
+// as returned for bigint[] and text[] columns. NULL elements are skipped and a NULL array results
+// in a nil collection.
+func (c *Collection) Scan(value any) error {
+	var literal string
+
+	switch input := value.(type) {
+	case nil:
+		*c = nil
+
+		return nil
+	case string:
+		literal = input
+	case []byte:
+		literal = string(input)
+	default:
+		return parseError(fmt.Sprint(value), fmt.Errorf("%w: unsupported type %T", ErrInvalidArray, value))
+	}
+
+	elements, errParse := parseArray(literal)
+	if errParse != nil {
+		return errParse
+	}
+
+	ids := make(Collection, 0, len(elements))
+
+	for _, element := range elements {
+		sid := New(element)
+		if !sid.Valid() {
+			return parseError(element, ErrInvalidSID)
+		}
+
+		ids = append(ids, sid)
+	}
+
+	*c = ids
+
+	return nil
+}
+
+// Value implements driver.Valuer, encoding the ids in ascending order like Collection.Value.
+func (s Set) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	return s.Collection().Value()
+}
+
+// Scan implements sql.Scanner, decoding the array like Collection.Scan.
+func (s *Set) Scan(value any) error {
+	var ids Collection
+	if errScan := ids.Scan(value); errScan != nil {
+		return errScan
+	}
+
+	if ids == nil {
+		*s = nil
+
+		return nil
+	}
+
+	*s = NewSet(ids...)
+
+	return nil
+}
+
+// parseArray returns the elements of a one dimensional postgres array literal, without the
+// NULL elements.
+func parseArray(literal string) ([]string, error) {
+	literal = strings.TrimSpace(literal)
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return nil, parseError(literal, ErrInvalidArray)
+	}
+
+	var (
+		body     = literal[1 : len(literal)-1]
+		elements []string
+		element  strings.Builder
+		quoted   bool
+		// wasQuoted tells a quoted "NULL" string apart from a NULL element.
+		wasQuoted bool
+	)
+
+	if strings.TrimSpace(body) == "" {
+		return nil, nil
+	}
+
+	for idx := 0; idx < len(body); idx++ {
+		switch char := body[idx]; {
+		case char == '\\' && quoted:
+			idx++
+			if idx == len(body) {
+				return nil, parseError(literal, ErrInvalidArray)
+			}
+
+			element.WriteByte(body[idx])
+		case char == '"':
+			quoted = !quoted
+			wasQuoted = true
+		case quoted:
+			element.WriteByte(char)
+		case char == '{' || char == '}':
+			return nil, parseError(literal, fmt.Errorf("%w: only one dimensional arrays are supported", ErrInvalidArray))
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			// Whitespace around elements is ignored, trailing whitespace of unquoted ones is trimmed later.
+			if !wasQuoted && element.Len() > 0 {
+				element.WriteByte(char)
+			}
+		case char == ',':
+			elements = appendElement(elements, element.String(), wasQuoted)
+			element.Reset()
+
+			wasQuoted = false
+		default:
+			element.WriteByte(char)
+		}
+	}
+
+	if quoted {
+		return nil, parseError(literal, ErrInvalidArray)
+	}
+
+	return appendElement(elements, element.String(), wasQuoted), nil
+}
+
+func appendElement(elements []string, element string, quoted bool) []string {
+	if !quoted {
+		element = strings.TrimSpace(element)
+		if strings.EqualFold(element, "NULL") {
+			return elements
+		}
+	}
+
+	return append(elements, element)
+}
@@ -0,0 +1,76 @@
+package steamid_test
+
+import (
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectionArray(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sidA = steamid.New(76561197960287930)
+		sidB = steamid.New(76561198132612090)
+	)
+
+	value, errValue := steamid.Collection{sidB, sidA}.Value()
+	require.NoError(t, errValue)
+	require.Equal(t, "{76561198132612090,76561197960287930}", value)
+
+	empty, errEmpty := steamid.Collection{}.Value()
+	require.NoError(t, errEmpty)
+	require.Equal(t, "{}", empty)
+
+	null, errNull := steamid.Collection(nil).Value()
+	require.NoError(t, errNull)
+	require.Nil(t, null)
+
+	for input, expected := range map[string]steamid.Collection{
+		"{76561198132612090,76561197960287930}":     {sidB, sidA},
+		`{"76561198132612090", "[U:1:22202]",NULL}`: {sidB, sidA},
+		`{"STEAM_0:0:86173181","\"[U:1:22202]\""}`:  nil,
+		"{}":                      {},
+		" { 76561198132612090 } ": {sidB},
+		`{"STEAM_0:0:86173181","[U:1:22202]","76561198132612090"}`: {sidB, sidA, sidB},
+	} {
+		var ids steamid.Collection
+
+		errScan := ids.Scan([]byte(input))
+		if expected == nil {
+			require.ErrorIs(t, errScan, steamid.ErrInvalidSID, input)
+
+			continue
+		}
+
+		require.NoError(t, errScan, input)
+		require.Equal(t, expected, ids, input)
+	}
+
+	for _, input := range []any{"76561198132612090", "{{1,2},{3,4}}", `{"76561198132612090}`, "{NULL", 12} {
+		var ids steamid.Collection
+		require.ErrorIs(t, ids.Scan(input), steamid.ErrInvalidArray, input)
+	}
+
+	var ids steamid.Collection
+	require.NoError(t, ids.Scan(nil))
+	require.Nil(t, ids)
+}
+
+func TestSetArray(t *testing.T) {
+	t.Parallel()
+
+	set := steamid.NewSet(steamid.New(76561198132612090), steamid.New(76561197960287930))
+
+	value, errValue := set.Value()
+	require.NoError(t, errValue)
+	require.Equal(t, "{76561197960287930,76561198132612090}", value)
+
+	var scanned steamid.Set
+	require.NoError(t, scanned.Scan(value))
+	require.Equal(t, set, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	require.Nil(t, scanned)
+}
@@ -0,0 +1,103 @@
+package steamid_test
+
+import (
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+//nolint:gochecknoglobals
+var benchSID steamid.SteamID
+
+//nolint:gochecknoglobals
+var benchString string
+
+func BenchmarkNew(b *testing.B) {
+	inputs := []struct {
+		name  string
+		value any
+	}{
+		{name: "steam64", value: "76561198132612090"},
+		{name: "steam64_int", value: int64(76561198132612090)},
+		{name: "steam", value: "STEAM_0:0:86173181"},
+		{name: "steam3", value: "[U:1:172346362]"},
+		{name: "steam32", value: "172346362"},
+		{name: "group", value: "[g:1:4]"},
+		{name: "invalid", value: "not a steam id"},
+	}
+
+	for _, input := range inputs {
+		b.Run(input.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				benchSID = steamid.New(input.value)
+			}
+		})
+	}
+}
+
+func BenchmarkFormat(b *testing.B) {
+	sid := steamid.New(76561198132612090)
+
+	b.Run("string", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			benchString = sid.String()
+		}
+	})
+
+	b.Run("steam", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			benchString = string(sid.Steam(false))
+		}
+	})
+
+	b.Run("steam3", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for range b.N {
+			benchString = string(sid.Steam3())
+		}
+	})
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	inputs := []struct {
+		name  string
+		value []byte
+	}{
+		{name: "steam64", value: []byte(`"76561198132612090"`)},
+		{name: "steam3", value: []byte(`"[U:1:172346362]"`)},
+		{name: "invalid", value: []byte(`"not a steam id"`)},
+	}
+
+	for _, input := range inputs {
+		b.Run(input.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for range b.N {
+				var sid steamid.SteamID
+				_ = sid.UnmarshalJSON(input.value)
+				benchSID = sid
+			}
+		})
+	}
+}
+
+func BenchmarkCollectionUnique(b *testing.B) {
+	ids := make(steamid.Collection, 0, 100000)
+	for idx := range cap(ids) {
+		// Every id appears twice.
+		ids = append(ids, steamid.New(int64(76561197960265728+idx%(cap(ids)/2))))
+	}
+
+	b.ReportAllocs()
+
+	for range b.N {
+		_ = ids.Unique()
+	}
+}
@@ -0,0 +1,64 @@
+package steamid
+
+import "runtime/debug"
+
+// modulePath is the path of the module, used to find its version among the dependencies of the
+// binary.
+const modulePath = "github.com/leighmacdonald/steamid/v4"
+
+// Build describes the version of the library compiled into the binary.
+type Build struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
+
+// BuildInfo returns the version of the library. The BuildVersion, BuildCommit and BuildDate values
+// set with -ldflags take precedence. When they are left unset, the version is read from the module
+// information embedded by the go toolchain: the module version when the library is a dependency or
+// was installed with go install, and the vcs revision and time when the binary was built from a
+// checkout.
+func BuildInfo() Build {
+	build := Build{Version: BuildVersion, Commit: BuildCommit, Date: BuildDate}
+
+	info, found := debug.ReadBuildInfo()
+	if !found {
+		return build
+	}
+
+	module := &info.Main
+	if module.Path != modulePath {
+		module = nil
+
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				module = dep
+				if dep.Replace != nil {
+					module = dep.Replace
+				}
+
+				break
+			}
+		}
+	}
+
+	if build.Version == "dev" && module != nil && module.Version != "" && module.Version != "(devel)" {
+		build.Version = module.Version
+	}
+
+	// The vcs settings describe the main module only.
+	if info.Main.Path != modulePath {
+		return build
+	}
+
+	for _, setting := range info.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && build.Commit == "master":
+			build.Commit = setting.Value
+		case setting.Key == "vcs.time" && build.Date == "":
+			build.Date = setting.Value
+		}
+	}
+
+	return build
+}
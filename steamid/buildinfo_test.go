@@ -0,0 +1,21 @@
+package steamid_test
+
+import (
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInfo(t *testing.T) {
+	require.NotEmpty(t, steamid.BuildInfo().Version)
+
+	version, commit, date := steamid.BuildVersion, steamid.BuildCommit, steamid.BuildDate
+	t.Cleanup(func() {
+		steamid.BuildVersion, steamid.BuildCommit, steamid.BuildDate = version, commit, date
+	})
+
+	// Values set with -ldflags take precedence over the embedded module information.
+	steamid.BuildVersion, steamid.BuildCommit, steamid.BuildDate = "v4.1.0", "abc123", "2024-01-02"
+	require.Equal(t, steamid.Build{Version: "v4.1.0", Commit: "abc123", Date: "2024-01-02"}, steamid.BuildInfo())
+}
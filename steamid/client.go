@@ -0,0 +1,632 @@
+//go:build !steamid_nonet
+
+package steamid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	apiBaseURL       = "https://api.steampowered.com"
+	communityBaseURL = "https://steamcommunity.com"
+	// MaxBatchIDs is the maximum number of ids the Steam Web API accepts in a single request.
+	MaxBatchIDs = 100
+	// vanityIndividual and vanityGroup are the url_type values of profile and group vanity urls.
+	vanityIndividual = "1"
+	vanityGroup      = "2"
+	// vanityNoMatch is the success value of a ResolveVanityURL response that found no match.
+	vanityNoMatch = 42
+	// apiKeyLength is the length of a Steam Web API key, which is hex encoded.
+	apiKeyLength = 32
+	// maxQueryLength is the longest encoded query sent with a GET request to a service interface.
+	// Longer inputs are sent as an input_json POST body instead, keeping well below the url length
+	// limits of the web api and proxies.
+	maxQueryLength = 2000
+)
+
+// Client performs Steam Web API requests. Unlike the package level functions, which share the key
+// configured with SetKey, each Client has its own key and http client.
+type Client struct {
+	apiKey       string
+	accessToken  string
+	httpClient   *http.Client
+	baseURL      string
+	communityURL string
+	requestHook  func(RequestInfo)
+	// keyErr is returned instead of ErrNoAPIKey by requests requiring a key, see envKeyErr.
+	keyErr error
+	// numericVanity resolves numbers below the steam64 range as vanity names first, see
+	// WithNumericVanity.
+	numericVanity bool
+	// coalesceWindow enables the summary and ban coalescers when positive, see WithCoalescing.
+	coalesceWindow   time.Duration
+	summaryCoalescer *coalescer[PlayerSummary]
+	banCoalescer     *coalescer[PlayerBanState]
+}
+
+// RequestInfo describes a completed request made by a Client, see WithRequestHook.
+type RequestInfo struct {
+	// Path is the request path without the query, e.g. /ISteamUser/GetPlayerBans/v1/.
+	Path string
+	// StatusCode is the response status code, or 0 when no response was received.
+	StatusCode int
+	// Duration is the time taken to receive the response headers.
+	Duration time.Duration
+	// Err is the error performing the request, if any.
+	Err error
+}
+
+// ClientOption configures optional Client settings.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the http client used to perform requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBaseURL overrides the Steam Web API base url, e.g. to use a proxy or a test server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithCommunityURL overrides the steam community base url used for requests that are not part of
+// the Steam Web API, such as group member lists.
+func WithCommunityURL(communityURL string) ClientOption {
+	return func(c *Client) {
+		c.communityURL = strings.TrimRight(communityURL, "/")
+	}
+}
+
+// WithRequestHook sets a function called after every request made by the client, e.g. to record
+// metrics. It is called from the goroutine performing the request, so it must be safe for
+// concurrent use.
+func WithRequestHook(hook func(RequestInfo)) ClientOption {
+	return func(c *Client) {
+		c.requestHook = hook
+	}
+}
+
+// WithNumericVanity makes Resolve look up numbers below the steam64 range, such as steam32 values,
+// as vanity names before converting them, for profiles whose custom url is a number. It requires an
+// api key, and the numeric conversion is used when no profile uses the name or the lookup fails.
+// Without it such numbers are always converted, as most of them are account ids.
+func WithNumericVanity() ClientOption {
+	return func(c *Client) {
+		c.numericVanity = true
+	}
+}
+
+// do performs the request, reporting it to the request hook if one is set.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, errDo := c.httpClient.Do(req)
+
+	if c.requestHook != nil {
+		info := RequestInfo{Path: req.URL.Path, Duration: time.Since(start), Err: errDo}
+		if resp != nil {
+			info.StatusCode = resp.StatusCode
+		}
+
+		c.requestHook(info)
+	}
+
+	return resp, errDo //nolint:wrapcheck
+}
+
+// normalizeKey trims the whitespace surrounding a pasted key and checks it is made of
+// apiKeyLength hexadecimal characters. The key is left out of the errors as it is a secret.
+func normalizeKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", nil
+	}
+
+	if len(key) != apiKeyLength {
+		return "", fmt.Errorf("%w: got %d chars", ErrInvalidKey, len(key))
+	}
+
+	for idx := range len(key) {
+		char := key[idx] | 0x20
+		if (char < '0' || char > '9') && (char < 'a' || char > 'f') {
+			return "", fmt.Errorf("%w: invalid char at position %d", ErrMalformedKey, idx+1)
+		}
+	}
+
+	return key, nil
+}
+
+// NewClient returns a client using the provided Steam Web API key. An empty key is allowed, in which
+// case functions requiring a key return ErrNoAPIKey, or use a user access token set with
+// WithAccessToken where an alternative endpoint exists. Surrounding whitespace is ignored, otherwise
+// ErrInvalidKey is returned for keys of the wrong length and ErrMalformedKey for keys holding
+// anything but hexadecimal characters.
+func NewClient(key string, opts ...ClientOption) (*Client, error) {
+	key, errKey := normalizeKey(key)
+	if errKey != nil {
+		return nil, errKey
+	}
+
+	client := &Client{
+		apiKey:       key,
+		httpClient:   &http.Client{Timeout: time.Second * 10},
+		baseURL:      apiBaseURL,
+		communityURL: communityBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if client.coalesceWindow > 0 {
+		client.summaryCoalescer = newCoalescer(client.coalesceWindow, client.PlayerSummaries, func(summary PlayerSummary) SteamID {
+			return summary.SteamID
+		})
+		client.banCoalescer = newCoalescer(client.coalesceWindow, client.PlayerBans, func(ban PlayerBanState) SteamID {
+			return ban.SteamID
+		})
+	}
+
+	return client, nil
+}
+
+// defaultClient returns a client using the package level key and http client.
+func defaultClient() *Client {
+	client := &Client{apiKey: currentKey(), httpClient: GetHTTP(), baseURL: apiBaseURL, communityURL: communityBaseURL}
+	if errKey := envKeyErr.Load(); errKey != nil {
+		client.keyErr = *errKey
+	}
+
+	return client
+}
+
+// errMissingKey returns the error of a request requiring a key made without one.
+func (c *Client) errMissingKey() error {
+	if c.keyErr != nil {
+		return c.keyErr
+	}
+
+	return ErrNoAPIKey
+}
+
+// get performs a GET request against the Steam Web API, decoding the JSON response into out. The
+// api key is added to the query when requireKey is true.
+func (c *Client) get(ctx context.Context, path string, values url.Values, requireKey bool, out any) error {
+	if values == nil {
+		values = url.Values{}
+	}
+
+	if requireKey {
+		if c.apiKey == "" {
+			return apiError(path, 0, c.errMissingKey())
+		}
+
+		values.Set("key", c.apiKey)
+	}
+
+	req, errReq := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+values.Encode(), nil)
+	if errReq != nil {
+		return apiError(path, 0, errors.Join(errReq, ErrRequestCreate))
+	}
+
+	return c.decode(req, path, out)
+}
+
+// post performs a POST request of the form values against the Steam Web API, decoding the JSON
+// response into out.
+func (c *Client) post(ctx context.Context, path string, values url.Values, out any) error {
+	req, errReq := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, strings.NewReader(values.Encode()))
+	if errReq != nil {
+		return apiError(path, 0, errors.Join(errReq, ErrRequestCreate))
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.decode(req, path, out)
+}
+
+// serviceInput holds the parameters of a service interface (I*Service) request. Values are
+// strings, numbers or booleans, or string slices which are sent as indexed parameters, e.g.
+// steamids[0].
+type serviceInput map[string]any
+
+// values encodes the input as query parameters.
+func (input serviceInput) values() url.Values {
+	values := url.Values{}
+
+	for name, value := range input {
+		if list, isList := value.([]string); isList {
+			for idx, item := range list {
+				values.Set(fmt.Sprintf("%s[%d]", name, idx), item)
+			}
+
+			continue
+		}
+
+		values.Set(name, fmt.Sprint(value))
+	}
+
+	return values
+}
+
+// callService performs a request against a service interface, decoding the JSON response into
+// out. The input is sent as query parameters, or as an input_json POST body when the encoded query
+// would exceed maxQueryLength, e.g. for large batches. The api key is added when requireKey is
+// true.
+func (c *Client) callService(ctx context.Context, path string, input serviceInput, requireKey bool, out any) error {
+	values := input.values()
+	if len(values.Encode()) <= maxQueryLength {
+		return c.get(ctx, path, values, requireKey, out)
+	}
+
+	body, errBody := json.Marshal(input)
+	if errBody != nil {
+		return apiError(path, 0, errors.Join(errBody, ErrRequestCreate))
+	}
+
+	form := url.Values{"input_json": {string(body)}}
+
+	if requireKey {
+		if c.apiKey == "" {
+			return apiError(path, 0, c.errMissingKey())
+		}
+
+		form.Set("key", c.apiKey)
+	}
+
+	return c.post(ctx, path, form, out)
+}
+
+// decode performs the request, decoding the JSON response into out.
+func (c *Client) decode(req *http.Request, path string, out any) error {
+	resp, errDo := c.do(req)
+	if errDo != nil {
+		return apiError(path, 0, errors.Join(errDo, ErrResponsePerform))
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return apiError(path, resp.StatusCode, ErrInvalidStatusCode)
+	}
+
+	if errDecode := json.NewDecoder(resp.Body).Decode(out); errDecode != nil {
+		return apiError(path, resp.StatusCode, errors.Join(errDecode, ErrResponseBody))
+	}
+
+	return nil
+}
+
+// resolveVanityURL resolves the vanity name using the ResolveVanityURL endpoint, where urlType
+// selects the kind of vanity url, vanityIndividual or vanityGroup.
+func (c *Client) resolveVanityURL(ctx context.Context, query string, urlType string) (SteamID, error) {
+	var vanityResp vanityURLResponse
+	if errGet := c.get(ctx, "/ISteamUser/ResolveVanityURL/v0001/",
+		url.Values{"vanityurl": {query}, "url_type": {urlType}}, true, &vanityResp); errGet != nil {
+		return SteamID{}, errGet
+	}
+
+	switch vanityResp.Response.Success {
+	case 1:
+	case vanityNoMatch:
+		return SteamID{}, resolveError(query, ErrVanityNotFound)
+	default:
+		return SteamID{}, apiError(query, vanityResp.Response.Success, ErrInvalidStatusCode)
+	}
+
+	if !vanityResp.Response.SteamID.Valid() {
+		return SteamID{}, apiError(query, vanityResp.Response.Success, ErrInvalidSID)
+	}
+
+	return vanityResp.Response.SteamID, nil
+}
+
+// ResolveVanity attempts to resolve the underlying SID64 of a users vanity url name
+// This only accepts the name or last portion of the /id/ profile link
+// For https://steamcommunity.com/id/SQUIRRELLY the value is SQUIRRELLY.
+//
+// ErrVanityNotFound is returned when no profile uses the name, so it can be told apart from
+// request failures which may be retried.
+func (c *Client) ResolveVanity(ctx context.Context, query string) (SteamID, error) {
+	return c.resolveVanityURL(ctx, query, vanityIndividual)
+}
+
+// parseProfileURL returns the kind, id or profiles, and the value of a steamcommunity.com profile
+// url. The scheme and www subdomain are optional, and trailing paths such as /badges, query strings
+// and fragments are ignored. False is returned when the query is not a steamcommunity.com url, and
+// an empty kind when it is not a profile url.
+func parseProfileURL(query string) (string, string, bool) {
+	if !strings.Contains(strings.ToLower(query), "steamcommunity.com") {
+		return "", "", false
+	}
+
+	if !strings.Contains(query, "://") {
+		query = "https://" + query
+	}
+
+	parsed, errParse := url.Parse(query)
+	if errParse != nil {
+		return "", "", false
+	}
+
+	if host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www."); host != "steamcommunity.com" {
+		return "", "", false
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 2 || (segments[0] != "id" && segments[0] != "profiles") {
+		return "", "", true
+	}
+
+	return segments[0], segments[1], true
+}
+
+// Resolver resolves profile urls, vanity names and ids to a SteamID. It is implemented by Client and
+// by the fake resolver of the steamidtest package.
+type Resolver interface {
+	Resolve(ctx context.Context, query string) (SteamID, error)
+}
+
+// Resolve tries to retrieve a SteamID from a profile URL.
+//
+// Profile urls may omit the scheme and www subdomain, and may include trailing paths such as
+// /badges, query strings and fragments. Queries that are neither a profile url nor a valid id are
+// resolved as vanity names. Trade offer urls are converted from their partner parameter.
+//
+// Numbers are converted as ids, and only those that do not convert to a valid id are resolved as
+// vanity names. This means that by default Resolve("123456789") returns the steam32 conversion
+// [U:1:123456789], even when a profile uses 123456789 as its custom url. To find such profiles,
+// create the client WithNumericVanity, which looks up numbers below the steam64 range as vanity
+// names first, or resolve them with their /id/ profile url or ResolveVanity.
+//
+// If an error occurs or the SteamID was unable to be resolved from the query
+// then am error is returned.
+func (c *Client) Resolve(ctx context.Context, query string) (SteamID, error) {
+	query = strings.ReplaceAll(query, " ", "")
+	if query == "" {
+		return SteamID{}, resolveError(query, ErrInvalidQueryValue)
+	}
+
+	if sid, errTrade := FromTradeURL(query); errTrade == nil {
+		return sid, nil
+	}
+
+	if kind, value, isProfileURL := parseProfileURL(query); isProfileURL {
+		switch {
+		case kind == "profiles" && strings.HasPrefix(value, "["):
+			// Steam redirects steam3 profile urls to their steam64 form, which is converted locally.
+			sid := New(value)
+			if !sid.Valid() {
+				return SteamID{}, resolveError(query, ErrInvalidQueryValue)
+			}
+
+			return sid, nil
+		case kind == "profiles":
+			output, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return SteamID{}, resolveError(query, errors.Join(err, ErrInvalidQueryValue))
+			}
+
+			if len(value) != 17 {
+				return SteamID{}, resolveError(query, ErrInvalidQueryLen)
+			}
+
+			return New(output), nil
+		case kind == "id" && value != "":
+			return c.ResolveVanity(ctx, value)
+		default:
+			return SteamID{}, resolveError(query, ErrInvalidQueryValue)
+		}
+	}
+
+	s := New(query)
+	if !s.Valid() {
+		return c.ResolveVanity(ctx, query)
+	}
+
+	if number, isNumber := parseDigits([]byte(query)); isNumber && number < BaseSID && c.numericVanity && c.apiKey != "" {
+		if vanity, errVanity := c.ResolveVanity(ctx, query); errVanity == nil {
+			return vanity, nil
+		}
+	}
+
+	return s, nil
+}
+
+// PlayerSummary is a single player from the ISteamUser/GetPlayerSummaries endpoint. Many of the
+// fields are only available when the profile is public.
+type PlayerSummary struct {
+	SteamID                  SteamID `json:"steamid"`
+	CommunityVisibilityState int     `json:"communityvisibilitystate"`
+	ProfileState             int     `json:"profilestate"`
+	PersonaName              string  `json:"personaname"`
+	CommentPermission        int     `json:"commentpermission"`
+	ProfileURL               string  `json:"profileurl"`
+	Avatar                   string  `json:"avatar"`
+	AvatarMedium             string  `json:"avatarmedium"`
+	AvatarFull               string  `json:"avatarfull"`
+	AvatarHash               string  `json:"avatarhash"`
+	LastLogoff               int64   `json:"lastlogoff"`
+	PersonaState             int     `json:"personastate"`
+	RealName                 string  `json:"realname"`
+	PrimaryClanID            string  `json:"primaryclanid"`
+	TimeCreated              int64   `json:"timecreated"`
+	PersonaStateFlags        int     `json:"personastateflags"`
+	LocCountryCode           string  `json:"loccountrycode"`
+	LocStateCode             string  `json:"locstatecode"`
+	LocCityID                int     `json:"loccityid"`
+	GameID                   string  `json:"gameid"`
+	GameExtraInfo            string  `json:"gameextrainfo"`
+	GameServerIP             string  `json:"gameserverip"`
+}
+
+// Created returns the time the account was created. The zero time is returned when it is
+// not visible.
+func (s PlayerSummary) Created() time.Time {
+	if s.TimeCreated <= 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(s.TimeCreated, 0)
+}
+
+// PlayerBanState is a single player from the ISteamUser/GetPlayerBans endpoint.
+type PlayerBanState struct {
+	SteamID          SteamID `json:"SteamId"`
+	CommunityBanned  bool    `json:"CommunityBanned"`
+	VACBanned        bool    `json:"VACBanned"`
+	NumberOfVACBans  int     `json:"NumberOfVACBans"`
+	DaysSinceLastBan int     `json:"DaysSinceLastBan"`
+	NumberOfGameBans int     `json:"NumberOfGameBans"`
+	EconomyBan       string  `json:"EconomyBan"`
+}
+
+// chunks de-duplicates the valid ids in the collection and splits them into batches of at most MaxBatchIDs.
+func chunks(steamIDs Collection) [][]string {
+	var (
+		batches [][]string
+		batch   []string
+		seen    = map[SteamID]bool{}
+	)
+
+	for _, sid := range steamIDs {
+		if !sid.Valid() || seen[sid] {
+			continue
+		}
+
+		seen[sid] = true
+
+		batch = append(batch, sid.String())
+		if len(batch) == MaxBatchIDs {
+			batches = append(batches, batch)
+			batch = nil
+		}
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches
+}
+
+// PlayerSummaries fetches the profile summaries of the provided steam ids. Requests for more than
+// MaxBatchIDs ids are split into multiple requests. Invalid and duplicate ids are ignored, as are
+// ids that do not exist, so fewer results than ids may be returned. Clients with an access token but
+// no api key fetch them with UserSummaries.
+func (c *Client) PlayerSummaries(ctx context.Context, steamIDs Collection) ([]PlayerSummary, error) {
+	if c.apiKey == "" && c.accessToken != "" {
+		return c.UserSummaries(ctx, steamIDs)
+	}
+
+	var summaries []PlayerSummary
+
+	for _, batch := range chunks(steamIDs) {
+		var resp struct {
+			Response struct {
+				Players []PlayerSummary `json:"players"`
+			} `json:"response"`
+		}
+
+		if errGet := c.get(ctx, "/ISteamUser/GetPlayerSummaries/v0002/",
+			url.Values{"steamids": {strings.Join(batch, ",")}}, true, &resp); errGet != nil {
+			return nil, errGet
+		}
+
+		summaries = append(summaries, resp.Response.Players...)
+	}
+
+	return summaries, nil
+}
+
+// PlayerBans fetches the VAC, game and community ban state of the provided steam ids. Requests for
+// more than MaxBatchIDs ids are split into multiple requests. Invalid and duplicate ids are ignored.
+func (c *Client) PlayerBans(ctx context.Context, steamIDs Collection) ([]PlayerBanState, error) {
+	var bans []PlayerBanState
+
+	for _, batch := range chunks(steamIDs) {
+		var resp struct {
+			Players []PlayerBanState `json:"players"`
+		}
+
+		if errGet := c.get(ctx, "/ISteamUser/GetPlayerBans/v1/",
+			url.Values{"steamids": {strings.Join(batch, ",")}}, true, &resp); errGet != nil {
+			return nil, errGet
+		}
+
+		bans = append(bans, resp.Players...)
+	}
+
+	return bans, nil
+}
+
+// PlayerSummaries fetches the profile summaries of the provided steam ids using the package level
+// api key. See Client.PlayerSummaries.
+func PlayerSummaries(ctx context.Context, steamIDs Collection) ([]PlayerSummary, error) {
+	return defaultClient().PlayerSummaries(ctx, steamIDs)
+}
+
+// PlayerBans fetches the ban state of the provided steam ids using the package level api key. See
+// Client.PlayerBans.
+func PlayerBans(ctx context.Context, steamIDs Collection) ([]PlayerBanState, error) {
+	return defaultClient().PlayerBans(ctx, steamIDs)
+}
+
+// ResolveResult is the result of resolving a single query with ResolveAll.
+type ResolveResult struct {
+	Query   string
+	SteamID SteamID
+	Err     error
+}
+
+// ResolveAll resolves the queries using a pool of concurrency workers, see Resolve. The results are
+// returned in the same order as the queries. A failure to resolve one query does not stop the others.
+func (c *Client) ResolveAll(ctx context.Context, queries []string, concurrency int) []ResolveResult {
+	var (
+		results = make([]ResolveResult, len(queries))
+		indexes = make(chan int)
+		wg      sync.WaitGroup
+	)
+
+	for range max(concurrency, 1) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for idx := range indexes {
+				sid, err := c.Resolve(ctx, queries[idx])
+				results[idx] = ResolveResult{Query: queries[idx], SteamID: sid, Err: err}
+			}
+		}()
+	}
+
+	for idx := range queries {
+		indexes <- idx
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// ResolveAll resolves the queries using the package level api key. See Client.ResolveAll.
+func ResolveAll(ctx context.Context, queries []string, concurrency int) []ResolveResult {
+	return defaultClient().ResolveAll(ctx, queries, concurrency)
+}
@@ -0,0 +1,360 @@
+//go:build !steamid_nonet
+
+package steamid_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/leighmacdonald/steamid/v4/steamid/steamidtest"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "0123456789ABCDEF0123456789ABCDEF"
+
+func newTestAPI(t testing.TB, handler http.HandlerFunc) *steamid.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := steamid.NewClient(testKey, steamid.WithBaseURL(server.URL), steamid.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	_, errKey := steamid.NewClient("short")
+	require.ErrorIs(t, errKey, steamid.ErrInvalidKey)
+
+	malformed := "0123456789ABCDEF0123456789ABCDEZ"
+	_, errMalformed := steamid.NewClient(malformed)
+	require.ErrorIs(t, errMalformed, steamid.ErrMalformedKey)
+	require.NotErrorIs(t, errMalformed, steamid.ErrInvalidKey)
+	require.NotContains(t, errMalformed.Error(), malformed)
+
+	_, errLower := steamid.NewClient(strings.ToLower(testKey))
+	require.NoError(t, errLower)
+
+	// Keys pasted with surrounding whitespace are sent trimmed.
+	server := steamidtest.NewServer(t)
+	padded, errPadded := steamid.NewClient(" "+steamidtest.Key+"\r\n", steamid.WithBaseURL(server.URL))
+	require.NoError(t, errPadded)
+
+	_, errBans := padded.PlayerBans(context.Background(), steamid.Collection{steamidtest.Individual})
+	require.NoError(t, errBans)
+
+	client, errEmpty := steamid.NewClient("")
+	require.NoError(t, errEmpty)
+
+	_, errBlank := steamid.NewClient(" \n")
+	require.NoError(t, errBlank)
+
+	_, errNoKey := client.PlayerSummaries(context.Background(), steamid.Collection{steamid.New(76561198132612090)})
+	require.ErrorIs(t, errNoKey, steamid.ErrNoAPIKey)
+}
+
+func TestClientPlayerSummaries(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		require.Equal(t, "/ISteamUser/GetPlayerSummaries/v0002/", r.URL.Path)
+		require.Equal(t, testKey, r.URL.Query().Get("key"))
+
+		ids := strings.Split(r.URL.Query().Get("steamids"), ",")
+		require.LessOrEqual(t, len(ids), steamid.MaxBatchIDs)
+
+		var players []string
+		for _, id := range ids {
+			players = append(players, fmt.Sprintf(`{"steamid":"%s","personaname":"p%s","timecreated":1600000000}`, id, id))
+		}
+
+		_, _ = fmt.Fprintf(w, `{"response":{"players":[%s]}}`, strings.Join(players, ","))
+	})
+
+	var ids steamid.Collection
+	for i := 1; i <= 150; i++ {
+		ids = append(ids, steamid.New(76561197960265728+int64(i)))
+	}
+
+	// duplicates and invalid ids are ignored
+	ids = append(ids, ids[0], steamid.New(""))
+
+	summaries, err := client.PlayerSummaries(context.Background(), ids)
+	require.NoError(t, err)
+	require.Equal(t, 2, requests)
+	require.Len(t, summaries, 150)
+	require.Equal(t, ids[0], summaries[0].SteamID)
+	require.Equal(t, time.Unix(1600000000, 0), summaries[0].Created())
+}
+
+func TestClientPlayerBans(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ISteamUser/GetPlayerBans/v1/", r.URL.Path)
+		_, _ = fmt.Fprint(w, `{"players":[{"SteamId":"76561198132612090","CommunityBanned":false,"VACBanned":true,`+
+			`"NumberOfVACBans":2,"DaysSinceLastBan":10,"NumberOfGameBans":0,"EconomyBan":"none"}]}`)
+	})
+
+	bans, err := client.PlayerBans(context.Background(), steamid.Collection{steamid.New(76561198132612090)})
+	require.NoError(t, err)
+	require.Equal(t, []steamid.PlayerBanState{{
+		SteamID:          steamid.New(76561198132612090),
+		VACBanned:        true,
+		NumberOfVACBans:  2,
+		DaysSinceLastBan: 10,
+		EconomyBan:       "none",
+	}}, bans)
+}
+
+func TestClientResolveVanity(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("vanityurl") == "SQUIRRELLY" {
+			_, _ = fmt.Fprint(w, `{"response":{"steamid":"76561197961279983","success":1}}`)
+
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"response":{"success":42,"message":"No match"}}`)
+	})
+
+	sid, err := client.ResolveVanity(context.Background(), "SQUIRRELLY")
+	require.NoError(t, err)
+	require.Equal(t, steamid.New(76561197961279983), sid)
+
+	_, errMissing := client.ResolveVanity(context.Background(), "FAKEXXXXXXXXXX123123")
+	require.ErrorIs(t, errMissing, steamid.ErrVanityNotFound)
+	require.NotErrorIs(t, errMissing, steamid.ErrInvalidStatusCode)
+
+	for _, query := range []string{
+		"https://steamcommunity.com/id/SQUIRRELLY/", "[U:1:1014255]", "https://steamcommunity.com/profiles/76561197961279983",
+		"steamcommunity.com/id/SQUIRRELLY", "www.steamcommunity.com/profiles/76561197961279983/badges",
+		"https://steamcommunity.com/id/SQUIRRELLY/?l=english", "http://www.SteamCommunity.com/id/SQUIRRELLY#top",
+		"https://steamcommunity.com/profiles/76561197961279983?l=english",
+		"https://steamcommunity.com/profiles/[U:1:1014255]", "steamcommunity.com/profiles/%5BU:1:1014255%5D/",
+	} {
+		resolved, errResolve := client.Resolve(context.Background(), query)
+		require.NoError(t, errResolve, query)
+		require.Equal(t, steamid.New(76561197961279983), resolved, query)
+	}
+
+	for _, query := range []string{"steamcommunity.com/id/", "https://steamcommunity.com/groups/SQUIRRELLY",
+		"steamcommunity.com/profiles/SQUIRRELLY", "steamcommunity.com/profiles/[U:1:0]", "steamcommunity.com/profiles/[U:1:"} {
+		_, errResolve := client.Resolve(context.Background(), query)
+		require.ErrorIs(t, errResolve, steamid.ErrInvalidQueryValue, query)
+	}
+
+	_, errLen := client.Resolve(context.Background(), "steamcommunity.com/profiles/7656119796127998")
+	require.ErrorIs(t, errLen, steamid.ErrInvalidQueryLen)
+}
+
+func TestClientResolveSteam3Profile(t *testing.T) {
+	t.Parallel()
+
+	server := steamidtest.NewServer(t)
+
+	sid, errResolve := server.Client(t).Resolve(context.Background(), "https://steamcommunity.com/profiles/[U:1:172346362]")
+	require.NoError(t, errResolve)
+	require.Equal(t, steamid.New(76561198132612090), sid)
+	require.Zero(t, server.Requests())
+}
+
+func TestClientResolveNumericVanityName(t *testing.T) {
+	t.Parallel()
+
+	server := steamidtest.NewServer(t)
+	server.AddVanity("123456789", steamidtest.Individual2)
+
+	client, errClient := steamid.NewClient(steamidtest.Key, steamid.WithBaseURL(server.URL), steamid.WithNumericVanity())
+	require.NoError(t, errClient)
+
+	// The profile whose custom url is literally 123456789 is found instead of [U:1:123456789].
+	sid, errResolve := client.Resolve(context.Background(), "123456789")
+	require.NoError(t, errResolve)
+	require.Equal(t, steamidtest.Individual2, sid)
+	require.Equal(t, 1, server.Requests())
+}
+
+func TestClientResolveNumericVanity(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = steamidtest.NewServer(t)
+		client = server.Client(t)
+		ctx    = context.Background()
+	)
+
+	server.AddVanity("123456789", steamidtest.Individual2)
+	server.AddVanity("4294967296", steamidtest.Individual)
+
+	// Numbers are converted as steam32 ids without a lookup.
+	sid, errResolve := client.Resolve(ctx, "123456789")
+	require.NoError(t, errResolve)
+	require.Equal(t, steamid.New("[U:1:123456789]"), sid)
+	require.Zero(t, server.Requests())
+
+	// Numeric vanity names are resolved with their profile url.
+	sid, errResolve = client.Resolve(ctx, "https://steamcommunity.com/id/123456789")
+	require.NoError(t, errResolve)
+	require.Equal(t, steamidtest.Individual2, sid)
+
+	// Values that do not convert to a valid id are resolved as vanity names.
+	sid, errResolve = client.Resolve(ctx, "4294967296")
+	require.NoError(t, errResolve)
+	require.Equal(t, steamidtest.Individual, sid)
+
+	numeric, errClient := steamid.NewClient(steamidtest.Key, steamid.WithBaseURL(server.URL), steamid.WithNumericVanity())
+	require.NoError(t, errClient)
+
+	// With WithNumericVanity numeric vanity names take precedence over the steam32 conversion.
+	sid, errResolve = numeric.Resolve(ctx, "123456789")
+	require.NoError(t, errResolve)
+	require.Equal(t, steamidtest.Individual2, sid)
+
+	sid, errResolve = numeric.Resolve(ctx, "22202")
+	require.NoError(t, errResolve)
+	require.Equal(t, steamid.New(76561197960287930), sid)
+
+	requests := server.Requests()
+
+	// Steam64 values are never looked up.
+	sid, errResolve = numeric.Resolve(ctx, "76561198132612090")
+	require.NoError(t, errResolve)
+	require.Equal(t, steamidtest.Individual2, sid)
+	require.Equal(t, requests, server.Requests())
+
+	// Without a key steam32 values are converted without a lookup.
+	keyless, errClient := steamid.NewClient("", steamid.WithBaseURL(server.URL), steamid.WithNumericVanity())
+	require.NoError(t, errClient)
+
+	sid, errResolve = keyless.Resolve(ctx, "123456789")
+	require.NoError(t, errResolve)
+	require.Equal(t, steamid.New("[U:1:123456789]"), sid)
+	require.Equal(t, requests, server.Requests())
+}
+
+func TestClientResolveAll(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("vanityurl") == "SQUIRRELLY" {
+			_, _ = fmt.Fprint(w, `{"response":{"steamid":"76561197961279983","success":1}}`)
+
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"response":{"success":42,"message":"No match"}}`)
+	})
+
+	queries := []string{"SQUIRRELLY", "missing", "[U:1:22202]"}
+	for i := 0; i < 20; i++ {
+		queries = append(queries, "SQUIRRELLY")
+	}
+
+	results := client.ResolveAll(context.Background(), queries, 4)
+	require.Len(t, results, len(queries))
+	require.Equal(t, steamid.ResolveResult{Query: "SQUIRRELLY", SteamID: steamid.New(76561197961279983)}, results[0])
+	require.ErrorIs(t, results[1].Err, steamid.ErrVanityNotFound)
+	require.Equal(t, steamid.New(76561197960287930), results[2].SteamID)
+
+	for _, result := range results[3:] {
+		require.NoError(t, result.Err)
+		require.Equal(t, steamid.New(76561197961279983), result.SteamID)
+	}
+}
+
+func TestClientRequestHook(t *testing.T) {
+	t.Parallel()
+
+	var requests []steamid.RequestInfo
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	client, errClient := steamid.NewClient(testKey, steamid.WithBaseURL(server.URL), steamid.WithHTTPClient(server.Client()),
+		steamid.WithRequestHook(func(info steamid.RequestInfo) {
+			requests = append(requests, info)
+		}))
+	require.NoError(t, errClient)
+
+	_, err := client.PlayerBans(context.Background(), steamid.Collection{steamid.New(76561198132612090)})
+	require.ErrorIs(t, err, steamid.ErrInvalidStatusCode)
+	require.Len(t, requests, 1)
+	require.Equal(t, "/ISteamUser/GetPlayerBans/v1/", requests[0].Path)
+	require.Equal(t, http.StatusTooManyRequests, requests[0].StatusCode)
+	require.NoError(t, requests[0].Err)
+}
+
+func TestClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = steamidtest.NewServer(t)
+		client = server.Client(t)
+		ctx    = context.Background()
+	)
+
+	_, errMissing := client.Resolve(ctx, "https://steamcommunity.com/id/unknown")
+	requireError(t, errMissing, steamid.ErrResolve, steamid.ErrVanityNotFound, "unknown")
+
+	_, errLength := client.Resolve(ctx, "steamcommunity.com/profiles/7656119796028793")
+	requireError(t, errLength, steamid.ErrResolve, steamid.ErrInvalidQueryLen, "steamcommunity.com/profiles/7656119796028793")
+
+	badKey, errClient := steamid.NewClient("FEDCBA9876543210FEDCBA9876543210", steamid.WithBaseURL(server.URL))
+	require.NoError(t, errClient)
+
+	_, errForbidden := badKey.PlayerBans(ctx, steamid.Collection{steamidtest.Individual})
+	sidErr := requireError(t, errForbidden, steamid.ErrAPI, steamid.ErrInvalidStatusCode, "/ISteamUser/GetPlayerBans/v1/")
+	require.Equal(t, http.StatusForbidden, sidErr.Code)
+	require.NotContains(t, errForbidden.Error(), "FEDCBA9876543210FEDCBA9876543210")
+
+	noKey, errClient := steamid.NewClient("", steamid.WithBaseURL(server.URL))
+	require.NoError(t, errClient)
+
+	_, errNoKey := noKey.PlayerSummaries(ctx, steamid.Collection{steamidtest.Individual})
+	sidErr = requireError(t, errNoKey, steamid.ErrAPI, steamid.ErrNoAPIKey, "/ISteamUser/GetPlayerSummaries/v0002/")
+	require.Zero(t, sidErr.Code)
+}
+
+func TestClientResolveTradeURL(t *testing.T) {
+	t.Parallel()
+
+	server := steamidtest.NewServer(t)
+
+	sid, errResolve := server.Client(t).Resolve(context.Background(), steamidtest.Individual2.TradeURL("AbCdEfGh"))
+	require.NoError(t, errResolve)
+	require.Equal(t, steamidtest.Individual2, sid)
+	require.Zero(t, server.Requests())
+}
+
+func FuzzResolve(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	client := newTestAPI(f, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"response":{"success":42,"message":"No match"}}`)
+	})
+
+	f.Fuzz(func(_ *testing.T, query string) {
+		_, _ = client.Resolve(context.Background(), query)
+	})
+}
@@ -0,0 +1,183 @@
+//go:build !steamid_nonet
+
+package steamid
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// coalescedBatch is the set of ids buffered by a coalescer during a single window, along with the
+// results of the request made for them once done is closed.
+type coalescedBatch[T any] struct {
+	steamIDs Collection
+	once     sync.Once
+	done     chan struct{}
+	results  map[SteamID]T
+	err      error
+}
+
+// coalescer buffers the single id lookups made within a window and performs them with a single
+// batch request.
+type coalescer[T any] struct {
+	window time.Duration
+	fetch  func(ctx context.Context, steamIDs Collection) ([]T, error)
+	key    func(result T) SteamID
+
+	mu      sync.Mutex
+	pending *coalescedBatch[T]
+}
+
+func newCoalescer[T any](window time.Duration, fetch func(context.Context, Collection) ([]T, error),
+	key func(T) SteamID,
+) *coalescer[T] {
+	return &coalescer[T]{window: window, fetch: fetch, key: key}
+}
+
+// get adds the id to the pending batch, starting a new one if none is pending, and waits for its
+// result. The batch is requested when the window elapses or it reaches MaxBatchIDs ids. found is
+// false when the id was not returned by the api.
+func (c *coalescer[T]) get(ctx context.Context, sid SteamID) (T, bool, error) {
+	c.mu.Lock()
+
+	batch := c.pending
+	if batch == nil {
+		batch = &coalescedBatch[T]{done: make(chan struct{})}
+		c.pending = batch
+
+		time.AfterFunc(c.window, func() {
+			c.flush(batch)
+		})
+	}
+
+	batch.steamIDs = append(batch.steamIDs, sid)
+	if len(batch.steamIDs) >= MaxBatchIDs {
+		c.pending = nil
+
+		go c.flush(batch)
+	}
+
+	c.mu.Unlock()
+
+	var zero T
+
+	select {
+	case <-ctx.Done():
+		return zero, false, ctx.Err() //nolint:wrapcheck
+	case <-batch.done:
+	}
+
+	if batch.err != nil {
+		return zero, false, batch.err
+	}
+
+	result, found := batch.results[sid]
+
+	return result, found, nil
+}
+
+// flush requests the ids of the batch, once. The request is not bound to the context of any of
+// the callers, since others may still be waiting for it.
+func (c *coalescer[T]) flush(batch *coalescedBatch[T]) {
+	batch.once.Do(func() {
+		c.mu.Lock()
+		if c.pending == batch {
+			c.pending = nil
+		}
+		c.mu.Unlock()
+
+		results, errFetch := c.fetch(context.Background(), batch.steamIDs)
+
+		batch.err = errFetch
+		batch.results = make(map[SteamID]T, len(results))
+
+		for _, result := range results {
+			batch.results[c.key(result)] = result
+		}
+
+		close(batch.done)
+	})
+}
+
+// WithCoalescing buffers the single id lookups of PlayerSummary and PlayerBan for the window, e.g.
+// 20ms, and performs the ids requested by all callers during it with a single batch request. This
+// cuts the number of requests made when many independent callers, such as the handlers of
+// concurrent web requests, each look up one player. Lookups are delayed by up to the window.
+func WithCoalescing(window time.Duration) ClientOption {
+	return func(c *Client) {
+		c.coalesceWindow = window
+	}
+}
+
+// PlayerSummary fetches the profile summary of a single steam id. ErrPlayerNotFound is returned
+// for ids that do not exist. When the client was created with WithCoalescing, the lookup is
+// combined with those of other callers.
+func (c *Client) PlayerSummary(ctx context.Context, sid SteamID) (PlayerSummary, error) {
+	if !sid.Valid() {
+		return PlayerSummary{}, parseError(sid.String(), ErrInvalidSID)
+	}
+
+	var (
+		summary PlayerSummary
+		found   bool
+		errGet  error
+	)
+
+	if c.summaryCoalescer != nil {
+		summary, found, errGet = c.summaryCoalescer.get(ctx, sid)
+	} else {
+		var summaries []PlayerSummary
+
+		summaries, errGet = c.PlayerSummaries(ctx, Collection{sid})
+		if found = len(summaries) > 0; found {
+			summary = summaries[0]
+		}
+	}
+
+	if errGet != nil {
+		return PlayerSummary{}, errGet
+	}
+
+	if !found {
+		return PlayerSummary{}, resolveError(sid.String(), ErrPlayerNotFound)
+	}
+
+	return summary, nil
+}
+
+// PlayerBan fetches the ban state of a single steam id. ErrPlayerNotFound is returned for ids that
+// do not exist. When the client was created with WithCoalescing, the lookup is combined with those
+// of other callers.
+func (c *Client) PlayerBan(ctx context.Context, sid SteamID) (PlayerBanState, error) {
+	if !sid.Valid() {
+		return PlayerBanState{}, parseError(sid.String(), ErrInvalidSID)
+	}
+
+	var (
+		ban    PlayerBanState
+		found  bool
+		errGet error
+	)
+
+	if c.banCoalescer != nil {
+		ban, found, errGet = c.banCoalescer.get(ctx, sid)
+	} else {
+		var bans []PlayerBanState
+
+		bans, errGet = c.PlayerBans(ctx, Collection{sid})
+		if found = len(bans) > 0; found {
+			ban = bans[0]
+		}
+	}
+
+	if errGet != nil {
+		return PlayerBanState{}, errGet
+	}
+
+	if !found {
+		return PlayerBanState{}, resolveError(sid.String(), ErrPlayerNotFound)
+	}
+
+	return ban, nil
+}
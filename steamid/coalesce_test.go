@@ -0,0 +1,100 @@
+//go:build !steamid_nonet
+
+package steamid_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCoalescing(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		var players []map[string]any
+
+		for _, sid := range strings.Split(r.URL.Query().Get("steamids"), ",") {
+			if sid != "76561197960287930" {
+				players = append(players, map[string]any{"steamid": sid, "SteamId": sid, "personaname": sid, "NumberOfVACBans": 1})
+			}
+		}
+
+		if r.URL.Path == "/ISteamUser/GetPlayerBans/v1/" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"players": players})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"response": map[string]any{"players": players}})
+	}))
+	t.Cleanup(server.Close)
+
+	client, errClient := steamid.NewClient(testKey, steamid.WithBaseURL(server.URL), steamid.WithCoalescing(time.Millisecond*200))
+	require.NoError(t, errClient)
+
+	var (
+		ctx       = context.Background()
+		wg        sync.WaitGroup
+		summaries = make([]steamid.PlayerSummary, 150)
+		errs      = make([]error, 150)
+	)
+
+	for idx := range summaries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			summaries[idx], errs[idx] = client.PlayerSummary(ctx, steamid.New(76561198132612090+uint64(idx)))
+		}()
+	}
+
+	wg.Wait()
+
+	for idx, summary := range summaries {
+		require.NoError(t, errs[idx])
+		require.Equal(t, steamid.New(76561198132612090+uint64(idx)), summary.SteamID)
+	}
+
+	// The lookups fill one full batch, which is requested immediately, and a partial one.
+	require.EqualValues(t, 2, requests.Load())
+
+	ban, errBan := client.PlayerBan(ctx, steamid.New(76561198132612090))
+	require.NoError(t, errBan)
+	require.Equal(t, 1, ban.NumberOfVACBans)
+
+	_, errMissing := client.PlayerSummary(ctx, steamid.New(76561197960287930))
+	require.ErrorIs(t, errMissing, steamid.ErrPlayerNotFound)
+	require.ErrorIs(t, errMissing, steamid.ErrResolve)
+
+	_, errInvalid := client.PlayerBan(ctx, steamid.SteamID{})
+	require.ErrorIs(t, errInvalid, steamid.ErrInvalidSID)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, errCancelled := client.PlayerSummary(cancelled, steamid.New(76561198132612090))
+	require.ErrorIs(t, errCancelled, context.Canceled)
+
+	// Without coalescing, each lookup is a request of its own.
+	direct := newTestAPI(t, server.Config.Handler.ServeHTTP)
+	before := requests.Load()
+
+	_, errDirect := direct.PlayerBan(ctx, steamid.New(76561198132612090))
+	require.NoError(t, errDirect)
+	require.Equal(t, before+1, requests.Load())
+}
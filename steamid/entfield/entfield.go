@@ -0,0 +1,78 @@
+// Package entfield provides a steam id field type for ent schemas. It does not depend on ent, the
+// type is declared with field.Other and the column types of SchemaType:
+//
+//	func (Player) Fields() []ent.Field {
+//		return []ent.Field{
+//			field.Other("steam_id", entfield.SteamID{}).SchemaType(entfield.SchemaType()).Unique(),
+//			field.Other("owner_id", &entfield.SteamID{}).SchemaType(entfield.SchemaType()).Optional().Nillable(),
+//		}
+//	}
+//
+// Ids are validated when they are stored and when they are read, so a field never holds an
+// invalid id. Optional fields should be nillable, as the zero value is not a valid id.
+package entfield
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// SchemaType returns the column type of a SteamID field for each ent sql dialect, keyed by the
+// dialect names of the entgo.io/ent/dialect package.
+func SchemaType() map[string]string {
+	return map[string]string{
+		"mysql":    "bigint",
+		"postgres": "bigint",
+		"sqlite3":  "integer",
+	}
+}
+
+// SteamID is a steamid.SteamID stored as a steam64 in a bigint column.
+type SteamID struct {
+	steamid.SteamID
+}
+
+// New returns the field value of the id.
+func New(sid steamid.SteamID) SteamID {
+	return SteamID{SteamID: sid}
+}
+
+// Value implements driver.Valuer, refusing to store invalid ids.
+func (t SteamID) Value() (driver.Value, error) {
+	if errValid := t.Validate(); errValid != nil {
+		return nil, errValid //nolint:wrapcheck
+	}
+
+	return t.Int64(), nil
+}
+
+// Scan implements sql.Scanner. Values of any steam id format are accepted, including the []byte
+// values returned by some drivers, but not NULL or invalid ids.
+func (t *SteamID) Scan(value any) error {
+	var sid steamid.SteamID
+
+	switch input := value.(type) {
+	case int64:
+		sid = steamid.New(input)
+	case string:
+		sid = steamid.New(input)
+	case []byte:
+		value = string(input)
+		sid = steamid.New(value)
+	default:
+		return steamid.Error{
+			Kind: steamid.ErrParse, Input: fmt.Sprint(value),
+			Err: fmt.Errorf("%w: unsupported type %T", steamid.ErrInvalidSID, value),
+		}
+	}
+
+	if errValid := sid.Validate(); errValid != nil {
+		return steamid.Error{Kind: steamid.ErrParse, Input: fmt.Sprint(value), Err: errValid}
+	}
+
+	t.SteamID = sid
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package entfield_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/leighmacdonald/steamid/v4/steamid/entfield"
+	"github.com/stretchr/testify/require"
+)
+
+// valueScanner is the interface ent requires of field.Other types.
+type valueScanner interface {
+	driver.Valuer
+	sql.Scanner
+}
+
+func TestSteamID(t *testing.T) {
+	t.Parallel()
+
+	var (
+		_   valueScanner = &entfield.SteamID{}
+		sid              = steamid.New(76561198132612090)
+	)
+
+	value, errValue := entfield.New(sid).Value()
+	require.NoError(t, errValue)
+	require.Equal(t, sid.Int64(), value)
+
+	_, errInvalid := entfield.SteamID{}.Value()
+	require.ErrorIs(t, errInvalid, steamid.ErrInvalidSID)
+
+	for _, input := range []any{sid.Int64(), "[U:1:172346362]", []byte("76561198132612090")} {
+		var field entfield.SteamID
+		require.NoError(t, field.Scan(input))
+		require.Equal(t, sid, field.SteamID)
+	}
+
+	for _, input := range []any{nil, int64(0), "not an id", 1.5} {
+		var field entfield.SteamID
+		require.ErrorIs(t, field.Scan(input), steamid.ErrInvalidSID)
+		require.False(t, field.Valid())
+	}
+
+	require.Equal(t, "bigint", entfield.SchemaType()["postgres"])
+}
@@ -0,0 +1,79 @@
+package steamid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The categories of errors returned by the package. Failures to parse, resolve or request a value
+// are returned as an Error of one of them, so callers can tell bad input, unknown names and web api
+// failures apart with errors.Is, while the specific cause, such as ErrInvalidSID or
+// ErrVanityNotFound, is matched the same way. Invalid api keys passed to NewClient and SetKey are
+// configuration errors and are returned as is.
+var (
+	// ErrParse is the category of input that is not a valid steam id, friend code or encoding of one.
+	ErrParse = errors.New("parse error")
+	// ErrResolve is the category of queries, vanity names and group urls that can not be resolved.
+	ErrResolve = errors.New("resolve error")
+	// ErrAPI is the category of failed Steam Web API and steam community requests.
+	ErrAPI = errors.New("api error")
+)
+
+// Error describes a failure along with the input that caused it. It matches both its Kind and Err
+// with errors.Is, and is retrieved with errors.As:
+//
+//	var sidErr steamid.Error
+//	if errors.As(err, &sidErr) && errors.Is(err, steamid.ErrAPI) && sidErr.Code == http.StatusTooManyRequests {
+//		// retry later
+//	}
+type Error struct {
+	// Kind is the category of the error, one of ErrParse, ErrResolve or ErrAPI.
+	Kind error
+	// Code is the http status code, or the success value of the response, of ErrAPI errors. It is 0
+	// when no response was received.
+	Code int
+	// Input is the value that failed to parse, the query that failed to resolve, or the path of the
+	// failed request. Api keys are never included.
+	Input string
+	// Err is the specific cause, such as ErrInvalidSID.
+	Err error
+}
+
+func (e Error) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString(e.Kind.Error())
+
+	if e.Code != 0 {
+		builder.WriteString(fmt.Sprintf(" (%d)", e.Code))
+	}
+
+	builder.WriteString(fmt.Sprintf(": %q", e.Input))
+
+	if e.Err != nil {
+		builder.WriteString(": " + e.Err.Error())
+	}
+
+	return builder.String()
+}
+
+func (e Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// parseError returns an ErrParse error for the input.
+func parseError(input string, err error) error {
+	return Error{Kind: ErrParse, Input: input, Err: err}
+}
+
+// resolveError returns an ErrResolve error for the query.
+func resolveError(query string, err error) error {
+	return Error{Kind: ErrResolve, Input: query, Err: err}
+}
+
+// apiError returns an ErrAPI error for the request path or query, with the status code of the
+// response, if any.
+func apiError(input string, code int, err error) error {
+	return Error{Kind: ErrAPI, Code: code, Input: input, Err: err}
+}
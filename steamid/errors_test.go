@@ -0,0 +1,46 @@
+package steamid_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+// requireError checks the error is a steamid.Error of the kind and cause, returning it.
+func requireError(t *testing.T, err error, kind error, cause error, input string) steamid.Error {
+	t.Helper()
+
+	var sidErr steamid.Error
+
+	require.ErrorIs(t, err, kind)
+	require.ErrorIs(t, err, cause)
+	require.True(t, errors.As(err, &sidErr), err)
+	require.Equal(t, input, sidErr.Input)
+	require.Contains(t, err.Error(), input)
+
+	return sidErr
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	_, errString := steamid.SID64FromString("7656119796028793x")
+	requireError(t, errString, steamid.ErrParse, steamid.ErrSIDConvertInt64, "7656119796028793x")
+
+	_, errFriend := steamid.FromFriendCode("SUCVS-FADB")
+	requireError(t, errFriend, steamid.ErrParse, steamid.ErrInvalidFriendCode, "SUCVS-FADB")
+
+	var sid steamid.SteamID
+	requireError(t, json.Unmarshal([]byte(`0`), &sid), steamid.ErrParse, steamid.ErrInvalidSID, "0")
+	require.ErrorIs(t, json.Unmarshal([]byte(`"STEAM_0:0:"`), &sid), steamid.ErrUnmarshalStringSID)
+	requireError(t, sid.Scan("[U:1:0]"), steamid.ErrParse, steamid.ErrInvalidSID, "[U:1:0]")
+
+	var ids steamid.Collection
+	requireError(t, ids.Scan("{22202,bad}"), steamid.ErrParse, steamid.ErrInvalidSID, "bad")
+
+	require.NotErrorIs(t, errFriend, steamid.ErrResolve)
+	require.NotErrorIs(t, errFriend, steamid.ErrAPI)
+}
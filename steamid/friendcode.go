@@ -0,0 +1,105 @@
+package steamid
+
+import (
+	"crypto/md5" //nolint:gosec
+	"encoding/binary"
+	"math/bits"
+	"strings"
+)
+
+const (
+	friendCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	// friendCodePrefix is the part of every encoded friend code that is dropped when shown to users.
+	friendCodePrefix = "AAAA-"
+)
+
+// friendCodeHash returns the hash used to fill the check bits of a friend code.
+func friendCodeHash(accountID SID32) uint32 {
+	buf := binary.LittleEndian.AppendUint32(nil, uint32(accountID))
+	sum := md5.Sum(append(buf, "OGSC"...)) //nolint:gosec
+
+	return binary.LittleEndian.Uint32(sum[:4])
+}
+
+// FriendCode converts the id to the friend code shown in the CS2 and CS:GO friends list.
+// e.g. 76561197960287930 -> SUCVS-FADA
+//
+// An empty string is returned for ids that are not valid individual accounts.
+func (t *SteamID) FriendCode() string {
+	if !t.Valid() || t.AccountType != AccountTypeIndividual {
+		return ""
+	}
+
+	var (
+		hash   = friendCodeHash(t.AccountID)
+		result uint64
+	)
+
+	for idx := 0; idx < 8; idx++ {
+		idNibble := uint64(t.AccountID>>(idx*4)) & 0xF
+		hashBit := uint64(hash>>idx) & 0x1
+		value := (result << 4) | idNibble
+
+		result = ((result >> 28) << 32) | value
+		result = ((result >> 31) << 32) | ((value << 1) | hashBit)
+	}
+
+	result = bits.ReverseBytes64(result)
+
+	var code strings.Builder
+
+	for idx := 0; idx < 13; idx++ {
+		if idx == 4 || idx == 9 {
+			code.WriteByte('-')
+		}
+
+		code.WriteByte(friendCodeAlphabet[result&31])
+		result >>= 5
+	}
+
+	return strings.TrimPrefix(code.String(), friendCodePrefix)
+}
+
+// FromFriendCode converts a CS2 or CS:GO friend code to a steam id. Both the short form (SUCVS-FADA)
+// and the full form (AAAA-SUCVS-FADA) are accepted, ignoring case.
+func FromFriendCode(input string) (SteamID, error) {
+	code := strings.ToUpper(strings.TrimSpace(input))
+	if !strings.HasPrefix(code, friendCodePrefix) {
+		code = friendCodePrefix + code
+	}
+
+	chars := strings.ReplaceAll(code, "-", "")
+	if len(code) != 15 || len(chars) != 13 || code[4] != '-' || code[10] != '-' {
+		return invalidSID, parseError(input, ErrInvalidFriendCode)
+	}
+
+	var result uint64
+
+	for idx := 0; idx < len(chars); idx++ {
+		value := strings.IndexByte(friendCodeAlphabet, chars[idx])
+		if value < 0 {
+			return invalidSID, parseError(input, ErrInvalidFriendCode)
+		}
+
+		result |= uint64(value) << (5 * idx)
+	}
+
+	result = bits.ReverseBytes64(result)
+
+	var accountID uint64
+
+	for idx := 0; idx < 8; idx++ {
+		result >>= 1
+		accountID = (accountID << 4) | (result & 0xF)
+		result >>= 4
+	}
+
+	sid := New(BaseSID + accountID)
+
+	// The hash bits act as a checksum, so mistyped codes decode to an id with a different code.
+	if strings.TrimPrefix(code, friendCodePrefix) != sid.FriendCode() {
+		return invalidSID, parseError(input, ErrInvalidFriendCode)
+	}
+
+	return sid, nil
+}
@@ -0,0 +1,36 @@
+package steamid_test
+
+import (
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFriendCode(t *testing.T) {
+	t.Parallel()
+
+	sid := steamid.New(76561197960287930)
+	require.Equal(t, "SUCVS-FADA", sid.FriendCode())
+
+	for _, code := range []string{"SUCVS-FADA", "sucvs-fada", "AAAA-SUCVS-FADA", " SUCVS-FADA\n"} {
+		decoded, errDecode := steamid.FromFriendCode(code)
+		require.NoError(t, errDecode, code)
+		require.Equal(t, sid, decoded)
+	}
+
+	for range 100 {
+		random := steamid.RandSID64()
+		decoded, errDecode := steamid.FromFriendCode(random.FriendCode())
+		require.NoError(t, errDecode)
+		require.Equal(t, random, decoded)
+	}
+
+	for _, code := range []string{"", "SUCVS", "SUCVSFADA", "SUCVS-FAD1", "SUCVS-FADB", "AAAB-SUCVS-FADA"} {
+		_, errDecode := steamid.FromFriendCode(code)
+		require.ErrorIs(t, errDecode, steamid.ErrInvalidFriendCode, code)
+	}
+
+	gid := steamid.New(103582791429521412)
+	require.Equal(t, "", gid.FriendCode())
+}
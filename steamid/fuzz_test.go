@@ -0,0 +1,61 @@
+package steamid_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzSeeds are real world ids along with truncated and malformed variations of them.
+var fuzzSeeds = []string{ //nolint:gochecknoglobals
+	"STEAM_0:0:86173181", "STEAM_1:1:507127", "[U:1:172346362]", "[g:1:4]", "[A:1:1234:5678]", "[G:1:3414356]",
+	"172346362", "76561198132612090", "103582791441572968", "85568392923453780",
+	"https://steamcommunity.com/profiles/76561198132612090", "https://steamcommunity.com/id/SQUIRRELLY/",
+	"", " ", "STEAM_", "STEAM_0:", "STEAM_0:0:", "[U:1:", "[U:1:]", "[:1:1]", "[U::1]", "[", "]", "-1", "0",
+	"18446744073709551615", "99999999999999999999", "SUCVS-FADA", "steamcommunity.com/profiles/", "/",
+	"www.steamcommunity.com/profiles/76561198132612090/badges?l=english", "steamcommunity.com/id/SQUIRRELLY#top",
+	"https://steamcommunity.com/profiles/[U:1:172346362]",
+}
+
+func FuzzNew(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		sid := steamid.New(input)
+		_ = sid.Steam3()
+		_ = sid.Steam(true)
+		_ = sid.FriendCode()
+
+		if sid.Valid() {
+			require.Equal(t, sid, steamid.New(sid.String()))
+			require.Equal(t, sid, steamid.New(sid.Int64()))
+
+			fromCode, errCode := steamid.FromShortCode(sid.ShortCode())
+			require.NoError(t, errCode)
+			require.Equal(t, sid, fromCode)
+		}
+
+		_, _ = steamid.SID64FromString(input)
+		_, _ = steamid.FromFriendCode(input)
+
+		if fromCode, errCode := steamid.FromShortCode(input); errCode == nil {
+			require.Equal(t, strings.TrimSpace(input), fromCode.ShortCode())
+		}
+
+		var decoded steamid.SteamID
+		_ = decoded.UnmarshalJSON([]byte(input))
+
+		quoted, errMarshal := json.Marshal(input)
+		require.NoError(t, errMarshal)
+
+		var fromJSON steamid.SteamID
+		if fromJSON.UnmarshalJSON(quoted) == nil {
+			require.Equal(t, steamid.New(input), fromJSON)
+		}
+	})
+}
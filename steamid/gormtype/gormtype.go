@@ -0,0 +1,112 @@
+// Package gormtype provides steam id field types for gorm models. The column type of each field is
+// set for every dialect, so AutoMigrate creates it correctly without a type tag, and ids that are
+// not valid, such as the zero value, are stored as NULL.
+//
+//	type Player struct {
+//		SteamID gormtype.SteamID `gorm:"primaryKey"`
+//		Owner   gormtype.Text
+//	}
+package gormtype
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// maxSteam64Length is the length of the largest steam64 value.
+const maxSteam64Length = 20
+
+// SteamID is a steamid.SteamID stored as a steam64 in a BIGINT column.
+type SteamID struct {
+	steamid.SteamID
+}
+
+// New returns the field value of the id.
+func New(sid steamid.SteamID) SteamID {
+	return SteamID{SteamID: sid}
+}
+
+// GormDataType implements schema.GormDataTypeInterface.
+func (SteamID) GormDataType() string {
+	return string(schema.Int)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface. Sqlite uses integer, so a primary key
+// becomes an alias of the rowid.
+func (SteamID) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	if db.Dialector.Name() == "sqlite" {
+		return "integer"
+	}
+
+	return "bigint"
+}
+
+// Value implements driver.Valuer.
+func (t SteamID) Value() (driver.Value, error) {
+	if !t.Valid() {
+		return nil, nil
+	}
+
+	return t.Int64(), nil
+}
+
+// Scan implements sql.Scanner.
+func (t *SteamID) Scan(value any) error {
+	return scan(&t.SteamID, value)
+}
+
+// Text is a steamid.SteamID stored as a steam64 string, for schemas keeping ids in text columns.
+type Text struct {
+	steamid.SteamID
+}
+
+// NewText returns the field value of the id.
+func NewText(sid steamid.SteamID) Text {
+	return Text{SteamID: sid}
+}
+
+// GormDataType implements schema.GormDataTypeInterface.
+func (Text) GormDataType() string {
+	return string(schema.String)
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface. Mysql and sql server use a varchar,
+// which unlike their text types can be indexed.
+func (Text) GormDBDataType(db *gorm.DB, _ *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "mysql", "sqlserver":
+		return fmt.Sprintf("varchar(%d)", maxSteam64Length)
+	default:
+		return "text"
+	}
+}
+
+// Value implements driver.Valuer.
+func (t Text) Value() (driver.Value, error) {
+	if !t.Valid() {
+		return nil, nil
+	}
+
+	return t.String(), nil
+}
+
+// Scan implements sql.Scanner.
+func (t *Text) Scan(value any) error {
+	return scan(&t.SteamID, value)
+}
+
+// scan reads a column value, which may be returned as bytes by some drivers, into the id.
+func scan(sid *steamid.SteamID, value any) error {
+	switch input := value.(type) {
+	case []byte:
+		return sid.Scan(string(input)) //nolint:wrapcheck
+	case uint64:
+		return sid.Scan(int64(input)) //nolint:wrapcheck,gosec
+	default:
+		return sid.Scan(value) //nolint:wrapcheck
+	}
+}
@@ -0,0 +1,77 @@
+package gormtype_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/leighmacdonald/steamid/v4/steamid/gormtype"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type player struct {
+	SteamID gormtype.SteamID `gorm:"primaryKey"`
+	Owner   gormtype.Text
+	Friend  gormtype.SteamID
+}
+
+func TestGormTypes(t *testing.T) {
+	t.Parallel()
+
+	db, errOpen := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")),
+		&gorm.Config{Logger: logger.Discard})
+	require.NoError(t, errOpen)
+	require.NoError(t, db.AutoMigrate(&player{}))
+
+	columns, errColumns := db.Migrator().ColumnTypes(&player{})
+	require.NoError(t, errColumns)
+
+	types := map[string]string{}
+	for _, column := range columns {
+		types[column.Name()] = strings.ToLower(column.DatabaseTypeName())
+	}
+
+	require.Equal(t, map[string]string{"steam_id": "integer", "owner": "text", "friend": "integer"}, types)
+
+	var (
+		sid   = steamid.New(76561198132612090)
+		owner = steamid.New("[U:1:22202]")
+	)
+
+	require.NoError(t, db.Create(&player{SteamID: gormtype.New(sid), Owner: gormtype.NewText(owner)}).Error)
+
+	var stored player
+	require.NoError(t, db.First(&stored, "steam_id = ?", sid.Int64()).Error)
+	require.Equal(t, sid, stored.SteamID.SteamID)
+	require.Equal(t, owner, stored.Owner.SteamID)
+	require.False(t, stored.Friend.Valid())
+
+	var raw struct {
+		Owner  string
+		Friend *int64
+	}
+
+	require.NoError(t, db.Table("players").Select("owner", "friend").Take(&raw).Error)
+	require.Equal(t, owner.String(), raw.Owner)
+	require.Nil(t, raw.Friend)
+}
+
+func TestScanBytes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sid  gormtype.SteamID
+		text gormtype.Text
+	)
+
+	require.NoError(t, sid.Scan([]byte("76561198132612090")))
+	require.NoError(t, text.Scan([]byte("76561198132612090")))
+	require.Equal(t, sid.SteamID, text.SteamID)
+	require.NoError(t, sid.Scan(nil))
+	require.False(t, sid.Valid())
+	require.Error(t, text.Scan([]byte("not an id")))
+}
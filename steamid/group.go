@@ -0,0 +1,218 @@
+//go:build !steamid_nonet
+
+package steamid
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxGroupPages limits how many member list pages GroupMembers will request. The member list
+// has 1000 members per page.
+const maxGroupPages = 10000
+
+// Group is the summary of a steam group from its community member list.
+type Group struct {
+	GroupID       SteamID
+	Name          string
+	URL           string
+	Headline      string
+	Summary       string
+	AvatarFull    string
+	MemberCount   int
+	MembersInChat int
+	MembersInGame int
+	MembersOnline int
+}
+
+type memberListXML struct {
+	XMLName   xml.Name `xml:"memberList"`
+	GroupID64 string   `xml:"groupID64"`
+	Details   struct {
+		Name          string `xml:"groupName"`
+		URL           string `xml:"groupURL"`
+		Headline      string `xml:"headline"`
+		Summary       string `xml:"summary"`
+		AvatarFull    string `xml:"avatarFull"`
+		MemberCount   int    `xml:"memberCount"`
+		MembersInChat int    `xml:"membersInChat"`
+		MembersInGame int    `xml:"membersInGame"`
+		MembersOnline int    `xml:"membersOnline"`
+	} `xml:"groupDetails"`
+	TotalPages int      `xml:"totalPages"`
+	Members    []string `xml:"members>steamID64"`
+}
+
+// groupPath returns the community path of a group from a group url, gid or vanity name.
+func groupPath(query string) string {
+	query = strings.TrimSpace(query)
+
+	for _, prefix := range []string{"steamcommunity.com/gid/", "steamcommunity.com/groups/"} {
+		if idx := strings.Index(query, prefix); idx >= 0 {
+			name := query[idx+len(prefix):]
+			if end := strings.IndexAny(name, "/?#"); end >= 0 {
+				name = name[:end]
+			}
+
+			return "/" + strings.Split(prefix, "/")[1] + "/" + url.PathEscape(name)
+		}
+	}
+
+	if gid := New(query); gid.Valid() && gid.AccountType == AccountTypeClan {
+		return "/gid/" + gid.String()
+	}
+
+	return "/groups/" + url.PathEscape(query)
+}
+
+// ResolveGID resolves the id of a group from its url, vanity name or any format of group id. Ids
+// and /gid/ urls are converted without a request. Vanity names are resolved with the web api when
+// the client has an api key, otherwise from the group member list on the community site.
+//
+// ErrVanityNotFound is returned when no group uses the name.
+func (c *Client) ResolveGID(ctx context.Context, query string) (SteamID, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return SteamID{}, resolveError(query, ErrInvalidQueryValue)
+	}
+
+	if gid := New(query); gid.Valid() && gid.AccountType == AccountTypeClan {
+		return gid, nil
+	}
+
+	kind, escaped, _ := strings.Cut(strings.TrimPrefix(groupPath(query), "/"), "/")
+
+	name, errUnescape := url.PathUnescape(escaped)
+	if errUnescape != nil || name == "" {
+		return SteamID{}, resolveError(query, ErrInvalidQueryValue)
+	}
+
+	if kind == "gid" {
+		gid := New(name)
+		if !gid.Valid() || gid.AccountType != AccountTypeClan {
+			return SteamID{}, resolveError(query, ErrInvalidGID)
+		}
+
+		return gid, nil
+	}
+
+	if c.apiKey != "" {
+		gid, errResolve := c.resolveVanityURL(ctx, name, vanityGroup)
+		if errResolve != nil {
+			return SteamID{}, errResolve
+		}
+
+		if gid.AccountType != AccountTypeClan {
+			return SteamID{}, resolveError(query, fmt.Errorf("%w: %s", ErrInvalidGID, gid.String()))
+		}
+
+		return gid, nil
+	}
+
+	group, errGroup := c.GroupDetails(ctx, name)
+	if errors.Is(errGroup, ErrResolveVanityGID) {
+		return SteamID{}, errors.Join(errGroup, ErrVanityNotFound)
+	}
+
+	return group.GroupID, errGroup
+}
+
+// memberListPage fetches a single page of a group member list.
+func (c *Client) memberListPage(ctx context.Context, query string, page int) (memberListXML, error) {
+	var list memberListXML
+
+	path := groupPath(query) + "/memberslistxml/"
+
+	req, errReq := http.NewRequestWithContext(ctx, http.MethodGet, c.communityURL+path+"?xml=1&p="+strconv.Itoa(page), nil)
+	if errReq != nil {
+		return list, apiError(path, 0, errors.Join(errReq, ErrRequestCreate))
+	}
+
+	resp, errDo := c.do(req)
+	if errDo != nil {
+		return list, apiError(path, 0, errors.Join(errDo, ErrResponsePerform))
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return list, apiError(path, resp.StatusCode, ErrInvalidStatusCode)
+	}
+
+	if errDecode := xml.NewDecoder(resp.Body).Decode(&list); errDecode != nil {
+		// Unknown groups return a html error page instead of xml.
+		return list, resolveError(query, errors.Join(errDecode, ErrResolveVanityGID))
+	}
+
+	return list, nil
+}
+
+// GroupDetails fetches the summary of a group. The query may be a group url, any format of
+// group id or the group vanity name.
+func (c *Client) GroupDetails(ctx context.Context, query string) (Group, error) {
+	list, errList := c.memberListPage(ctx, query, 1)
+	if errList != nil {
+		return Group{}, errList
+	}
+
+	gid := New(list.GroupID64)
+	if !gid.Valid() || gid.AccountType != AccountTypeClan {
+		return Group{}, resolveError(query, fmt.Errorf("%w: %s", ErrInvalidGID, list.GroupID64))
+	}
+
+	return Group{
+		GroupID:       gid,
+		Name:          list.Details.Name,
+		URL:           list.Details.URL,
+		Headline:      list.Details.Headline,
+		Summary:       list.Details.Summary,
+		AvatarFull:    list.Details.AvatarFull,
+		MemberCount:   list.Details.MemberCount,
+		MembersInChat: list.Details.MembersInChat,
+		MembersInGame: list.Details.MembersInGame,
+		MembersOnline: list.Details.MembersOnline,
+	}, nil
+}
+
+// GroupMembers fetches the steam ids of all members of a group, requesting each page of the member
+// list in turn. The query may be a group url, any format of group id or the group vanity name.
+func (c *Client) GroupMembers(ctx context.Context, query string) (Collection, error) {
+	var members Collection
+
+	for page := 1; page <= maxGroupPages; page++ {
+		list, errList := c.memberListPage(ctx, query, page)
+		if errList != nil {
+			return nil, errList
+		}
+
+		for _, member := range list.Members {
+			if sid := New(member); sid.Valid() {
+				members = append(members, sid)
+			}
+		}
+
+		if page >= list.TotalPages {
+			break
+		}
+	}
+
+	return members, nil
+}
+
+// GroupDetails fetches the summary of a group. See Client.GroupDetails.
+func GroupDetails(ctx context.Context, query string) (Group, error) {
+	return defaultClient().GroupDetails(ctx, query)
+}
+
+// GroupMembers fetches the steam ids of all members of a group. See Client.GroupMembers.
+func GroupMembers(ctx context.Context, query string) (Collection, error) {
+	return defaultClient().GroupMembers(ctx, query)
+}
@@ -0,0 +1,129 @@
+//go:build !steamid_nonet
+
+package steamid_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/leighmacdonald/steamid/v4/steamid/steamidtest"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCommunity(t *testing.T) *steamid.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/groups/valve/memberslistxml/" && r.URL.Path != "/gid/103582791429521412/memberslistxml/" {
+			_, _ = fmt.Fprint(w, "<!DOCTYPE html><html></html>")
+
+			return
+		}
+
+		page := r.URL.Query().Get("p")
+		_, _ = fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<memberList>
+<groupID64>103582791429521412</groupID64>
+<groupDetails>
+<groupName><![CDATA[Valve]]></groupName>
+<groupURL><![CDATA[Valve]]></groupURL>
+<memberCount>3</memberCount>
+<membersOnline>1</membersOnline>
+</groupDetails>
+<memberCount>3</memberCount>
+<totalPages>2</totalPages>
+<currentPage>%s</currentPage>
+<members>
+<steamID64>7656119796026573%s</steamID64>
+<steamID64>7656119796026574%s</steamID64>
+</members>
+</memberList>`, page, page, page)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := steamid.NewClient("", steamid.WithCommunityURL(server.URL), steamid.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestClientGroupDetails(t *testing.T) {
+	t.Parallel()
+
+	client := newTestCommunity(t)
+
+	for _, query := range []string{"valve", "https://steamcommunity.com/groups/valve/members", "103582791429521412", "[g:1:4]"} {
+		group, err := client.GroupDetails(context.Background(), query)
+		require.NoError(t, err, query)
+		require.Equal(t, steamid.New(103582791429521412), group.GroupID)
+		require.Equal(t, "Valve", group.Name)
+		require.Equal(t, 3, group.MemberCount)
+	}
+
+	_, errMissing := client.GroupDetails(context.Background(), "missing")
+	require.ErrorIs(t, errMissing, steamid.ErrResolveVanityGID)
+}
+
+func TestClientGroupMembers(t *testing.T) {
+	t.Parallel()
+
+	members, err := newTestCommunity(t).GroupMembers(context.Background(), "valve")
+	require.NoError(t, err)
+	require.Equal(t, steamid.Collection{
+		steamid.New(76561197960265731), steamid.New(76561197960265741),
+		steamid.New(76561197960265732), steamid.New(76561197960265742),
+	}, members)
+}
+
+func TestClientResolveGID(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx    = context.Background()
+		server = steamidtest.NewServer(t)
+		client = server.Client(t)
+	)
+
+	// Ids and gid urls are converted without a request.
+	for _, query := range []string{"103582791429521412", "[g:1:4]", "https://steamcommunity.com/gid/103582791429521412/members"} {
+		gid, err := client.ResolveGID(ctx, query)
+		require.NoError(t, err, query)
+		require.Equal(t, steamidtest.Clan, gid)
+	}
+
+	require.Zero(t, server.Requests())
+
+	// With a key vanity names are resolved with the web api.
+	server.AddGroupVanity("valve", steamidtest.Clan)
+	server.AddVanity("player", steamidtest.Individual)
+
+	for _, query := range []string{"valve", "https://steamcommunity.com/groups/valve/members?l=english"} {
+		gid, err := client.ResolveGID(ctx, query)
+		require.NoError(t, err, query)
+		require.Equal(t, steamidtest.Clan, gid)
+	}
+
+	_, errMissing := client.ResolveGID(ctx, "player")
+	require.ErrorIs(t, errMissing, steamid.ErrVanityNotFound)
+
+	_, errGID := client.ResolveGID(ctx, "https://steamcommunity.com/gid/76561197960287930")
+	require.ErrorIs(t, errGID, steamid.ErrInvalidGID)
+
+	_, errEmpty := client.ResolveGID(ctx, " ")
+	require.ErrorIs(t, errEmpty, steamid.ErrInvalidQueryValue)
+
+	// Without a key they are resolved from the community member list.
+	community := newTestCommunity(t)
+
+	gid, errCommunity := community.ResolveGID(ctx, "valve")
+	require.NoError(t, errCommunity)
+	require.Equal(t, steamidtest.Clan, gid)
+
+	_, errNotFound := community.ResolveGID(ctx, "missing")
+	require.ErrorIs(t, errNotFound, steamid.ErrVanityNotFound)
+	require.ErrorIs(t, errNotFound, steamid.ErrResolveVanityGID)
+}
@@ -0,0 +1,119 @@
+// Package httpbind parses steam ids from the path parameters and query strings of http requests.
+// Ids of any format are accepted, and failures are returned as an Error which carries the 400
+// status to respond with.
+//
+// Path parameters of the standard library router are read by FromRequest and FromPath. Other
+// routers are supported without depending on them:
+//
+//	// chi
+//	sid, err := httpbind.FromURLParam(r, "id", chi.URLParam)
+//	// gin and echo contexts both implement Params
+//	sid, err := httpbind.FromParams(c, "id")
+package httpbind
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// ErrMissingParam is returned when the request has no value for the parameter.
+var ErrMissingParam = errors.New("missing parameter")
+
+// Error describes a parameter that did not hold a valid steam id. It unwraps to ErrMissingParam or
+// a steamid.Error matching steamid.ErrParse and steamid.ErrInvalidSID.
+type Error struct {
+	// Param is the name of the parameter.
+	Param string
+	// Value is the raw value of the parameter.
+	Value string
+	Err   error
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Param, e.Err)
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the http status to respond with, which is always http.StatusBadRequest.
+func (e Error) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Params is implemented by the request contexts of routers such as gin and echo.
+type Params interface {
+	Param(name string) string
+}
+
+// Parse parses the value of the named parameter.
+func Parse(param string, value string) (steamid.SteamID, error) {
+	if value == "" {
+		return steamid.SteamID{}, Error{Param: param, Value: value, Err: ErrMissingParam}
+	}
+
+	sid := steamid.New(value)
+	if !sid.Valid() {
+		return steamid.SteamID{}, Error{Param: param, Value: value, Err: steamid.Error{
+			Kind: steamid.ErrParse, Input: value, Err: steamid.ErrInvalidSID,
+		}}
+	}
+
+	return sid, nil
+}
+
+// FromRequest parses the path parameter of the request, falling back to the query string when the
+// route has no value for it.
+func FromRequest(r *http.Request, param string) (steamid.SteamID, error) {
+	if value := r.PathValue(param); value != "" {
+		return Parse(param, value)
+	}
+
+	return FromQuery(r, param)
+}
+
+// FromPath parses the path parameter of a route registered with http.ServeMux.
+func FromPath(r *http.Request, param string) (steamid.SteamID, error) {
+	return Parse(param, r.PathValue(param))
+}
+
+// FromQuery parses the first value of the query string parameter.
+func FromQuery(r *http.Request, param string) (steamid.SteamID, error) {
+	return Parse(param, r.URL.Query().Get(param))
+}
+
+// FromURLParam parses the parameter returned by urlParam, such as chi.URLParam.
+func FromURLParam(r *http.Request, param string, urlParam func(r *http.Request, key string) string) (steamid.SteamID, error) {
+	return Parse(param, urlParam(r, param))
+}
+
+// FromParams parses the parameter of a router context, such as a *gin.Context or echo.Context.
+func FromParams(params Params, param string) (steamid.SteamID, error) {
+	return Parse(param, params.Param(param))
+}
+
+// FromQueryAll parses every value of the query string parameter, failing on the first that is not
+// a valid steam id.
+func FromQueryAll(r *http.Request, param string) (steamid.Collection, error) {
+	values := r.URL.Query()[param]
+	if len(values) == 0 {
+		return nil, Error{Param: param, Err: ErrMissingParam}
+	}
+
+	ids := make(steamid.Collection, 0, len(values))
+
+	for _, value := range values {
+		sid, errParse := Parse(param, value)
+		if errParse != nil {
+			return nil, errParse
+		}
+
+		ids = append(ids, sid)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,103 @@
+package httpbind_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/leighmacdonald/steamid/v4/steamid/httpbind"
+	"github.com/stretchr/testify/require"
+)
+
+type testParams map[string]string
+
+func (p testParams) Param(name string) string {
+	return p[name]
+}
+
+func TestFromRequest(t *testing.T) {
+	t.Parallel()
+
+	var (
+		expected = steamid.New(76561197960287930)
+		results  = map[string]error{}
+		mux      = http.NewServeMux()
+	)
+
+	mux.HandleFunc("GET /path/{id}", func(_ http.ResponseWriter, r *http.Request) {
+		sid, err := httpbind.FromRequest(r, "id")
+		if err == nil {
+			require.Equal(t, expected, sid)
+		}
+
+		results[r.URL.String()] = err
+	})
+	mux.HandleFunc("GET /query", func(_ http.ResponseWriter, r *http.Request) {
+		sid, err := httpbind.FromRequest(r, "id")
+		if err == nil {
+			require.Equal(t, expected, sid)
+		}
+
+		results[r.URL.String()] = err
+	})
+
+	for _, target := range []string{
+		"/path/76561197960287930", "/path/STEAM_0:0:11101", "/path/%5BU:1:22202%5D",
+		"/query?id=22202", "/query?id=bad", "/query", "/path/bad",
+	} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	for _, valid := range []string{"/path/76561197960287930", "/path/STEAM_0:0:11101", "/path/%5BU:1:22202%5D", "/query?id=22202"} {
+		require.NoError(t, results[valid], valid)
+	}
+
+	require.ErrorIs(t, results["/query"], httpbind.ErrMissingParam)
+
+	for _, invalid := range []string{"/query?id=bad", "/path/bad"} {
+		var bindErr httpbind.Error
+
+		require.ErrorIs(t, results[invalid], steamid.ErrInvalidSID, invalid)
+		require.ErrorIs(t, results[invalid], steamid.ErrParse, invalid)
+		require.True(t, errors.As(results[invalid], &bindErr))
+		require.Equal(t, "bad", bindErr.Value)
+		require.Equal(t, http.StatusBadRequest, bindErr.StatusCode())
+	}
+}
+
+func TestFromParams(t *testing.T) {
+	t.Parallel()
+
+	params := testParams{"id": "[U:1:22202]", "bad": "STEAM_0:0:"}
+
+	sid, errParse := httpbind.FromParams(params, "id")
+	require.NoError(t, errParse)
+	require.Equal(t, steamid.New(76561197960287930), sid)
+
+	_, errParse = httpbind.FromParams(params, "bad")
+	require.ErrorIs(t, errParse, steamid.ErrInvalidSID)
+
+	_, errParse = httpbind.FromParams(params, "missing")
+	require.ErrorIs(t, errParse, httpbind.ErrMissingParam)
+
+	sid, errParse = httpbind.FromURLParam(httptest.NewRequest(http.MethodGet, "/", nil), "id",
+		func(_ *http.Request, key string) string { return params[key] })
+	require.NoError(t, errParse)
+	require.Equal(t, steamid.New(76561197960287930), sid)
+}
+
+func TestFromQueryAll(t *testing.T) {
+	t.Parallel()
+
+	ids, errParse := httpbind.FromQueryAll(httptest.NewRequest(http.MethodGet, "/?id=22202&id=STEAM_0:1:1", nil), "id")
+	require.NoError(t, errParse)
+	require.Equal(t, steamid.Collection{steamid.New(76561197960287930), steamid.New("STEAM_0:1:1")}, ids)
+
+	_, errParse = httpbind.FromQueryAll(httptest.NewRequest(http.MethodGet, "/?id=22202&id=bad", nil), "id")
+	require.ErrorIs(t, errParse, steamid.ErrInvalidSID)
+
+	_, errParse = httpbind.FromQueryAll(httptest.NewRequest(http.MethodGet, "/", nil), "id")
+	require.ErrorIs(t, errParse, httpbind.ErrMissingParam)
+}
@@ -0,0 +1,125 @@
+//go:build !steamid_nonet
+
+package steamid
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WithAccessToken sets a user access token, such as the one held by the steam mobile app, which
+// authenticates requests to the ISteamUserOAuth endpoints. It lets applications that only have a
+// user token, and no Web API key, fetch summaries and friend lists: PlayerSummaries and FriendList
+// use the ISteamUserOAuth endpoints when the client has a token but no key. Surrounding whitespace
+// is ignored.
+func WithAccessToken(token string) ClientOption {
+	return func(c *Client) {
+		c.accessToken = strings.TrimSpace(token)
+	}
+}
+
+// Friend is a single relationship from a friend list.
+type Friend struct {
+	SteamID SteamID `json:"steamid"`
+	// Relationship is the kind of relationship, "friend" for the lists returned by FriendList.
+	Relationship string `json:"relationship"`
+	FriendSince  int64  `json:"friend_since"`
+}
+
+// Since returns the time the relationship started. The zero time is returned when it is not known.
+func (f Friend) Since() time.Time {
+	if f.FriendSince <= 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(f.FriendSince, 0)
+}
+
+// getOAuth performs a GET request against an ISteamUserOAuth endpoint, authenticated with the
+// access token of the client.
+func (c *Client) getOAuth(ctx context.Context, path string, values url.Values, out any) error {
+	if c.accessToken == "" {
+		return apiError(path, 0, ErrNoAccessToken)
+	}
+
+	values.Set("access_token", c.accessToken)
+
+	return c.get(ctx, path, values, false, out)
+}
+
+// UserSummaries fetches the profile summaries of the provided steam ids from the
+// ISteamUserOAuth/GetUserSummaries endpoint, using the access token of the client. It otherwise
+// behaves like PlayerSummaries, returning ErrNoAccessToken when the client has no token.
+func (c *Client) UserSummaries(ctx context.Context, steamIDs Collection) ([]PlayerSummary, error) {
+	var summaries []PlayerSummary
+
+	for _, batch := range chunks(steamIDs) {
+		var resp struct {
+			Players []PlayerSummary `json:"players"`
+		}
+
+		if errGet := c.getOAuth(ctx, "/ISteamUserOAuth/GetUserSummaries/v1/",
+			url.Values{"steamids": {strings.Join(batch, ",")}}, &resp); errGet != nil {
+			return nil, errGet
+		}
+
+		summaries = append(summaries, resp.Players...)
+	}
+
+	return summaries, nil
+}
+
+// UserFriendList fetches the friends of the steam id from the ISteamUserOAuth/GetFriendList
+// endpoint, using the access token of the client. The friend list of the owner of the token is
+// always available, those of other players only when they are public. ErrNoAccessToken is
+// returned when the client has no token.
+func (c *Client) UserFriendList(ctx context.Context, sid SteamID) ([]Friend, error) {
+	if !sid.Valid() {
+		return nil, parseError(sid.String(), ErrInvalidSID)
+	}
+
+	var resp struct {
+		Friends []Friend `json:"friends"`
+	}
+
+	if errGet := c.getOAuth(ctx, "/ISteamUserOAuth/GetFriendList/v1/",
+		url.Values{"steamid": {sid.String()}, "relationship": {"friend"}}, &resp); errGet != nil {
+		return nil, errGet
+	}
+
+	return resp.Friends, nil
+}
+
+// FriendList fetches the friends of the steam id, which must have a public friend list. The
+// ISteamUser/GetFriendList endpoint is used when the client has an api key, otherwise the
+// UserFriendList endpoint is used with its access token.
+func (c *Client) FriendList(ctx context.Context, sid SteamID) ([]Friend, error) {
+	if c.apiKey == "" && c.accessToken != "" {
+		return c.UserFriendList(ctx, sid)
+	}
+
+	if !sid.Valid() {
+		return nil, parseError(sid.String(), ErrInvalidSID)
+	}
+
+	var resp struct {
+		FriendsList struct {
+			Friends []Friend `json:"friends"`
+		} `json:"friendslist"`
+	}
+
+	if errGet := c.get(ctx, "/ISteamUser/GetFriendList/v1/",
+		url.Values{"steamid": {sid.String()}, "relationship": {"friend"}}, true, &resp); errGet != nil {
+		return nil, errGet
+	}
+
+	return resp.FriendsList.Friends, nil
+}
+
+// FriendList fetches the friends of the steam id using the package level api key. See
+// Client.FriendList.
+func FriendList(ctx context.Context, sid SteamID) ([]Friend, error) {
+	return defaultClient().FriendList(ctx, sid)
+}
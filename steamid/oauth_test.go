@@ -0,0 +1,92 @@
+//go:build !steamid_nonet
+
+package steamid_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientAccessToken(t *testing.T) {
+	t.Parallel()
+
+	const token = "user-access-token"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Has("key") || query.Get("access_token") != token {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		switch r.URL.Path {
+		case "/ISteamUserOAuth/GetUserSummaries/v1/":
+			require.Equal(t, "76561197960435530", query.Get("steamids"))
+			_, _ = w.Write([]byte(`{"players":[{"steamid":"76561197960435530","personaname":"Robin"}]}`))
+		case "/ISteamUserOAuth/GetFriendList/v1/":
+			require.Equal(t, "76561197960435530", query.Get("steamid"))
+			_, _ = w.Write([]byte(`{"friends":[{"steamid":"76561197960287930","relationship":"friend","friend_since":1262304000}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client, errClient := steamid.NewClient("", steamid.WithAccessToken(" "+token+"\n"),
+		steamid.WithBaseURL(server.URL), steamid.WithHTTPClient(server.Client()))
+	require.NoError(t, errClient)
+
+	sid := steamid.New(76561197960435530)
+
+	summaries, errSummaries := client.PlayerSummaries(context.Background(), steamid.Collection{sid})
+	require.NoError(t, errSummaries)
+	require.Equal(t, []steamid.PlayerSummary{{SteamID: sid, PersonaName: "Robin"}}, summaries)
+
+	friends, errFriends := client.FriendList(context.Background(), sid)
+	require.NoError(t, errFriends)
+	require.Len(t, friends, 1)
+	require.Equal(t, steamid.New(76561197960287930), friends[0].SteamID)
+	require.Equal(t, time.Unix(1262304000, 0), friends[0].Since())
+
+	_, errBans := client.PlayerBans(context.Background(), steamid.Collection{sid})
+	require.ErrorIs(t, errBans, steamid.ErrNoAPIKey)
+
+	_, errInvalid := client.UserFriendList(context.Background(), steamid.SteamID{})
+	require.ErrorIs(t, errInvalid, steamid.ErrInvalidSID)
+}
+
+func TestClientFriendList(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ISteamUser/GetFriendList/v1/", r.URL.Path)
+		require.Equal(t, testKey, r.URL.Query().Get("key"))
+		require.Equal(t, "friend", r.URL.Query().Get("relationship"))
+
+		if r.URL.Query().Get("steamid") != "76561197960435530" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"friendslist":{"friends":[{"steamid":"76561197960287930","relationship":"friend","friend_since":0}]}}`))
+	})
+
+	friends, errFriends := client.FriendList(context.Background(), steamid.New(76561197960435530))
+	require.NoError(t, errFriends)
+	require.Equal(t, []steamid.Friend{{SteamID: steamid.New(76561197960287930), Relationship: "friend"}}, friends)
+	require.True(t, friends[0].Since().IsZero())
+
+	_, errPrivate := client.FriendList(context.Background(), steamid.New(76561197960287930))
+	require.ErrorIs(t, errPrivate, steamid.ErrInvalidStatusCode)
+
+	_, errNoToken := client.UserSummaries(context.Background(), steamid.Collection{steamid.New(76561197960435530)})
+	require.ErrorIs(t, errNoToken, steamid.ErrNoAccessToken)
+}
@@ -0,0 +1,87 @@
+package steamid
+
+// rangeConfig holds the parts of the ids produced by Range other than the account id.
+type rangeConfig struct {
+	accountType AccountType
+	universe    Universe
+	instance    Instance
+	instanceSet bool
+}
+
+// RangeOption configures the ids produced by Range.
+type RangeOption func(*rangeConfig)
+
+// WithRangeAccountType sets the account type of the ids, AccountTypeIndividual by default.
+func WithRangeAccountType(accountType AccountType) RangeOption {
+	return func(c *rangeConfig) {
+		c.accountType = accountType
+	}
+}
+
+// WithRangeUniverse sets the universe of the ids, UniversePublic by default.
+func WithRangeUniverse(universe Universe) RangeOption {
+	return func(c *rangeConfig) {
+		c.universe = universe
+	}
+}
+
+// WithRangeInstance sets the instance of the ids. By default individual ids use InstanceDesktop
+// and other account types InstanceAll.
+func WithRangeInstance(instance Instance) RangeOption {
+	return func(c *rangeConfig) {
+		c.instance = instance
+		c.instanceSet = true
+	}
+}
+
+// Range returns an iterator over the ids with the account ids from start to end, inclusive, in
+// ascending order. Ids which are not valid, such as account id 0 or those of an unsupported
+// account type, are skipped, so nothing is produced when start is greater than end. The iterator
+// is an iter.Seq[SteamID], which with go 1.23 or later is used with range:
+//
+//	for sid := range steamid.Range(1, 1000) {
+//		fmt.Println(sid.String())
+//	}
+//
+// Combine it with PlayerSummaries and PlayerBans, in batches of MaxBatchIDs, to scan a range of
+// accounts.
+func Range(start uint32, end uint32, opts ...RangeOption) func(yield func(SteamID) bool) {
+	config := rangeConfig{accountType: AccountTypeIndividual, universe: UniversePublic}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if !config.instanceSet {
+		config.instance = InstanceAll
+		if config.accountType == AccountTypeIndividual {
+			config.instance = InstanceDesktop
+		}
+	}
+
+	return func(yield func(SteamID) bool) {
+		// Only the account id varies, so when any other part is invalid every id would be skipped.
+		if probe := (SteamID{AccountID: 1, Instance: config.instance, AccountType: config.accountType,
+			Universe: config.universe}); !probe.Valid() {
+			return
+		}
+
+		// A uint64 counter avoids overflowing when end is math.MaxUint32.
+		for accountID := uint64(start); accountID <= uint64(end); accountID++ {
+			sid := SteamID{
+				AccountID:   SID32(accountID),
+				Instance:    config.instance,
+				AccountType: config.accountType,
+				Universe:    config.universe,
+			}
+
+			if !sid.Valid() {
+				continue
+			}
+
+			if !yield(sid) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package steamid_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+// collectRange returns the ids produced by the iterator, stopping after limit ids.
+func collectRange(seq func(yield func(steamid.SteamID) bool), limit int) []steamid.SteamID {
+	var sids []steamid.SteamID
+
+	seq(func(sid steamid.SteamID) bool {
+		sids = append(sids, sid)
+
+		return len(sids) < limit
+	})
+
+	return sids
+}
+
+func TestRange(t *testing.T) {
+	t.Parallel()
+
+	sids := collectRange(steamid.Range(0, 3), 10)
+	require.Len(t, sids, 3)
+	require.Equal(t, "76561197960265729", sids[0].String())
+	require.Equal(t, "76561197960265731", sids[2].String())
+
+	require.Len(t, collectRange(steamid.Range(1, 100), 5), 5)
+	require.Empty(t, collectRange(steamid.Range(5, 4), 10))
+
+	last := collectRange(steamid.Range(math.MaxUint32-1, math.MaxUint32), 10)
+	require.Len(t, last, 2)
+	require.Equal(t, steamid.SID32(math.MaxUint32), last[1].AccountID)
+
+	clans := collectRange(steamid.Range(1, 2, steamid.WithRangeAccountType(steamid.AccountTypeClan)), 10)
+	require.Len(t, clans, 2)
+	require.Equal(t, steamid.AccountTypeClan, clans[0].AccountType)
+	require.Equal(t, steamid.InstanceAll, clans[0].Instance)
+
+	web := collectRange(steamid.Range(1, 1, steamid.WithRangeInstance(steamid.InstanceWeb),
+		steamid.WithRangeUniverse(steamid.UniverseBeta)), 10)
+	require.Len(t, web, 1)
+	require.Equal(t, steamid.InstanceWeb, web[0].Instance)
+	require.Equal(t, steamid.UniverseBeta, web[0].Universe)
+
+	require.Empty(t, collectRange(steamid.Range(0, math.MaxUint32,
+		steamid.WithRangeAccountType(steamid.AccountTypeInvalid)), 10))
+}
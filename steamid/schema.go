@@ -0,0 +1,21 @@
+package steamid
+
+const (
+	// JSONSchemaPattern matches the quoted steam64 that valid steam ids are encoded to in json.
+	JSONSchemaPattern = `^[1-9][0-9]{16,18}$`
+	// JSONSchemaExample is the example value of the steam id schema.
+	JSONSchemaExample = "76561198132612090"
+)
+
+// JSONSchema returns the JSON Schema of a SteamID as encoded by MarshalJSON, which is also a valid
+// OpenAPI schema object. It can be used to document steam id fields with spec generators that do
+// not know the type, such as the SchemaCustomizer of kin-openapi. A new map is returned by every
+// call so it may be extended by the caller.
+func JSONSchema() map[string]any {
+	return map[string]any{
+		"type":        "string",
+		"pattern":     JSONSchemaPattern,
+		"example":     JSONSchemaExample,
+		"description": "Steam64 id",
+	}
+}
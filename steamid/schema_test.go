@@ -0,0 +1,36 @@
+package steamid_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schema  = steamid.JSONSchema()
+		pattern = regexp.MustCompile(steamid.JSONSchemaPattern)
+	)
+
+	require.Equal(t, "string", schema["type"])
+
+	for _, input := range []string{steamid.JSONSchemaExample, "STEAM_0:0:11101", "[g:1:4]"} {
+		var encoded string
+
+		body, errMarshal := json.Marshal(steamid.New(input))
+		require.NoError(t, errMarshal)
+		require.NoError(t, json.Unmarshal(body, &encoded))
+		require.Regexp(t, pattern, encoded)
+	}
+
+	example := steamid.New(schema["example"])
+	require.True(t, example.Valid())
+
+	schema["type"] = "integer"
+	require.Equal(t, "string", steamid.JSONSchema()["type"])
+}
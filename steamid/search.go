@@ -0,0 +1,131 @@
+//go:build !steamid_nonet
+
+package steamid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//nolint:gochecknoglobals
+var (
+	reSearchMiniProfile = regexp.MustCompile(`data-miniprofile="(\d+)"`)
+	reSearchPersonaName = regexp.MustCompile(`<a class="searchPersonaName" href="([^"]+)">([^<]*)</a>`)
+	reSearchAvatar      = regexp.MustCompile(`<img src="([^"]+)"`)
+)
+
+// SearchResult is a profile found by SearchUsers.
+type SearchResult struct {
+	SteamID     SteamID
+	PersonaName string
+	ProfileURL  string
+	// Avatar is the url of the medium sized avatar.
+	Avatar string
+}
+
+// searchResponse is the response of the community search endpoint, whose results are rendered
+// as html.
+type searchResponse struct {
+	Success int    `json:"success"`
+	HTML    string `json:"html"`
+}
+
+// parseSearchResults extracts the profiles from the html rows of a community search response.
+// Rows without a valid account id are skipped.
+func parseSearchResults(body string) []SearchResult {
+	var results []SearchResult
+
+	for _, row := range strings.Split(body, `class="search_row"`)[1:] {
+		miniProfile := reSearchMiniProfile.FindStringSubmatch(row)
+		if miniProfile == nil {
+			continue
+		}
+
+		accountID, errAccountID := strconv.ParseUint(miniProfile[1], 10, 32)
+		if errAccountID != nil || accountID == 0 {
+			continue
+		}
+
+		result := SearchResult{SteamID: SID32(accountID).SteamID()}
+
+		if persona := reSearchPersonaName.FindStringSubmatch(row); persona != nil {
+			result.ProfileURL = html.UnescapeString(persona[1])
+			result.PersonaName = html.UnescapeString(persona[2])
+		}
+
+		if avatar := reSearchAvatar.FindStringSubmatch(row); avatar != nil {
+			result.Avatar = html.UnescapeString(avatar[1])
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// SearchUsers searches the steam community for profiles whose persona name matches the name,
+// returning the candidates of the first page of results in the order steam ranks them. No api key
+// is required. Persona names are not unique, so the results should be checked before acting on
+// them.
+func (c *Client) SearchUsers(ctx context.Context, name string) ([]SearchResult, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, resolveError(name, ErrInvalidQueryValue)
+	}
+
+	// The endpoint only checks that the session id parameter matches the cookie.
+	session := make([]byte, 12)
+	_, _ = rand.Read(session)
+	sessionID := hex.EncodeToString(session)
+
+	const path = "/search/SearchCommunityAjax"
+
+	values := url.Values{
+		"text": {name}, "filter": {"users"}, "sessionid": {sessionID}, "steamid_user": {"false"}, "page": {"1"},
+	}
+
+	req, errReq := http.NewRequestWithContext(ctx, http.MethodGet, c.communityURL+path+"?"+values.Encode(), nil)
+	if errReq != nil {
+		return nil, apiError(path, 0, errors.Join(errReq, ErrRequestCreate))
+	}
+
+	req.AddCookie(&http.Cookie{Name: "sessionid", Value: sessionID})
+
+	resp, errDo := c.do(req)
+	if errDo != nil {
+		return nil, apiError(path, 0, errors.Join(errDo, ErrResponsePerform))
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, apiError(path, resp.StatusCode, ErrInvalidStatusCode)
+	}
+
+	var search searchResponse
+	if errDecode := json.NewDecoder(resp.Body).Decode(&search); errDecode != nil {
+		return nil, apiError(path, resp.StatusCode, errors.Join(errDecode, ErrResponseBody))
+	}
+
+	if search.Success != 1 {
+		return nil, apiError(name, search.Success, ErrInvalidStatusCode)
+	}
+
+	return parseSearchResults(search.HTML), nil
+}
+
+// SearchUsers searches the steam community for profiles matching the name. See Client.SearchUsers.
+func SearchUsers(ctx context.Context, name string) ([]SearchResult, error) {
+	return defaultClient().SearchUsers(ctx, name)
+}
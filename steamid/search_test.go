@@ -0,0 +1,81 @@
+//go:build !steamid_nonet
+
+package steamid_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+const searchHTML = `<div class="search_row" data-panel="{}">
+	<div class="mediumHolder_default" data-miniprofile="172346362" style="float:left;">
+		<div class="avatarMedium"><a href="https://steamcommunity.com/id/SQUIRRELLY"><img src="https://avatars.example/a_medium.jpg"></a></div>
+	</div>
+	<div class="searchPersonaInfo">
+		<a class="searchPersonaName" href="https://steamcommunity.com/id/SQUIRRELLY">Squirrel &amp; Co</a><br />
+	</div>
+</div>
+<div class="search_row" data-panel="{}">
+	<div class="mediumHolder_default" data-miniprofile="22202" style="float:left;">
+		<div class="avatarMedium"><a href="https://steamcommunity.com/profiles/76561197960287930"><img src="https://avatars.example/b_medium.jpg"></a></div>
+	</div>
+	<div class="searchPersonaInfo">
+		<a class="searchPersonaName" href="https://steamcommunity.com/profiles/76561197960287930">squirrel</a><br />
+	</div>
+</div>`
+
+func TestClientSearchUsers(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, errCookie := r.Cookie("sessionid")
+		if errCookie != nil || cookie.Value != r.URL.Query().Get("sessionid") || r.URL.Path != "/search/SearchCommunityAjax" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"success": 0})
+
+			return
+		}
+
+		require.Equal(t, "users", r.URL.Query().Get("filter"))
+
+		body := ""
+		if r.URL.Query().Get("text") == "squirrel" {
+			body = searchHTML
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"success": 1, "search_result_count": 2, "html": body})
+	}))
+	t.Cleanup(server.Close)
+
+	client, errClient := steamid.NewClient("", steamid.WithCommunityURL(server.URL))
+	require.NoError(t, errClient)
+
+	results, errSearch := client.SearchUsers(context.Background(), "squirrel")
+	require.NoError(t, errSearch)
+	require.Equal(t, []steamid.SearchResult{
+		{
+			SteamID:     steamid.New(76561198132612090),
+			PersonaName: "Squirrel & Co",
+			ProfileURL:  "https://steamcommunity.com/id/SQUIRRELLY",
+			Avatar:      "https://avatars.example/a_medium.jpg",
+		},
+		{
+			SteamID:     steamid.New(76561197960287930),
+			PersonaName: "squirrel",
+			ProfileURL:  "https://steamcommunity.com/profiles/76561197960287930",
+			Avatar:      "https://avatars.example/b_medium.jpg",
+		},
+	}, results)
+
+	results, errSearch = client.SearchUsers(context.Background(), "nobody")
+	require.NoError(t, errSearch)
+	require.Empty(t, results)
+
+	_, errEmpty := client.SearchUsers(context.Background(), " ")
+	require.ErrorIs(t, errEmpty, steamid.ErrInvalidQueryValue)
+}
@@ -0,0 +1,220 @@
+//go:build !steamid_nonet
+
+package steamid
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// GameServer is a server registered with steam, as returned by the ISteamApps/GetServersAtAddress
+// endpoint.
+type GameServer struct {
+	// Addr is the ip and query port of the server, e.g. 23.239.22.163:27015.
+	Addr string `json:"addr"`
+	// SteamID is the game server account of the server. Servers without a persistent account
+	// have an anonymous game server id.
+	SteamID  SteamID `json:"steamid"`
+	AppID    AppID   `json:"appid"`
+	GameDir  string  `json:"gamedir"`
+	Region   int     `json:"region"`
+	Secure   bool    `json:"secure"`
+	LAN      bool    `json:"lan"`
+	GamePort int     `json:"gameport"`
+	SpecPort int     `json:"specport"`
+}
+
+// ServersAtAddress returns the game servers registered with steam at the ip address, including
+// their game server steam ids, app ids and ports. An optional port in the address is ignored. No
+// api key is required.
+func (c *Client) ServersAtAddress(ctx context.Context, ip string) ([]GameServer, error) {
+	query := strings.TrimSpace(ip)
+	if addrPort, errAddrPort := netip.ParseAddrPort(query); errAddrPort == nil {
+		query = addrPort.Addr().String()
+	}
+
+	if _, errAddr := netip.ParseAddr(query); errAddr != nil {
+		return nil, resolveError(ip, ErrInvalidQueryValue)
+	}
+
+	var resp struct {
+		Response struct {
+			Success bool         `json:"success"`
+			Message string       `json:"message"`
+			Servers []GameServer `json:"servers"`
+		} `json:"response"`
+	}
+
+	if errGet := c.get(ctx, "/ISteamApps/GetServersAtAddress/v1/", url.Values{"addr": {query}}, false, &resp); errGet != nil {
+		return nil, errGet
+	}
+
+	if !resp.Response.Success {
+		return nil, apiError(ip, 0, fmt.Errorf("%w: %s", ErrResponseBody, resp.Response.Message))
+	}
+
+	return resp.Response.Servers, nil
+}
+
+// ServersAtAddress returns the game servers registered with steam at the ip address. See
+// Client.ServersAtAddress.
+func ServersAtAddress(ctx context.Context, ip string) ([]GameServer, error) {
+	return defaultClient().ServersAtAddress(ctx, ip)
+}
+
+// ListedServer is a server returned by the master server query of the
+// IGameServersService/GetServerList endpoint.
+type ListedServer struct {
+	// Addr is the ip and query port of the server, e.g. 23.239.22.163:27015.
+	Addr       string  `json:"addr"`
+	GamePort   int     `json:"gameport"`
+	SteamID    SteamID `json:"steamid"`
+	Name       string  `json:"name"`
+	AppID      AppID   `json:"appid"`
+	GameDir    string  `json:"gamedir"`
+	Version    string  `json:"version"`
+	Product    string  `json:"product"`
+	Region     int     `json:"region"`
+	Players    int     `json:"players"`
+	MaxPlayers int     `json:"max_players"`
+	Bots       int     `json:"bots"`
+	Map        string  `json:"map"`
+	Secure     bool    `json:"secure"`
+	Dedicated  bool    `json:"dedicated"`
+	// OS is l for linux, w for windows and m for macOS.
+	OS string `json:"os"`
+	// GameType holds the server tags, e.g. nocrits,payload.
+	GameType string `json:"gametype"`
+}
+
+// ServerList queries the master server for the servers matching the filter, returning at most
+// limit servers, or the api default when limit is not positive. The filter uses the master server
+// query syntax, e.g. `\appid\440\gametype\uncletopia`, see
+// https://developer.valvesoftware.com/wiki/Master_Server_Query_Protocol#Filter. Requires an api
+// key.
+func (c *Client) ServerList(ctx context.Context, filter string, limit int) ([]ListedServer, error) {
+	input := serviceInput{"filter": filter}
+	if limit > 0 {
+		input["limit"] = limit
+	}
+
+	var resp struct {
+		Response struct {
+			Servers []ListedServer `json:"servers"`
+		} `json:"response"`
+	}
+
+	if errGet := c.callService(ctx, "/IGameServersService/GetServerList/v1/", input, true, &resp); errGet != nil {
+		return nil, errGet
+	}
+
+	return resp.Response.Servers, nil
+}
+
+// ServerList queries the master server for the servers matching the filter using the package
+// level api key. See Client.ServerList.
+func ServerList(ctx context.Context, filter string, limit int) ([]ListedServer, error) {
+	return defaultClient().ServerList(ctx, filter, limit)
+}
+
+// GameServerAddress pairs the steam id of a game server with the address it is running at.
+type GameServerAddress struct {
+	SteamID SteamID
+	Addr    netip.AddrPort
+}
+
+// gameServerAddressResponse is the response of the IGameServersService/GetServerSteamIDsByIP and
+// IGameServersService/GetServerIPsBySteamID endpoints.
+type gameServerAddressResponse struct {
+	Response struct {
+		Servers []struct {
+			Addr    string  `json:"addr"`
+			SteamID SteamID `json:"steamid"`
+		} `json:"servers"`
+	} `json:"response"`
+}
+
+// serverAddresses performs a request for the indexed parameter values in batches of at most
+// MaxBatchIDs, returning the servers with a valid id and address.
+func (c *Client) serverAddresses(ctx context.Context, path string, param string, inputs []string) ([]GameServerAddress, error) {
+	var servers []GameServerAddress
+
+	for start := 0; start < len(inputs); start += MaxBatchIDs {
+		batch := serviceInput{param: inputs[start:min(start+MaxBatchIDs, len(inputs))]}
+
+		var resp gameServerAddressResponse
+		if errGet := c.callService(ctx, path, batch, true, &resp); errGet != nil {
+			return nil, errGet
+		}
+
+		for _, server := range resp.Response.Servers {
+			addr, errAddr := netip.ParseAddrPort(server.Addr)
+			if errAddr != nil || !server.SteamID.Valid() {
+				continue
+			}
+
+			servers = append(servers, GameServerAddress{SteamID: server.SteamID, Addr: addr})
+		}
+	}
+
+	return servers, nil
+}
+
+// ServerSteamIDsByIP returns the steam ids of the game servers running at the addresses, which
+// must include the query port. Addresses without a running server are left out of the results.
+// Invalid and duplicate addresses are ignored. Requires an api key.
+func (c *Client) ServerSteamIDsByIP(ctx context.Context, addrs []netip.AddrPort) ([]GameServerAddress, error) {
+	var (
+		inputs []string
+		seen   = map[netip.AddrPort]bool{}
+	)
+
+	for _, addr := range addrs {
+		if !addr.IsValid() || seen[addr] {
+			continue
+		}
+
+		seen[addr] = true
+		inputs = append(inputs, addr.String())
+	}
+
+	return c.serverAddresses(ctx, "/IGameServersService/GetServerSteamIDsByIP/v1/", "server_ips", inputs)
+}
+
+// ServerIPsBySteamID returns the addresses of the running game servers logged in with the steam
+// ids, e.g. the accounts of game server login tokens. Servers which are not running are left out
+// of the results. Ids which are not game server accounts are ignored, as are duplicates. Requires
+// an api key.
+func (c *Client) ServerIPsBySteamID(ctx context.Context, steamIDs Collection) ([]GameServerAddress, error) {
+	var (
+		inputs []string
+		seen   = map[SteamID]bool{}
+	)
+
+	for _, sid := range steamIDs {
+		isServer := sid.AccountType == AccountTypeGameServer || sid.AccountType == AccountTypeAnonGameServer
+		if !isServer || !sid.Valid() || seen[sid] {
+			continue
+		}
+
+		seen[sid] = true
+		inputs = append(inputs, sid.String())
+	}
+
+	return c.serverAddresses(ctx, "/IGameServersService/GetServerIPsBySteamID/v1/", "server_steamids", inputs)
+}
+
+// ServerSteamIDsByIP returns the steam ids of the game servers running at the addresses using the
+// package level api key. See Client.ServerSteamIDsByIP.
+func ServerSteamIDsByIP(ctx context.Context, addrs []netip.AddrPort) ([]GameServerAddress, error) {
+	return defaultClient().ServerSteamIDsByIP(ctx, addrs)
+}
+
+// ServerIPsBySteamID returns the addresses of the game servers logged in with the steam ids using
+// the package level api key. See Client.ServerIPsBySteamID.
+func ServerIPsBySteamID(ctx context.Context, steamIDs Collection) ([]GameServerAddress, error) {
+	return defaultClient().ServerIPsBySteamID(ctx, steamIDs)
+}
@@ -0,0 +1,195 @@
+//go:build !steamid_nonet
+
+package steamid_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sync"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientServersAtAddress(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ISteamApps/GetServersAtAddress/v1/", r.URL.Path)
+
+		if r.URL.Query().Get("addr") != "23.239.22.163" {
+			_, _ = w.Write([]byte(`{"response":{"success":false,"message":"Invalid IP address"}}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"response":{"success":true,"servers":[{"addr":"23.239.22.163:27015","gmsindex":65534,
+"steamid":"85568392923453780","appid":440,"gamedir":"tf","region":-1,"secure":true,"lan":false,"gameport":27015,"specport":27020}]}}`))
+	})
+
+	for _, addr := range []string{"23.239.22.163", "23.239.22.163:27015", " 23.239.22.163 "} {
+		servers, errServers := client.ServersAtAddress(context.Background(), addr)
+		require.NoError(t, errServers, addr)
+		require.Equal(t, []steamid.GameServer{{
+			Addr:     "23.239.22.163:27015",
+			SteamID:  steamid.New(85568392923453780),
+			AppID:    440,
+			GameDir:  "tf",
+			Region:   -1,
+			Secure:   true,
+			GamePort: 27015,
+			SpecPort: 27020,
+		}}, servers, addr)
+	}
+
+	_, errFailed := client.ServersAtAddress(context.Background(), "10.0.0.1")
+	require.ErrorIs(t, errFailed, steamid.ErrAPI)
+
+	for _, addr := range []string{"", "steamcommunity.com", "23.239.22"} {
+		_, errInvalid := client.ServersAtAddress(context.Background(), addr)
+		require.ErrorIs(t, errInvalid, steamid.ErrInvalidQueryValue, addr)
+	}
+}
+
+func TestClientServerAddresses(t *testing.T) {
+	t.Parallel()
+
+	const serverSID = "85568392923453780"
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		require.NotEmpty(t, query.Get("key"))
+
+		switch r.URL.Path {
+		case "/IGameServersService/GetServerSteamIDsByIP/v1/":
+			require.Equal(t, "23.239.22.163:27015", query.Get("server_ips[0]"))
+			require.Empty(t, query.Get("server_ips[1]"))
+		case "/IGameServersService/GetServerIPsBySteamID/v1/":
+			require.Equal(t, serverSID, query.Get("server_steamids[0]"))
+			require.Empty(t, query.Get("server_steamids[1]"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"response":{"servers":[{"addr":"23.239.22.163:27015","steamid":"` + serverSID + `"},
+{"addr":"","steamid":"` + serverSID + `"}]}}`))
+	})
+
+	expected := []steamid.GameServerAddress{{
+		SteamID: steamid.New(serverSID),
+		Addr:    netip.MustParseAddrPort("23.239.22.163:27015"),
+	}}
+
+	addr := netip.MustParseAddrPort("23.239.22.163:27015")
+
+	byIP, errByIP := client.ServerSteamIDsByIP(context.Background(), []netip.AddrPort{addr, addr, {}})
+	require.NoError(t, errByIP)
+	require.Equal(t, expected, byIP)
+
+	bySID, errBySID := client.ServerIPsBySteamID(context.Background(),
+		steamid.Collection{steamid.New(serverSID), steamid.New(serverSID), steamid.New(76561198132612090)})
+	require.NoError(t, errBySID)
+	require.Equal(t, expected, bySID)
+
+	none, errNone := client.ServerIPsBySteamID(context.Background(), steamid.Collection{steamid.New(76561198132612090)})
+	require.NoError(t, errNone)
+	require.Empty(t, none)
+
+	noKey, errClient := steamid.NewClient("")
+	require.NoError(t, errClient)
+
+	_, errNoKey := noKey.ServerSteamIDsByIP(context.Background(), []netip.AddrPort{addr})
+	require.ErrorIs(t, errNoKey, steamid.ErrNoAPIKey)
+}
+
+func TestClientServerAddressesInputJSON(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		methods []string
+		counts  []int
+	)
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/IGameServersService/GetServerSteamIDsByIP/v1/", r.URL.Path)
+
+		var input struct {
+			ServerIPs []string `json:"server_ips"`
+		}
+
+		if r.Method == http.MethodPost {
+			require.NoError(t, r.ParseForm())
+			require.Equal(t, testKey, r.PostForm.Get("key"))
+			require.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("input_json")), &input))
+		} else {
+			for idx := 0; r.URL.Query().Has(fmt.Sprintf("server_ips[%d]", idx)); idx++ {
+				input.ServerIPs = append(input.ServerIPs, r.URL.Query().Get(fmt.Sprintf("server_ips[%d]", idx)))
+			}
+		}
+
+		mu.Lock()
+		methods = append(methods, r.Method)
+		counts = append(counts, len(input.ServerIPs))
+		mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"response":{"servers":[]}}`))
+	})
+
+	addrs := make([]netip.AddrPort, 150)
+	for idx := range addrs {
+		addrs[idx] = netip.AddrPortFrom(netip.AddrFrom4([4]byte{10, 0, byte(idx), 1}), 27015)
+	}
+
+	_, errServers := client.ServerSteamIDsByIP(context.Background(), addrs)
+	require.NoError(t, errServers)
+	require.Equal(t, []string{http.MethodPost, http.MethodGet}, methods)
+	require.Equal(t, []int{100, 50}, counts)
+}
+
+func TestClientServerList(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/IGameServersService/GetServerList/v1/", r.URL.Path)
+		require.Equal(t, `\appid\440\gametype\uncletopia`, r.URL.Query().Get("filter"))
+
+		if limit := r.URL.Query().Get("limit"); limit != "" {
+			require.Equal(t, "10", limit)
+		}
+
+		_, _ = w.Write([]byte(`{"response":{"servers":[{"addr":"23.239.22.163:27015","gameport":27015,
+"steamid":"85568392923453780","name":"Uncletopia | US West 2","appid":440,"gamedir":"tf","version":"8604597",
+"product":"tf","region":1,"players":24,"max_players":32,"bots":0,"map":"pl_goldrush","secure":true,
+"dedicated":true,"os":"l","gametype":"nocrits,payload,uncletopia"}]}}`))
+	})
+
+	for _, limit := range []int{10, 0} {
+		servers, errServers := client.ServerList(context.Background(), `\appid\440\gametype\uncletopia`, limit)
+		require.NoError(t, errServers)
+		require.Equal(t, []steamid.ListedServer{{
+			Addr:       "23.239.22.163:27015",
+			GamePort:   27015,
+			SteamID:    steamid.New(85568392923453780),
+			Name:       "Uncletopia | US West 2",
+			AppID:      440,
+			GameDir:    "tf",
+			Version:    "8604597",
+			Product:    "tf",
+			Region:     1,
+			Players:    24,
+			MaxPlayers: 32,
+			Map:        "pl_goldrush",
+			Secure:     true,
+			Dedicated:  true,
+			OS:         "l",
+			GameType:   "nocrits,payload,uncletopia",
+		}}, servers)
+	}
+}
@@ -0,0 +1,58 @@
+package steamid
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Set is an unordered collection of unique steam ids. Lookups are constant time, unlike
+// Collection.Contains, which makes it suitable for checking ids against large lists.
+type Set map[SteamID]struct{}
+
+// NewSet returns a set containing the ids.
+func NewSet(ids ...SteamID) Set {
+	set := make(Set, len(ids))
+	set.Add(ids...)
+
+	return set
+}
+
+// Add adds the ids to the set.
+func (s Set) Add(ids ...SteamID) {
+	for _, sid := range ids {
+		s[sid] = struct{}{}
+	}
+}
+
+// Remove removes the ids from the set.
+func (s Set) Remove(ids ...SteamID) {
+	for _, sid := range ids {
+		delete(s, sid)
+	}
+}
+
+// Contains returns true if the id is in the set.
+func (s Set) Contains(sid SteamID) bool {
+	_, found := s[sid]
+
+	return found
+}
+
+// Len returns the number of ids in the set.
+func (s Set) Len() int {
+	return len(s)
+}
+
+// Collection returns the ids of the set in ascending order.
+func (s Set) Collection() Collection {
+	ids := make(Collection, 0, len(s))
+	for sid := range s {
+		ids = append(ids, sid)
+	}
+
+	slices.SortFunc(ids, func(a, b SteamID) int {
+		return cmp.Compare(a.Uint64(), b.Uint64())
+	})
+
+	return ids
+}
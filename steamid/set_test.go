@@ -0,0 +1,60 @@
+package steamid_test
+
+import (
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sidA = steamid.New(76561197960287930)
+		sidB = steamid.New(76561198132612090)
+		sidC = steamid.New(103582791429521412)
+	)
+
+	set := steamid.NewSet(sidB, sidA, steamid.New("[U:1:22202]"))
+	require.Equal(t, 2, set.Len())
+	require.True(t, set.Contains(sidA))
+	require.True(t, set.Contains(steamid.New("STEAM_0:0:86173181")))
+	require.False(t, set.Contains(sidC))
+
+	set.Add(sidC)
+	require.Equal(t, steamid.Collection{sidA, sidB, sidC}, set.Collection())
+
+	set.Remove(sidA, sidC)
+	require.Equal(t, steamid.Collection{sidB}, set.Collection())
+}
+
+func TestCollectionDiff(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sidA = steamid.New(76561197960287930)
+		sidB = steamid.New(76561198132612090)
+		sidC = steamid.New(76561198045011302)
+	)
+
+	added, removed := steamid.Collection{sidA, sidB, sidB}.Diff(steamid.Collection{sidC, steamid.New("[U:1:22202]"), sidC})
+	require.Equal(t, steamid.Collection{sidC}, added)
+	require.Equal(t, steamid.Collection{sidB}, removed)
+
+	added, removed = steamid.Collection{sidA}.Diff(steamid.Collection{sidA})
+	require.Empty(t, added)
+	require.Empty(t, removed)
+}
+
+func TestCollectionUnique(t *testing.T) {
+	t.Parallel()
+
+	var (
+		sidA = steamid.New(76561197960287930)
+		sidB = steamid.New(76561198132612090)
+	)
+
+	require.Equal(t, steamid.Collection{sidB, sidA}, steamid.Collection{sidB, sidA, sidB, steamid.New("[U:1:22202]")}.Unique())
+	require.Nil(t, steamid.Collection(nil).Unique())
+}
@@ -0,0 +1,67 @@
+package steamid
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	shortCodeAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// shortCodeMaxLen is the length of the largest uint64 in base62.
+	shortCodeMaxLen = 11
+)
+
+// ShortCode encodes the steam64 of the id in base62, a compact form for short urls and QR codes
+// that holds only letters and digits. e.g. 76561197960287930 -> 5eeMp6JlTG. Codes are case
+// sensitive, see FromShortCode.
+//
+// An empty string is returned for ids that are not valid.
+func (t *SteamID) ShortCode() string {
+	if !t.Valid() {
+		return ""
+	}
+
+	var (
+		value = t.Uint64()
+		code  [shortCodeMaxLen]byte
+		pos   = len(code)
+	)
+
+	for value > 0 {
+		pos--
+		code[pos] = shortCodeAlphabet[value%62]
+		value /= 62
+	}
+
+	return string(code[pos:])
+}
+
+// FromShortCode decodes a short code created with SteamID.ShortCode. Surrounding whitespace is
+// ignored, otherwise decoding is strict: codes with characters outside of the base62 alphabet,
+// leading zeros or values that overflow a steam64 are rejected, as are codes of ids that are not
+// valid, so each id has exactly one code.
+func FromShortCode(input string) (SteamID, error) {
+	code := strings.TrimSpace(input)
+	if code == "" || len(code) > shortCodeMaxLen || code[0] == '0' {
+		return invalidSID, parseError(input, ErrInvalidShortCode)
+	}
+
+	var value uint64
+
+	for idx := 0; idx < len(code); idx++ {
+		digit := strings.IndexByte(shortCodeAlphabet, code[idx])
+		if digit < 0 || value > (math.MaxUint64-uint64(digit))/62 {
+			return invalidSID, parseError(input, ErrInvalidShortCode)
+		}
+
+		value = value*62 + uint64(digit)
+	}
+
+	// The value is always decoded as a steam64, unlike New which treats small values as steam32.
+	sid := fromAccountID(value)
+	if !sid.Valid() {
+		return invalidSID, parseError(input, ErrInvalidShortCode)
+	}
+
+	return sid, nil
+}
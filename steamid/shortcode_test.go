@@ -0,0 +1,38 @@
+package steamid_test
+
+import (
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortCode(t *testing.T) {
+	t.Parallel()
+
+	sid := steamid.New(76561197960287930)
+	code := sid.ShortCode()
+	require.Equal(t, "5eeMp6JlTG", code)
+
+	decoded, errDecode := steamid.FromShortCode(" " + code + "\n")
+	require.NoError(t, errDecode)
+	require.Equal(t, sid, decoded)
+
+	for _, value := range []uint64{76561197960265729, 76561202255233023, 103582791429521409, 85568392923453780} {
+		roundTrip := steamid.New(value)
+		fromCode, errCode := steamid.FromShortCode(roundTrip.ShortCode())
+		require.NoError(t, errCode, value)
+		require.Equal(t, value, fromCode.Uint64())
+	}
+
+	var invalid steamid.SteamID
+	require.Empty(t, invalid.ShortCode())
+
+	for _, input := range []string{
+		"", "05eeMp6JlTG", "5eeMp6JlT-", "5eeMp6 JlTG", "zzzzzzzzzzz", "LygHa16AHYF", "LygHa16AHYG", "5eeMp6JlTG5eeM", "111",
+	} {
+		_, errInvalid := steamid.FromShortCode(input)
+		require.ErrorIs(t, errInvalid, steamid.ErrInvalidShortCode, input)
+		require.ErrorIs(t, errInvalid, steamid.ErrParse, input)
+	}
+}
@@ -13,31 +13,19 @@
 package steamid
 
 import (
-	"context"
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
-	"net/url"
-	"os"
+	"math/rand/v2"
 	"regexp"
 	"strconv"
-	"strings"
 	"sync/atomic"
-	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 var (
-	httpClient    *http.Client //nolint:gochecknoglobals
-	reGroupIDTags = regexp.MustCompile(`<groupID64>(\w+)</groupID64>`)
-	reGroupURL    = regexp.MustCompile(`steamcommunity.com/groups/(\S+)/?`)
-	apiKey        string //nolint:gochecknoglobals
-
 	// BuildVersion is replaced at compile time with the current tag or revision.
 	BuildVersion = "dev"        //nolint:gochecknoglobals
 	BuildCommit  = "master"     //nolint:gochecknoglobals
@@ -122,6 +110,15 @@ func fromSteam3Strings(match []string) SteamID {
 		sid.Instance = InstanceWeb
 	}
 
+	if len(match) > 4 && match[4] != "" {
+		instanceInt, errInstanceInt := strconv.ParseUint(match[4][1:], 10, 64)
+		if errInstanceInt != nil {
+			return sid
+		}
+
+		sid.Instance = Instance(instanceInt & InstanceMask)
+	}
+
 	switch ir {
 	case "c":
 		sid.Instance |= ClanMask
@@ -221,6 +218,15 @@ func New(input any) SteamID {
 		return invalidSID
 	}
 
+	return fromNumber(intVal)
+}
+
+// fromNumber returns the id of a numeric steam64 or steam32 value.
+func fromNumber(intVal uint64) SteamID {
+	if intVal == 0 {
+		return invalidSID
+	}
+
 	if intVal < BaseSID {
 		return fromUInt64(intVal)
 	}
@@ -228,42 +234,93 @@ func New(input any) SteamID {
 	return fromAccountID(intVal)
 }
 
+// parseDigits parses an unsigned base 10 number without allocating, returning false when the
+// value is empty, holds anything but digits or overflows an uint64.
+func parseDigits(value []byte) (uint64, bool) {
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	var number uint64
+
+	for _, char := range value {
+		if char < '0' || char > '9' || number > (math.MaxUint64-uint64(char-'0'))/10 {
+			return 0, false
+		}
+
+		number = number*10 + uint64(char-'0')
+	}
+
+	return number, true
+}
+
 func (t *SteamID) Equal(id SteamID) bool {
 	return t.AccountID == id.AccountID && t.AccountType == id.AccountType && t.Instance == id.Instance && t.Universe == id.Universe
 }
 
 func (t *SteamID) String() string {
-	return fmt.Sprintf("%d", t.Int64())
+	return strconv.FormatUint(t.Uint64(), 10)
 }
 
+// Uint64 returns the steam64 value of the id, for storage and protocols using unsigned integers.
+func (t *SteamID) Uint64() uint64 {
+	return uint64(t.Universe)<<56 | uint64(t.AccountType)<<52 | uint64(t.Instance)<<32 | uint64(t.AccountID)
+}
+
+// Int64 returns the steam64 value of the id. Valid ids always fit, but values with the high bit set,
+// which only occur for universes above 127, saturate to math.MaxInt64 rather than overflowing into
+// negative numbers. Use Uint64 when such ids must be kept apart.
 func (t *SteamID) Int64() int64 {
-	return int64((uint64(t.Universe << 56)) | (uint64(t.AccountType) << 52) | (uint64(t.Instance) << 32) | uint64(t.AccountID))
+	value := t.Uint64()
+	if value > math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return int64(value)
 }
 
 // Valid ensures the value is at least large enough to be valid
 // No further validation is done.
 func (t *SteamID) Valid() bool {
+	return t.Validate() == nil
+}
+
+// Validate works like Valid, but returns the reason the id is not valid. The error wraps
+// ErrInvalidSID along with one of ErrInvalidAccountType, ErrInvalidUniverse, ErrInvalidAccountID
+// or ErrInvalidInstance.
+func (t *SteamID) Validate() error {
 	if t.AccountType <= AccountTypeInvalid || t.AccountType > AccountTypeAnonUser {
-		return false
+		return fmt.Errorf("%w: %w: %d", ErrInvalidSID, ErrInvalidAccountType, t.AccountType)
 	}
 
 	if t.Universe <= UniverseInvalid || t.Universe > UniverseDev {
-		return false
+		return fmt.Errorf("%w: %w: %d", ErrInvalidSID, ErrInvalidUniverse, t.Universe)
 	}
 
-	if t.AccountType == AccountTypeIndividual && (t.AccountID == 0 || t.Instance > InstanceWeb) {
-		return false
-	}
+	switch t.AccountType {
+	case AccountTypeIndividual:
+		if t.AccountID == 0 {
+			return fmt.Errorf("%w: %w", ErrInvalidSID, ErrInvalidAccountID)
+		}
 
-	if t.AccountType == AccountTypeClan && (t.AccountID == 0 || t.Instance != InstanceAll) {
-		return false
-	}
+		if t.Instance > InstanceWeb {
+			return fmt.Errorf("%w: %w: %d", ErrInvalidSID, ErrInvalidInstance, t.Instance)
+		}
+	case AccountTypeClan:
+		if t.AccountID == 0 {
+			return fmt.Errorf("%w: %w", ErrInvalidSID, ErrInvalidAccountID)
+		}
 
-	if t.AccountType == AccountTypeGameServer && t.AccountID == 0 {
-		return false
+		if t.Instance != InstanceAll {
+			return fmt.Errorf("%w: %w: %d", ErrInvalidSID, ErrInvalidInstance, t.Instance)
+		}
+	case AccountTypeGameServer:
+		if t.AccountID == 0 {
+			return fmt.Errorf("%w: %w", ErrInvalidSID, ErrInvalidAccountID)
+		}
 	}
 
-	return true
+	return nil
 }
 
 // Steam converts a given SID64 to a SteamID2 format.
@@ -312,37 +369,61 @@ func (t SteamID) MarshalJSON() ([]byte, error) {
 	return []byte("\"" + t.String() + "\""), nil
 }
 
-// UnmarshalJSON implements the Unmarshaler interface for steam ids. It will attempt to
-// do all steam id types by calling StringToSID64.
+// UnmarshalJSON implements the Unmarshaler interface for steam ids. Strings of any steam id
+// format are accepted, along with steam64 and steam32 numbers. The raw value is inspected directly,
+// so the common case of a quoted steam64 is decoded without allocating.
 func (t *SteamID) UnmarshalJSON(data []byte) error {
-	var (
-		sidInput  any
-		outputSid SteamID
-		err       error
-	)
-
-	if err = json.Unmarshal(data, &sidInput); err != nil {
-		return errors.Join(err, ErrDecodeSID)
-	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return parseError(string(data), ErrDecodeSID)
+	}
+
+	var sid SteamID
+
+	switch first := data[0]; {
+	case first == '"':
+		if len(data) < 2 || data[len(data)-1] != '"' || bytes.IndexAny(data[1:len(data)-1], "\\\"") >= 0 {
+			// Escaped values are rare enough to leave to the json package.
+			var value string
+			if errDecode := json.Unmarshal(data, &value); errDecode != nil {
+				return parseError(string(data), errors.Join(errDecode, ErrDecodeSID))
+			}
+
+			sid = New(value)
+		} else if number, isNumber := parseDigits(data[1 : len(data)-1]); isNumber {
+			sid = fromNumber(number)
+		} else {
+			sid = New(string(data[1 : len(data)-1]))
+		}
 
-	switch sid := sidInput.(type) {
-	case string:
-		outputSid = New(sid)
-		if !outputSid.Valid() {
-			return errors.Join(err, ErrUnmarshalStringSID)
+		if !sid.Valid() {
+			return parseError(string(data), ErrUnmarshalStringSID)
+		}
+	case first == '-' || (first >= '0' && first <= '9'):
+		number, isNumber := parseDigits(data)
+		if !isNumber {
+			if !json.Valid(data) {
+				return parseError(string(data), ErrDecodeSID)
+			}
+
+			return parseError(string(data), ErrInvalidSID)
 		}
 
-		*t = outputSid
-	case int64:
-		*t = New(fmt.Sprintf("%d", sid))
+		sid = fromNumber(number)
 	default:
-		return ErrInvalidSID
+		if !json.Valid(data) {
+			return parseError(string(data), ErrDecodeSID)
+		}
+
+		return parseError(string(data), ErrInvalidSID)
 	}
 
-	if !outputSid.Valid() {
-		return ErrInvalidSID
+	if !sid.Valid() {
+		return parseError(string(data), ErrInvalidSID)
 	}
 
+	*t = sid
+
 	return nil
 }
 
@@ -351,16 +432,6 @@ func (t SteamID) MarshalText() ([]byte, error) {
 	return []byte(t.String()), nil
 }
 
-// UnmarshalYAML implements the yaml.Unmarshaler interface for steam ids.
-func (t *SteamID) UnmarshalYAML(node *yaml.Node) error {
-	sid := New(node.Value)
-	if !sid.Valid() {
-		return ErrInvalidSID
-	}
-	*t = sid
-	return nil
-}
-
 func (t *SteamID) Scan(value interface{}) error {
 	if value == nil {
 		*t = SteamID{}
@@ -386,30 +457,16 @@ func (t *SteamID) Scan(value interface{}) error {
 		}
 	}
 
-	return ErrInvalidSID
+	return parseError(fmt.Sprint(value), ErrInvalidSID)
 }
 
+// Value implements driver.Valuer. Ids that do not fit a signed 64bit column, see Int64, are refused.
 func (t SteamID) Value() (driver.Value, error) {
-	return t.Int64(), nil
-}
-
-func KeyConfigured() bool {
-	return apiKey != ""
-}
-
-// SetKey will set the package global steam webapi key used for some requests
-// Basic id conversion usage does not require this to be set.
-//
-// You can alternatively set the key with the environment variable `STEAM_TOKEN={YOUR_API_KEY`
-// To get a key see: https://steamcommunity.com/dev/apikey
-func SetKey(key string) error {
-	if len(key) != 32 && len(key) != 0 {
-		return ErrInvalidKey
+	if t.Uint64() > math.MaxInt64 {
+		return nil, parseError(t.String(), ErrSIDConvertInt64)
 	}
 
-	apiKey = key
-
-	return nil
+	return t.Int64(), nil
 }
 
 var idGen = uint64(0) //nolint:gochecknoglobals
@@ -427,163 +484,58 @@ func RandSID64() SteamID {
 	return sid
 }
 
-// SID64FromString will attempt to convert a Steam64 formatted string into a SID64.
-func SID64FromString(steamID string) (SteamID, error) {
-	if steamID == "" {
-		return SteamID{}, errors.Join(ErrInvalidSID, ErrEmptyString)
+// RandomSteamID generates a random valid steam id of the account type for testing. Ids are
+// drawn from rng, allowing repeatable sequences when it is created with a fixed seed, or from
+// the global source when rng is nil. Individual, clan, game server and anonymous game server
+// ids are supported.
+func RandomSteamID(rng *rand.Rand, accountType AccountType) (SteamID, error) {
+	uint32N := rand.Uint32N
+	if rng != nil {
+		uint32N = rng.Uint32N
 	}
 
-	i, err := strconv.ParseInt(steamID, 10, 64)
-	if err != nil {
-		return SteamID{}, errors.Join(err, ErrSIDConvertInt64)
+	sid := SteamID{
+		// Account id 0 is never valid.
+		AccountID:   SID32(uint32N(math.MaxUint32) + 1),
+		Instance:    InstanceAll,
+		AccountType: accountType,
+		Universe:    UniversePublic,
 	}
 
-	sid := New(i)
-	if !sid.Valid() {
-		return SteamID{}, ErrInvalidSID
+	switch accountType {
+	case AccountTypeIndividual:
+		sid.Instance = InstanceDesktop
+	case AccountTypeClan, AccountTypeGameServer:
+	case AccountTypeAnonGameServer:
+		// The upper instance bits are flags used by chat ids.
+		sid.Instance = Instance(uint32N(MMSLobby))
+	default:
+		return invalidSID, fmt.Errorf("%w: %s", ErrInvalidSID, accountType.String())
 	}
 
 	return sid, nil
 }
 
-// ResolveGID tries to resolve the GroupID from a group custom URL.
-// NOTE This may be prone to error due to not being a real api endpoint.
-func ResolveGID(ctx context.Context, groupVanityURL string) (SteamID, error) {
-	m := reGroupURL.FindStringSubmatch(groupVanityURL)
-	if len(m) > 0 {
-		groupVanityURL = m[1]
-	}
-
-	u := "https://steamcommunity.com/groups/" + groupVanityURL + "/memberslistxml?xml=1"
-
-	req, errReq := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
-	if errReq != nil {
-		return SteamID{}, errors.Join(errReq, ErrRequestCreate)
-	}
-
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return SteamID{}, errors.Join(err, ErrResponsePerform)
-	}
-
-	content, errRead := io.ReadAll(resp.Body)
-	if errRead != nil {
-		return SteamID{}, errors.Join(errRead, ErrResponseBody)
-	}
-
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	groupIDTags := reGroupIDTags.FindStringSubmatch(string(content))
-	if len(groupIDTags) >= 2 {
-		gid := New(groupIDTags[1])
-		if !gid.Valid() || gid.AccountType != AccountTypeClan {
-			return SteamID{}, ErrInvalidGID
-		}
-
-		return gid, nil
-	}
-
-	return SteamID{}, ErrResolveVanityGID
-}
-
-type vanityURLResponse struct {
-	Response struct {
-		SteamID SteamID `json:"steamid"`
-		Success int     `json:"success"`
-	} `json:"response"`
-}
-
-// ResolveVanity attempts to resolve the underlying SID64 of a users vanity url name
-// This only accepts the name or last portion of the /id/ profile link
-// For https://steamcommunity.com/id/SQUIRRELLY the value is SQUIRRELLY.
-func ResolveVanity(ctx context.Context, query string) (SteamID, error) {
-	if apiKey == "" {
-		return SteamID{}, ErrNoAPIKey
+// SID64FromString will attempt to convert a Steam64 formatted string into a SID64.
+func SID64FromString(steamID string) (SteamID, error) {
+	if steamID == "" {
+		return SteamID{}, parseError(steamID, errors.Join(ErrInvalidSID, ErrEmptyString))
 	}
 
-	u := urlVanity + url.Values{"key": {apiKey}, "vanityurl": {query}}.Encode()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	i, err := strconv.ParseInt(steamID, 10, 64)
 	if err != nil {
-		return SteamID{}, errors.Join(err, ErrRequestCreate)
-	}
-
-	resp, errDo := httpClient.Do(req)
-	if errDo != nil {
-		return SteamID{}, errors.Join(errDo, ErrResponsePerform)
-	}
-
-	defer func() {
-		_ = resp.Body.Close()
-	}()
-
-	var vanityResp vanityURLResponse
-	if errUnmarshal := json.NewDecoder(resp.Body).Decode(&vanityResp); err != nil {
-		return SteamID{}, errors.Join(errUnmarshal, ErrDecodeSID)
-	}
-
-	if vanityResp.Response.Success != 1 {
-		return SteamID{}, fmt.Errorf("%w: %d", ErrInvalidStatusCode, vanityResp.Response.Success)
-	}
-
-	if !vanityResp.Response.SteamID.Valid() {
-		return SteamID{}, fmt.Errorf("%w: %s", ErrInvalidSID, vanityResp.Response.SteamID.String())
-	}
-
-	return vanityResp.Response.SteamID, nil
-}
-
-// Resolve tries to retrieve a SteamID from a profile URL.
-//
-// If an error occurs or the SteamID was unable to be resolved from the query
-// then am error is returned.
-// TODO try and resolve len(17) && len(9) failed conversions as vanity.
-func Resolve(ctx context.Context, query string) (SteamID, error) {
-	query = strings.ReplaceAll(query, " ", "")
-	if strings.Contains(query, "steamcommunity.com/profiles/") {
-		if string(query[len(query)-1]) == "/" {
-			query = query[0 : len(query)-1]
-		}
-
-		output, err := strconv.ParseInt(query[strings.Index(query, "steamcommunity.com/profiles/")+len("steamcommunity.com/profiles/"):], 10, 64)
-		if err != nil {
-			return SteamID{}, errors.Join(err, ErrInvalidQueryValue)
-		}
-
-		// query = strings.Replace(query, "/", "", -1)
-		if len(strconv.FormatInt(output, 10)) != 17 {
-			return SteamID{}, errors.Join(err, ErrInvalidQueryLen)
-		}
-
-		return New(output), nil
-	} else if strings.Contains(query, "steamcommunity.com/id/") {
-		if string(query[len(query)-1]) == "/" {
-			query = query[0 : len(query)-1]
-		}
-		query = query[strings.Index(query, "steamcommunity.com/id/")+len("steamcommunity.com/id/"):]
-		return ResolveVanity(ctx, query)
+		return SteamID{}, parseError(steamID, errors.Join(err, ErrSIDConvertInt64))
 	}
 
-	s := New(query)
-	if s.Valid() {
-		return s, nil
+	sid := New(i)
+	if !sid.Valid() {
+		return SteamID{}, parseError(steamID, ErrInvalidSID)
 	}
 
-	return ResolveVanity(ctx, query)
+	return sid, nil
 }
 
 func init() {
 	reSteam2 = regexp.MustCompile(`^STEAM_([0-5]):([0-1]):([0-9]+)$`)
 	reSteam3 = regexp.MustCompile(`^\[([a-zA-Z]):([0-5]):([0-9]+)(:[0-9]+)?]$`)
-	if t, found := os.LookupEnv("STEAM_TOKEN"); found && t != "" {
-		if err := SetKey(t); err != nil {
-			panic(err)
-		}
-	}
-
-	httpClient = &http.Client{
-		Timeout: time.Second * 10,
-	}
 }
@@ -1,15 +1,13 @@
 package steamid_test
 
 import (
-	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"os"
+	"math"
+	"math/rand/v2"
 	"testing"
 
-	"gopkg.in/yaml.v3"
-
 	_ "github.com/glebarez/go-sqlite"
 	"github.com/leighmacdonald/steamid/v4/steamid"
 	"github.com/stretchr/testify/require"
@@ -35,6 +33,22 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestUint64(t *testing.T) {
+	t.Parallel()
+
+	sid := steamid.New(uint64(76561198045011302))
+	require.Equal(t, uint64(76561198045011302), sid.Uint64())
+	require.Equal(t, int64(76561198045011302), sid.Int64())
+
+	high := steamid.New(uint64(math.MaxUint64 - 1))
+	require.Equal(t, uint64(math.MaxUint64-1), high.Uint64())
+	require.Equal(t, int64(math.MaxInt64), high.Int64())
+	require.Equal(t, "18446744073709551614", high.String())
+
+	_, errValue := high.Value()
+	require.ErrorIs(t, errValue, steamid.ErrSIDConvertInt64)
+}
+
 func TestSID64FromString(t *testing.T) {
 	t.Parallel()
 
@@ -88,6 +102,32 @@ func TestConversions(t *testing.T) {
 	require.Equal(t, ii.Steam(false), steamid.SID("STEAM_0:0:86173181"))
 }
 
+func TestAliasTypes(t *testing.T) {
+	t.Parallel()
+
+	expected := steamid.New(76561198132612090)
+
+	require.Equal(t, expected, steamid.SID("STEAM_0:0:86173181").SteamID())
+	require.Equal(t, expected, steamid.SID3("[U:1:172346362]").SteamID())
+	require.Equal(t, expected, steamid.SID32(172346362).SteamID())
+
+	require.True(t, steamid.SID("STEAM_1:0:86173181").Valid())
+	require.True(t, steamid.SID3("[g:1:4]").Valid())
+	require.True(t, steamid.SID32(1).Valid())
+
+	// The format is not detected, so other formats are not valid.
+	require.False(t, steamid.SID("[U:1:172346362]").Valid())
+	require.False(t, steamid.SID3("STEAM_0:0:86173181").Valid())
+	require.False(t, steamid.SID3("76561198132612090").Valid())
+	require.False(t, steamid.SID32(0).Valid())
+	invalid := steamid.SID32(0).SteamID()
+	require.False(t, invalid.Valid())
+
+	require.Equal(t, "STEAM_0:0:86173181", steamid.SID("STEAM_0:0:86173181").String())
+	require.Equal(t, "[U:1:172346362]", expected.Steam3().String())
+	require.Equal(t, "172346362", expected.AccountID.String())
+}
+
 func TestJSON(t *testing.T) {
 	t.Parallel()
 
@@ -114,6 +154,37 @@ func TestJSON(t *testing.T) {
 	expectedGID := steamid.New(103582791441572968)
 
 	require.Equal(t, expectedGID.Int64(), r.GID.Int64())
+
+	for input, expectedSID := range map[string]steamid.SteamID{
+		`76561197970669109`:             expected,
+		`"[U:1:10403381]"`:              expected,
+		` "STEAM_0:1:5201690" `:         expected,
+		`10403381`:                      expected,
+		`"\u0037\u0036561197970669109"`: expected,
+		`"103582791441572968"`:          expectedGID,
+	} {
+		var sid steamid.SteamID
+		require.NoError(t, sid.UnmarshalJSON([]byte(input)), input)
+		require.Equal(t, expectedSID, sid, input)
+	}
+
+	for input, expectedErr := range map[string]error{
+		`"not an id"`:          steamid.ErrUnmarshalStringSID,
+		`""`:                   steamid.ErrUnmarshalStringSID,
+		`0`:                    steamid.ErrInvalidSID,
+		`-1`:                   steamid.ErrInvalidSID,
+		`1.5`:                  steamid.ErrInvalidSID,
+		`null`:                 steamid.ErrInvalidSID,
+		`99999999999999999999`: steamid.ErrInvalidSID,
+		`"`:                    steamid.ErrDecodeSID,
+		`"76561197970669109`:   steamid.ErrDecodeSID,
+		`76561197970669109abc`: steamid.ErrDecodeSID,
+		``:                     steamid.ErrDecodeSID,
+	} {
+		var sid steamid.SteamID
+		require.ErrorIs(t, sid.UnmarshalJSON([]byte(input)), expectedErr, input)
+		require.False(t, sid.Valid(), input)
+	}
 }
 
 func TestSQL(t *testing.T) {
@@ -144,91 +215,58 @@ func TestSQL(t *testing.T) {
 	require.Equal(t, testID, outAccountID)
 }
 
-func TestYAML(t *testing.T) {
+func TestRandomSteamID(t *testing.T) {
 	t.Parallel()
 
-	type testFormats struct {
-		Quoted steamid.SteamID `yaml:"quoted"`
+	for _, accountType := range []steamid.AccountType{
+		steamid.AccountTypeIndividual, steamid.AccountTypeClan,
+		steamid.AccountTypeGameServer, steamid.AccountTypeAnonGameServer,
+	} {
+		for range 100 {
+			sid, errRandom := steamid.RandomSteamID(nil, accountType)
+			require.NoError(t, errRandom)
+			require.True(t, sid.Valid())
+			require.Equal(t, accountType, sid.AccountType)
+			require.Equal(t, sid, steamid.New(sid.String()))
+		}
 	}
 
-	var out testFormats
-	require.NoError(t, yaml.Unmarshal([]byte(`{"quoted":"76561197970669109"}`), &out))
-
-	expected := steamid.New(76561197970669109)
-	require.Equal(t, expected, out.Quoted, "Quoted value invalid")
-
-	body, errMarshal := yaml.Marshal(&expected)
-	require.NoError(t, errMarshal)
-	require.Equal(t, []byte("\"76561197970669109\"\n"), body)
+	seeded := func() steamid.SteamID {
+		sid, _ := steamid.RandomSteamID(rand.New(rand.NewPCG(1, 2)), steamid.AccountTypeIndividual)
 
-	type testGIDResp struct {
-		GID steamid.SteamID `json:"gid"`
+		return sid
 	}
 
-	var r testGIDResp
-	require.NoError(t, yaml.Unmarshal([]byte(`{"gid":"103582791441572968"}`), &r))
-	expectedGID := steamid.New(103582791441572968)
+	require.Equal(t, seeded(), seeded())
 
-	require.Equal(t, expectedGID.Int64(), r.GID.Int64())
+	_, errType := steamid.RandomSteamID(nil, steamid.AccountTypeChat)
+	require.ErrorIs(t, errType, steamid.ErrInvalidSID)
 }
 
-func TestResolveGID(t *testing.T) {
+func TestValidate(t *testing.T) {
 	t.Parallel()
 
-	gid1, err := steamid.ResolveGID(context.Background(), "SQ_Stream")
-
-	require.NoError(t, err, "Failed to fetch gid")
-	require.True(t, gid1.Valid())
-	require.Equal(t, gid1, steamid.New(103582791441572968))
-
-	gid2, err2 := steamid.ResolveGID(context.Background(), "SQTreeHouseHJHJHSDAF")
-
-	require.Errorf(t, err2, "Failed to fetch gid2")
-	require.False(t, gid2.Valid())
-}
-
-func TestResolveSID(t *testing.T) {
-	t.Parallel()
-
-	if !steamid.KeyConfigured() {
-		t.Skip("steam_api_key unset, SetKey() required")
-
-		return
-	}
-
-	sid1, err := steamid.Resolve(context.Background(), "https://steamcommunity.com/id/SQUIRRELLY")
-	require.NoError(t, err)
-	require.Equal(t, sid1, steamid.New(76561197961279983))
-
-	sid2, err2 := steamid.Resolve(context.Background(), "https://steamcommunity.com/id/FAKEXXXXXXXXXX123123")
-	require.Error(t, err2)
-	require.False(t, sid2.Valid())
-
-	sid3, err3 := steamid.Resolve(context.Background(), "http://steamcommunity.com/profiles/76561197961279983")
-	require.NoError(t, err3)
-	require.Equal(t, sid3, steamid.New(76561197961279983))
-
-	sid4, err4 := steamid.Resolve(context.Background(), "[U:1:1014255]")
-	require.NoError(t, err4)
-	require.Equal(t, sid4, steamid.New(76561197961279983))
-
-	sid5, err5 := steamid.Resolve(context.Background(), "STEAM_0:1:507127")
-	require.Equal(t, sid5, steamid.New(76561197961279983))
-	require.NoError(t, err5)
-
-	sid6, err6 := steamid.Resolve(context.Background(), "")
-	require.Error(t, err6)
-	require.False(t, sid6.Valid())
-}
-
-func TestMain(m *testing.M) {
-	key, found := os.LookupEnv("STEAM_TOKEN")
-
-	if found {
-		if e := steamid.SetKey(key); e != nil {
-			panic(e.Error())
-		}
+	valid := steamid.New(76561197960287930)
+	require.NoError(t, valid.Validate())
+
+	for _, testCase := range []struct {
+		sid steamid.SteamID
+		err error
+	}{
+		{sid: steamid.New("nope"), err: steamid.ErrInvalidAccountType},
+		{sid: steamid.SteamID{AccountID: 1, AccountType: steamid.AccountTypeIndividual}, err: steamid.ErrInvalidUniverse},
+		{
+			sid: steamid.SteamID{AccountType: steamid.AccountTypeIndividual, Universe: steamid.UniversePublic},
+			err: steamid.ErrInvalidAccountID,
+		},
+		{
+			sid: steamid.SteamID{AccountID: 1, AccountType: steamid.AccountTypeClan, Instance: steamid.InstanceDesktop, Universe: steamid.UniversePublic},
+			err: steamid.ErrInvalidInstance,
+		},
+	} {
+		errValidate := testCase.sid.Validate()
+		require.ErrorIs(t, errValidate, steamid.ErrInvalidSID)
+		require.ErrorIs(t, errValidate, testCase.err)
+		require.False(t, testCase.sid.Valid())
 	}
-
-	os.Exit(m.Run())
 }
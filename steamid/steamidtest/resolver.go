@@ -0,0 +1,63 @@
+//go:build !steamid_nonet
+
+package steamidtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// Resolver is a steamid.Resolver answering vanity lookups from the Vanity map instead of the web
+// api. Queries are otherwise handled exactly as by steamid.Client, and vanity names without an entry
+// fail like a web api miss, with an error wrapping steamid.ErrVanityNotFound. It is safe for
+// concurrent use.
+type Resolver struct {
+	// Vanity maps vanity names to their ids.
+	Vanity map[string]steamid.SteamID
+	// Err, when set, is returned for every query.
+	Err error
+
+	mu      sync.Mutex
+	queries []string
+}
+
+// vanityTransport is a http.RoundTripper answering ResolveVanityURL requests from the map.
+type vanityTransport map[string]steamid.SteamID
+
+func (v vanityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	sid, found := v[req.URL.Query().Get("vanityurl")]
+	writeVanity(recorder, sid, found)
+
+	return recorder.Result(), nil
+}
+
+// Resolve implements steamid.Resolver.
+func (r *Resolver) Resolve(ctx context.Context, query string) (steamid.SteamID, error) {
+	r.mu.Lock()
+	r.queries = append(r.queries, query)
+	r.mu.Unlock()
+
+	if r.Err != nil {
+		return steamid.SteamID{}, r.Err
+	}
+
+	client, errClient := steamid.NewClient(Key, steamid.WithHTTPClient(&http.Client{Transport: vanityTransport(r.Vanity)}))
+	if errClient != nil {
+		return steamid.SteamID{}, errClient //nolint:wrapcheck
+	}
+
+	return client.Resolve(ctx, query) //nolint:wrapcheck
+}
+
+// Queries returns the queries resolved so far, in order.
+func (r *Resolver) Queries() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.queries...)
+}
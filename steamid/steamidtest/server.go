@@ -0,0 +1,191 @@
+//go:build !steamid_nonet
+
+package steamidtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// Key is the web api key accepted by Server.
+const Key = "0123456789ABCDEF0123456789ABCDEF"
+
+// vanityNoMatch is the success value of a ResolveVanityURL response that found no match.
+const vanityNoMatch = 42
+
+// Server is a httptest.Server mimicking the ResolveVanityURL, for profiles and groups,
+// GetPlayerSummaries and GetPlayerBans endpoints of the steam web api. Requests without Key are rejected with a 403 as
+// the web api does, and ids without a summary or ban state are left out of the responses. Its
+// methods are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	vanity    map[string]steamid.SteamID
+	groups    map[string]steamid.SteamID
+	summaries map[steamid.SteamID]steamid.PlayerSummary
+	bans      map[steamid.SteamID]steamid.PlayerBanState
+	requests  int
+}
+
+// NewServer starts a Server which is closed when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	server := &Server{
+		vanity:    map[string]steamid.SteamID{},
+		groups:    map[string]steamid.SteamID{},
+		summaries: map[steamid.SteamID]steamid.PlayerSummary{},
+		bans:      map[steamid.SteamID]steamid.PlayerBanState{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ISteamUser/ResolveVanityURL/v0001/", server.onResolveVanity)
+	mux.HandleFunc("GET /ISteamUser/GetPlayerSummaries/v0002/", server.onPlayerSummaries)
+	mux.HandleFunc("GET /ISteamUser/GetPlayerBans/v1/", server.onPlayerBans)
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		server.requests++
+		server.mu.Unlock()
+
+		if r.URL.Query().Get("key") != Key {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// Client returns a client using Key which sends its requests to the server.
+func (s *Server) Client(t testing.TB) *steamid.Client {
+	t.Helper()
+
+	client, errClient := steamid.NewClient(Key, steamid.WithBaseURL(s.URL), steamid.WithHTTPClient(s.Server.Client()))
+	if errClient != nil {
+		t.Fatalf("failed to create client: %v", errClient)
+	}
+
+	return client
+}
+
+// AddVanity adds a vanity name resolving to the id.
+func (s *Server) AddVanity(name string, sid steamid.SteamID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.vanity[name] = sid
+}
+
+// AddGroupVanity adds a group vanity name resolving to the id.
+func (s *Server) AddGroupVanity(name string, gid steamid.SteamID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.groups[name] = gid
+}
+
+// AddSummary adds the summary returned for its SteamID.
+func (s *Server) AddSummary(summary steamid.PlayerSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.summaries[summary.SteamID] = summary
+}
+
+// AddBans adds the ban state returned for its SteamID.
+func (s *Server) AddBans(ban steamid.PlayerBanState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bans[ban.SteamID] = ban
+}
+
+// Requests returns the number of requests received, including rejected ones.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// requestIDs returns the ids of the steamids query parameter.
+func requestIDs(r *http.Request) steamid.Collection {
+	var ids steamid.Collection
+
+	for _, value := range strings.Split(r.URL.Query().Get("steamids"), ",") {
+		if sid := steamid.New(value); sid.Valid() {
+			ids = append(ids, sid)
+		}
+	}
+
+	return ids
+}
+
+func (s *Server) onResolveVanity(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	names := s.vanity
+	if r.URL.Query().Get("url_type") == "2" {
+		names = s.groups
+	}
+
+	sid, found := names[r.URL.Query().Get("vanityurl")]
+	s.mu.Unlock()
+
+	writeVanity(w, sid, found)
+}
+
+// writeVanity writes the ResolveVanityURL response of the id, or of a miss when it was not found.
+func writeVanity(w http.ResponseWriter, sid steamid.SteamID, found bool) {
+	if !found {
+		writeJSON(w, map[string]any{"response": map[string]any{"success": vanityNoMatch, "message": "No match"}})
+
+		return
+	}
+
+	writeJSON(w, map[string]any{"response": map[string]any{"success": 1, "steamid": sid}})
+}
+
+func (s *Server) onPlayerSummaries(w http.ResponseWriter, r *http.Request) {
+	players := []steamid.PlayerSummary{}
+
+	s.mu.Lock()
+	for _, sid := range requestIDs(r) {
+		if summary, found := s.summaries[sid]; found {
+			players = append(players, summary)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, map[string]any{"response": map[string]any{"players": players}})
+}
+
+func (s *Server) onPlayerBans(w http.ResponseWriter, r *http.Request) {
+	players := []steamid.PlayerBanState{}
+
+	s.mu.Lock()
+	for _, sid := range requestIDs(r) {
+		if ban, found := s.bans[sid]; found {
+			players = append(players, ban)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, map[string]any{"players": players})
+}
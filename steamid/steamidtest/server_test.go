@@ -0,0 +1,83 @@
+//go:build !steamid_nonet
+
+package steamidtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/leighmacdonald/steamid/v4/steamid/steamidtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	t.Parallel()
+
+	var (
+		resolver                  = &steamidtest.Resolver{Vanity: map[string]steamid.SteamID{"SQUIRRELLY": steamidtest.Individual2}}
+		ctx                       = context.Background()
+		_        steamid.Resolver = resolver
+	)
+
+	for _, query := range []string{"SQUIRRELLY", "https://steamcommunity.com/id/SQUIRRELLY/", "[U:1:172346362]",
+		"https://steamcommunity.com/profiles/76561198132612090", "www.steamcommunity.com/id/SQUIRRELLY/badges?l=english"} {
+		sid, errResolve := resolver.Resolve(ctx, query)
+		require.NoError(t, errResolve, query)
+		steamidtest.RequireEqualSID(t, steamidtest.Individual2, sid, query)
+	}
+
+	_, errMissing := resolver.Resolve(ctx, "unknown")
+	require.ErrorIs(t, errMissing, steamid.ErrVanityNotFound)
+
+	_, errEmpty := resolver.Resolve(ctx, " ")
+	require.ErrorIs(t, errEmpty, steamid.ErrInvalidQueryValue)
+
+	require.Len(t, resolver.Queries(), 7)
+
+	errFail := errors.New("failed")
+	_, errResolve := (&steamidtest.Resolver{Err: errFail}).Resolve(ctx, "SQUIRRELLY")
+	require.ErrorIs(t, errResolve, errFail)
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		server = steamidtest.NewServer(t)
+		client = server.Client(t)
+		ctx    = context.Background()
+	)
+
+	server.AddVanity("SQUIRRELLY", steamidtest.Individual2)
+	server.AddSummary(steamid.PlayerSummary{SteamID: steamidtest.Individual, PersonaName: "player"})
+	server.AddBans(steamid.PlayerBanState{SteamID: steamidtest.Individual2, VACBanned: true, NumberOfVACBans: 1})
+
+	sid, errResolve := client.Resolve(ctx, "https://steamcommunity.com/id/SQUIRRELLY")
+	require.NoError(t, errResolve)
+	steamidtest.RequireEqualSID(t, steamidtest.Individual2, sid)
+
+	_, errMissing := client.ResolveVanity(ctx, "unknown")
+	require.ErrorIs(t, errMissing, steamid.ErrVanityNotFound)
+
+	ids := steamid.Collection{steamidtest.Individual, steamidtest.Individual2}
+
+	summaries, errSummaries := client.PlayerSummaries(ctx, ids)
+	require.NoError(t, errSummaries)
+	require.Len(t, summaries, 1)
+	require.Equal(t, "player", summaries[0].PersonaName)
+
+	bans, errBans := client.PlayerBans(ctx, ids)
+	require.NoError(t, errBans)
+	require.Len(t, bans, 1)
+	steamidtest.RequireEqualSID(t, steamidtest.Individual2, bans[0].SteamID)
+	require.True(t, bans[0].VACBanned)
+
+	badKey, errClient := steamid.NewClient("FEDCBA9876543210FEDCBA9876543210", steamid.WithBaseURL(server.URL))
+	require.NoError(t, errClient)
+
+	_, errForbidden := badKey.PlayerBans(ctx, ids)
+	require.ErrorIs(t, errForbidden, steamid.ErrInvalidStatusCode)
+	require.Equal(t, 5, server.Requests())
+}
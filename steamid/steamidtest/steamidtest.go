@@ -0,0 +1,84 @@
+// Package steamidtest provides utilities for testing code using steam ids and the steam web api
+// client without network access: fixture ids, assertions, a fake Resolver and a Server mimicking
+// the web api endpoints used by steamid.Client. The Resolver and Server are left out of builds with
+// the steamid_nonet tag, which have no client.
+package steamidtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+)
+
+// Fixture ids of each of the common account types. They never change, so they can be used in
+// golden files.
+var (
+	// Individual is the individual account [U:1:22202].
+	Individual = steamid.New(76561197960287930) //nolint:gochecknoglobals
+	// Individual2 is the individual account [U:1:172346362].
+	Individual2 = steamid.New(76561198132612090) //nolint:gochecknoglobals
+	// Clan is the group [g:1:4].
+	Clan = steamid.New(103582791429521412) //nolint:gochecknoglobals
+	// GameServer is the persistent game server [G:1:1234].
+	GameServer = steamid.New("[G:1:1234]") //nolint:gochecknoglobals
+	// AnonGameServer is the anonymous game server [A:1:1234:5678].
+	AnonGameServer = steamid.New("[A:1:1234:5678]") //nolint:gochecknoglobals
+)
+
+// describe formats the id in every format, so the differences between two ids are easy to spot.
+func describe(sid steamid.SteamID) string {
+	if !sid.Valid() {
+		return fmt.Sprintf("%s (invalid)", sid.String())
+	}
+
+	return fmt.Sprintf("%s %s %s (%s)", sid.String(), sid.Steam3(), sid.Steam(false), sid.AccountType)
+}
+
+// RequireEqualSID fails the test immediately when the ids are not equal, reporting both ids in
+// every format.
+func RequireEqualSID(t testing.TB, expected steamid.SteamID, actual steamid.SteamID, msgAndArgs ...any) {
+	t.Helper()
+
+	if expected == actual {
+		return
+	}
+
+	t.Fatalf("steam ids are not equal%s\n  expected: %s\n  actual:   %s", message(msgAndArgs), describe(expected), describe(actual))
+}
+
+// RequireEqualCollection fails the test immediately when the collections do not hold the same ids
+// in the same order, reporting the first difference.
+func RequireEqualCollection(t testing.TB, expected steamid.Collection, actual steamid.Collection, msgAndArgs ...any) {
+	t.Helper()
+
+	for idx := range min(len(expected), len(actual)) {
+		if expected[idx] != actual[idx] {
+			t.Fatalf("steam ids are not equal at index %d%s\n  expected: %s\n  actual:   %s", idx, message(msgAndArgs),
+				describe(expected[idx]), describe(actual[idx]))
+		}
+	}
+
+	if len(expected) != len(actual) {
+		t.Fatalf("steam id collections have different lengths%s\n  expected: %d\n  actual:   %d", message(msgAndArgs),
+			len(expected), len(actual))
+	}
+}
+
+// message formats the optional message of an assertion, either a single value or a format string
+// followed by its arguments.
+func message(msgAndArgs []any) string {
+	switch {
+	case len(msgAndArgs) == 0:
+		return ""
+	case len(msgAndArgs) == 1:
+		return fmt.Sprintf(": %v", msgAndArgs[0])
+	default:
+		format, isString := msgAndArgs[0].(string)
+		if !isString {
+			return fmt.Sprintf(": %v", msgAndArgs)
+		}
+
+		return ": " + fmt.Sprintf(format, msgAndArgs[1:]...)
+	}
+}
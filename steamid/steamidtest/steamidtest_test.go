@@ -0,0 +1,60 @@
+package steamidtest_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/leighmacdonald/steamid/v4/steamid/steamidtest"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records the first failure instead of stopping the test.
+type recorder struct {
+	testing.TB
+	failure string
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	if r.failure == "" {
+		r.failure = fmt.Sprintf(format, args...)
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	t.Parallel()
+
+	for _, sid := range []steamid.SteamID{steamidtest.Individual, steamidtest.Individual2, steamidtest.Clan,
+		steamidtest.GameServer, steamidtest.AnonGameServer} {
+		require.True(t, sid.Valid())
+	}
+
+	require.Equal(t, steamid.AccountTypeIndividual, steamidtest.Individual.AccountType)
+	require.Equal(t, steamid.AccountTypeClan, steamidtest.Clan.AccountType)
+	require.Equal(t, steamid.AccountTypeGameServer, steamidtest.GameServer.AccountType)
+	require.Equal(t, steamid.AccountTypeAnonGameServer, steamidtest.AnonGameServer.AccountType)
+}
+
+func TestRequireEqual(t *testing.T) {
+	t.Parallel()
+
+	rec := &recorder{TB: t}
+	steamidtest.RequireEqualSID(rec, steamidtest.Individual, steamidtest.Individual)
+	steamidtest.RequireEqualCollection(rec, steamid.Collection{steamidtest.Clan}, steamid.Collection{steamidtest.Clan})
+	require.Empty(t, rec.failure)
+
+	steamidtest.RequireEqualSID(rec, steamidtest.Individual, steamidtest.Individual2, "player %d", 1)
+	require.Equal(t, "steam ids are not equal: player 1\n"+
+		"  expected: 76561197960287930 [U:1:22202] STEAM_0:0:11101 (Individual)\n"+
+		"  actual:   76561198132612090 [U:1:172346362] STEAM_0:0:86173181 (Individual)", rec.failure)
+
+	rec = &recorder{TB: t}
+	steamidtest.RequireEqualCollection(rec, steamid.Collection{steamidtest.Clan, steamidtest.Individual},
+		steamid.Collection{steamidtest.Clan, steamid.SteamID{}})
+	require.Contains(t, rec.failure, "at index 1")
+	require.Contains(t, rec.failure, "(invalid)")
+
+	rec = &recorder{TB: t}
+	steamidtest.RequireEqualCollection(rec, steamid.Collection{steamidtest.Clan}, nil)
+	require.Contains(t, rec.failure, "different lengths")
+}
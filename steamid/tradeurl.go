@@ -0,0 +1,69 @@
+package steamid
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// tradeOfferURL is the address of new trade offers, which the partner and token parameters are
+// appended to.
+const tradeOfferURL = "https://steamcommunity.com/tradeoffer/new/"
+
+// TradePartner returns the partner parameter of trade offer urls for the id, which is its account
+// id. e.g. 76561198132612090 -> 172346362
+//
+// An empty string is returned for ids that are not valid individual accounts.
+func (t *SteamID) TradePartner() string {
+	if !t.Valid() || t.AccountType != AccountTypeIndividual {
+		return ""
+	}
+
+	return t.AccountID.String()
+}
+
+// TradeURL returns the trade offer url of the id, including the token when it is not empty. The
+// token is required to send offers to players who are not friends.
+// e.g. https://steamcommunity.com/tradeoffer/new/?partner=172346362&token=AbCdEfGh
+//
+// An empty string is returned for ids that are not valid individual accounts.
+func (t *SteamID) TradeURL(token string) string {
+	partner := t.TradePartner()
+	if partner == "" {
+		return ""
+	}
+
+	values := url.Values{"partner": {partner}}
+	if token != "" {
+		values.Set("token", token)
+	}
+
+	return tradeOfferURL + "?" + values.Encode()
+}
+
+// FromTradeURL returns the id of the partner of a trade offer url, such as
+// https://steamcommunity.com/tradeoffer/new/?partner=172346362&token=AbCdEfGh. The scheme and www
+// subdomain are optional and the token is ignored.
+func FromTradeURL(input string) (SteamID, error) {
+	query := strings.TrimSpace(input)
+	if !strings.Contains(query, "://") {
+		query = "https://" + query
+	}
+
+	parsed, errParse := url.Parse(query)
+	if errParse != nil {
+		return invalidSID, parseError(input, ErrInvalidTradeURL)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if host != "steamcommunity.com" || strings.Trim(parsed.Path, "/") != "tradeoffer/new" {
+		return invalidSID, parseError(input, ErrInvalidTradeURL)
+	}
+
+	accountID, errAccountID := strconv.ParseUint(parsed.Query().Get("partner"), 10, 32)
+	if errAccountID != nil || accountID == 0 {
+		return invalidSID, parseError(input, ErrInvalidTradeURL)
+	}
+
+	return SID32(accountID).SteamID(), nil
+}
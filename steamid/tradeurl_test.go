@@ -0,0 +1,40 @@
+package steamid_test
+
+import (
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTradeURL(t *testing.T) {
+	t.Parallel()
+
+	sid := steamid.New(76561198132612090)
+	require.Equal(t, "172346362", sid.TradePartner())
+	require.Equal(t, "https://steamcommunity.com/tradeoffer/new/?partner=172346362&token=AbCdEfGh", sid.TradeURL("AbCdEfGh"))
+	require.Equal(t, "https://steamcommunity.com/tradeoffer/new/?partner=172346362", sid.TradeURL(""))
+
+	for _, input := range []string{
+		sid.TradeURL("AbCdEfGh"), sid.TradeURL(""), "steamcommunity.com/tradeoffer/new?partner=172346362",
+		" http://www.steamcommunity.com/tradeoffer/new/?token=AbCdEfGh&partner=172346362 ",
+	} {
+		decoded, errDecode := steamid.FromTradeURL(input)
+		require.NoError(t, errDecode, input)
+		require.Equal(t, sid, decoded, input)
+	}
+
+	for _, input := range []string{
+		"", "https://steamcommunity.com/tradeoffer/new/", "https://steamcommunity.com/tradeoffer/new/?partner=0",
+		"https://steamcommunity.com/tradeoffer/new/?partner=4294967296", "https://example.com/tradeoffer/new/?partner=172346362",
+		"https://steamcommunity.com/profiles/76561198132612090",
+	} {
+		_, errDecode := steamid.FromTradeURL(input)
+		require.ErrorIs(t, errDecode, steamid.ErrInvalidTradeURL, input)
+		require.ErrorIs(t, errDecode, steamid.ErrParse, input)
+	}
+
+	clan := steamid.New(103582791429521412)
+	require.Empty(t, clan.TradePartner())
+	require.Empty(t, clan.TradeURL("AbCdEfGh"))
+}
@@ -3,10 +3,10 @@ package steamid
 import (
 	"errors"
 	"slices"
+	"strconv"
 )
 
 const (
-	urlVanity    = "https://api.steampowered.com/ISteamUser/ResolveVanityURL/v0001/?"
 	BaseGID      = uint64(103582791429521408)
 	BaseSID      = uint64(76561197960265728)
 	InstanceMask = 0x000FFFFF
@@ -19,7 +19,10 @@ var (
 	// ErrNoAPIKey is returned for functions that require an API key to use when one has not been set.
 	ErrNoAPIKey = errors.New("no steam web api key, to obtain one see: " +
 		"https://steamcommunity.com/dev/apikey and call steamid.SetKey()")
+	// ErrNoAccessToken is returned for the ISteamUserOAuth functions when no access token is set.
+	ErrNoAccessToken      = errors.New("no steam user access token, see WithAccessToken")
 	ErrInvalidKey         = errors.New("invalid steam api key length, must be 32 chars or 0 to remove it")
+	ErrMalformedKey       = errors.New("invalid steam api key, must only contain hexadecimal characters")
 	ErrInvalidSID         = errors.New("invalid steam id")
 	ErrEmptyString        = errors.New("invalid id, string empty")
 	ErrSIDConvertInt64    = errors.New("failed to convert id to int64")
@@ -31,8 +34,19 @@ var (
 	ErrResponsePerform    = errors.New("failed to perform request")
 	ErrResponseBody       = errors.New("failed to read response body")
 	ErrResolveVanityGID   = errors.New("failed to resolve group vanity name")
+	ErrVanityNotFound     = errors.New("vanity name not found")
+	ErrFileNotFound       = errors.New("published file not found")
+	ErrPlayerNotFound     = errors.New("player not found")
 	ErrInvalidQueryValue  = errors.New("invalid query value")
 	ErrInvalidQueryLen    = errors.New("invalid value length")
+	ErrInvalidFriendCode  = errors.New("invalid friend code")
+	ErrInvalidTradeURL    = errors.New("invalid trade offer url")
+	ErrInvalidShortCode   = errors.New("invalid short code")
+	ErrInvalidAccountType = errors.New("account type out of range")
+	ErrInvalidUniverse    = errors.New("universe out of range")
+	ErrInvalidAccountID   = errors.New("account id must not be 0")
+	ErrInvalidInstance    = errors.New("instance not valid for the account type")
+	ErrInvalidArray       = errors.New("invalid array value")
 )
 
 // AppID is the id associated with games/apps.
@@ -42,6 +56,28 @@ type AppID uint32
 // STEAM_0:0:86173181.
 type SID string
 
+// SteamID converts the steam2 id without detecting its format, returning an invalid id when it is
+// not a steam2 id.
+func (s SID) SteamID() SteamID {
+	match := reSteam2.FindStringSubmatch(string(s))
+	if match == nil {
+		return invalidSID
+	}
+
+	return fromSteam2Strings(match)
+}
+
+// Valid returns true when the value is a valid steam2 id.
+func (s SID) Valid() bool {
+	sid := s.SteamID()
+
+	return sid.Valid()
+}
+
+func (s SID) String() string {
+	return string(s)
+}
+
 // Universe describes the 6 known steam universe
 // Universes 0 to 3 are common, 4 Dev not exist in all games, 5 RC is removed out from some source files "// no such universe anymore".
 type Universe int
@@ -216,10 +252,50 @@ func (i Instance) String() string {
 // 172346362.
 type SID32 uint32
 
+// SteamID returns the individual account of the public universe with the account id.
+func (s SID32) SteamID() SteamID {
+	if s == 0 {
+		return invalidSID
+	}
+
+	return fromUInt64(uint64(s))
+}
+
+// Valid returns true when the value is a valid account id, which is any value but 0.
+func (s SID32) Valid() bool {
+	return s != 0
+}
+
+func (s SID32) String() string {
+	return strconv.FormatUint(uint64(s), 10)
+}
+
 // SID3 represents a Steam3
 // [U:1:172346362].
 type SID3 string
 
+// SteamID converts the steam3 id without detecting its format, returning an invalid id when it is
+// not a steam3 id.
+func (s SID3) SteamID() SteamID {
+	match := reSteam3.FindStringSubmatch(string(s))
+	if match == nil {
+		return invalidSID
+	}
+
+	return fromSteam3Strings(match)
+}
+
+// Valid returns true when the value is a valid steam3 id.
+func (s SID3) Valid() bool {
+	sid := s.SteamID()
+
+	return sid.Valid()
+}
+
+func (s SID3) String() string {
+	return string(s)
+}
+
 type Collection []SteamID
 
 func (c Collection) ToStringSlice() []string {
@@ -244,6 +320,57 @@ func (c Collection) ToInt64Slice() []int64 {
 
 func (c Collection) Contains(sid64 SteamID) bool {
 	return slices.ContainsFunc(c, func(id SteamID) bool {
-		return id.Int64() == sid64.Int64()
+		return id.Uint64() == sid64.Uint64()
 	})
 }
+
+// Unique returns the ids of the collection without duplicates, in the order they were first seen.
+func (c Collection) Unique() Collection {
+	if len(c) == 0 {
+		return c
+	}
+
+	var (
+		seen = make(map[uint64]struct{}, len(c))
+		uniq = make(Collection, 0, len(c))
+	)
+
+	for _, sid := range c {
+		if _, found := seen[sid.Uint64()]; found {
+			continue
+		}
+
+		seen[sid.Uint64()] = struct{}{}
+		uniq = append(uniq, sid)
+	}
+
+	return uniq
+}
+
+// Diff compares the collection to a newer version of it. Added contains the ids only in other and
+// removed contains the ids only in c, each in the order they appear and without duplicates.
+func (c Collection) Diff(other Collection) (Collection, Collection) {
+	var (
+		current  = NewSet(c...)
+		next     = NewSet(other...)
+		added    Collection
+		removed  Collection
+		reported = Set{}
+	)
+
+	for _, sid := range other {
+		if !current.Contains(sid) && !reported.Contains(sid) {
+			added = append(added, sid)
+			reported.Add(sid)
+		}
+	}
+
+	for _, sid := range c {
+		if !next.Contains(sid) && !reported.Contains(sid) {
+			removed = append(removed, sid)
+			reported.Add(sid)
+		}
+	}
+
+	return added, removed
+}
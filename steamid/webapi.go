@@ -0,0 +1,113 @@
+//go:build !steamid_nonet
+
+package steamid
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// httpClient and apiKey are used by the package level functions. They are only accessed
+	// atomically so SetKey and SetHTTPClient are safe to call while requests are being made.
+	// httpClient is created on first use, see GetHTTP.
+	httpClient atomic.Pointer[http.Client] //nolint:gochecknoglobals
+	apiKey     atomic.Pointer[string]      //nolint:gochecknoglobals
+	// envKeyErr holds the error of an invalid key set with the STEAM_TOKEN environment variable. It
+	// is returned by the package level functions requiring a key until SetKey is called.
+	envKeyErr atomic.Pointer[error] //nolint:gochecknoglobals
+)
+
+// currentKey returns the package level api key, or an empty string when none is set.
+func currentKey() string {
+	if key := apiKey.Load(); key != nil {
+		return *key
+	}
+
+	return ""
+}
+
+// KeyConfigured returns true when a package level api key has been set.
+func KeyConfigured() bool {
+	return currentKey() != ""
+}
+
+// SetKey will set the package global steam webapi key used for some requests
+// Basic id conversion usage does not require this to be set.
+//
+// You can alternatively set the key with the environment variable `STEAM_TOKEN={YOUR_API_KEY`
+// To get a key see: https://steamcommunity.com/dev/apikey
+//
+// Surrounding whitespace is ignored. ErrInvalidKey is returned for keys of the wrong length and
+// ErrMalformedKey for keys holding anything but hexadecimal characters. An invalid STEAM_TOKEN is
+// not set, instead its error is returned by the first request requiring a key.
+//
+// It is safe to call at any time, requests already in progress keep using the previous key.
+func SetKey(key string) error {
+	key, errKey := normalizeKey(key)
+	if errKey != nil {
+		return errKey
+	}
+
+	apiKey.Store(&key)
+	envKeyErr.Store(nil)
+
+	return nil
+}
+
+// GetHTTP returns the http client used by the package level functions, creating the default
+// client with a 10 second timeout if none has been set with SetHTTPClient.
+func GetHTTP() *http.Client {
+	if client := httpClient.Load(); client != nil {
+		return client
+	}
+
+	httpClient.CompareAndSwap(nil, &http.Client{Timeout: time.Second * 10})
+
+	return httpClient.Load()
+}
+
+// SetHTTPClient replaces the http client used by the package level functions, e.g. to configure
+// the transport, timeouts or TLS settings. Passing nil restores the default client. It is safe to
+// call at any time, requests already in progress keep using the previous client.
+func SetHTTPClient(client *http.Client) {
+	httpClient.Store(client)
+}
+
+// ResolveGID resolves the id of a group from its url, vanity name or any format of group id using
+// the package level api key and http client. See Client.ResolveGID.
+func ResolveGID(ctx context.Context, groupVanityURL string) (SteamID, error) {
+	return defaultClient().ResolveGID(ctx, groupVanityURL)
+}
+
+type vanityURLResponse struct {
+	Response struct {
+		SteamID SteamID `json:"steamid"`
+		Success int     `json:"success"`
+	} `json:"response"`
+}
+
+// ResolveVanity attempts to resolve the underlying SID64 of a users vanity url name
+// This only accepts the name or last portion of the /id/ profile link
+// For https://steamcommunity.com/id/SQUIRRELLY the value is SQUIRRELLY.
+func ResolveVanity(ctx context.Context, query string) (SteamID, error) {
+	return defaultClient().ResolveVanity(ctx, query)
+}
+
+// Resolve tries to retrieve a SteamID from a profile URL using the package level api key. See
+// Client.Resolve. Numbers are always converted as ids first, use a Client created WithNumericVanity
+// to resolve numeric vanity names such as 123456789.
+func Resolve(ctx context.Context, query string) (SteamID, error) {
+	return defaultClient().Resolve(ctx, query)
+}
+
+func init() {
+	if t, found := os.LookupEnv("STEAM_TOKEN"); found && t != "" {
+		if err := SetKey(t); err != nil {
+			envKeyErr.Store(&err)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+//go:build !steamid_nonet
+
+package steamid_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveGID(t *testing.T) {
+	t.Parallel()
+
+	gid1, err := steamid.ResolveGID(context.Background(), "SQ_Stream")
+
+	require.NoError(t, err, "Failed to fetch gid")
+	require.True(t, gid1.Valid())
+	require.Equal(t, gid1, steamid.New(103582791441572968))
+
+	gid2, err2 := steamid.ResolveGID(context.Background(), "SQTreeHouseHJHJHSDAF")
+
+	require.Errorf(t, err2, "Failed to fetch gid2")
+	require.False(t, gid2.Valid())
+}
+
+func TestResolveSID(t *testing.T) {
+	t.Parallel()
+
+	if !steamid.KeyConfigured() {
+		t.Skip("steam_api_key unset, SetKey() required")
+
+		return
+	}
+
+	sid1, err := steamid.Resolve(context.Background(), "https://steamcommunity.com/id/SQUIRRELLY")
+	require.NoError(t, err)
+	require.Equal(t, sid1, steamid.New(76561197961279983))
+
+	sid2, err2 := steamid.Resolve(context.Background(), "https://steamcommunity.com/id/FAKEXXXXXXXXXX123123")
+	require.Error(t, err2)
+	require.False(t, sid2.Valid())
+
+	sid3, err3 := steamid.Resolve(context.Background(), "http://steamcommunity.com/profiles/76561197961279983")
+	require.NoError(t, err3)
+	require.Equal(t, sid3, steamid.New(76561197961279983))
+
+	sid4, err4 := steamid.Resolve(context.Background(), "[U:1:1014255]")
+	require.NoError(t, err4)
+	require.Equal(t, sid4, steamid.New(76561197961279983))
+
+	sid5, err5 := steamid.Resolve(context.Background(), "STEAM_0:1:507127")
+	require.Equal(t, sid5, steamid.New(76561197961279983))
+	require.NoError(t, err5)
+
+	sid6, err6 := steamid.Resolve(context.Background(), "")
+	require.Error(t, err6)
+	require.False(t, sid6.Valid())
+}
+
+func TestMain(m *testing.M) {
+	if key, found := os.LookupEnv("STEAM_TOKEN"); found {
+		_ = steamid.SetKey(key)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestMalformedEnvKey(t *testing.T) {
+	t.Parallel()
+
+	if os.Getenv("STEAMID_TEST_MALFORMED_KEY") != "" {
+		// Running in the process started below, where the package was initialised with a
+		// malformed STEAM_TOKEN.
+		ids := []steamid.SteamID{steamid.New(76561197960287930)}
+
+		_, errSummaries := steamid.PlayerSummaries(context.Background(), ids)
+		require.ErrorIs(t, errSummaries, steamid.ErrMalformedKey)
+		require.False(t, steamid.KeyConfigured())
+
+		require.NoError(t, steamid.SetKey(""))
+
+		_, errNoKey := steamid.PlayerSummaries(context.Background(), ids)
+		require.ErrorIs(t, errNoKey, steamid.ErrNoAPIKey)
+
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestMalformedEnvKey$")
+	cmd.Env = append(os.Environ(), "STEAM_TOKEN="+strings.Repeat("z", 32), "STEAMID_TEST_MALFORMED_KEY=1")
+
+	output, errRun := cmd.CombinedOutput()
+	require.NoError(t, errRun, string(output))
+}
+
+func TestSetKeyConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key = os.Getenv("STEAM_TOKEN")
+		wg  sync.WaitGroup
+	)
+
+	// Run with -race to detect unsynchronised access to the package level key.
+	for range 4 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				_ = steamid.SetKey(key)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				_ = steamid.KeyConfigured()
+				_, _ = steamid.PlayerSummaries(context.Background(), nil)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, key != "", steamid.KeyConfigured())
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// TestSetHTTPClient replaces the package level client and key, so it must not run in parallel.
+func TestSetHTTPClient(t *testing.T) {
+	// Without a key groups are resolved from the community site.
+	key := os.Getenv("STEAM_TOKEN")
+	require.NoError(t, steamid.SetKey(""))
+	t.Cleanup(func() {
+		_ = steamid.SetKey(key)
+	})
+
+	original := steamid.GetHTTP()
+	require.NotNil(t, original)
+	require.Same(t, original, steamid.GetHTTP())
+
+	var requested string
+
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = req.URL.String()
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`<memberList><groupID64>103582791441572968</groupID64></memberList>`)),
+		}, nil
+	})}
+
+	steamid.SetHTTPClient(client)
+	t.Cleanup(func() {
+		steamid.SetHTTPClient(original)
+	})
+
+	require.Same(t, client, steamid.GetHTTP())
+
+	gid, err := steamid.ResolveGID(context.Background(), "SQTreeHouse")
+	require.NoError(t, err)
+	require.Equal(t, steamid.New(103582791441572968), gid)
+	require.Equal(t, "https://steamcommunity.com/groups/SQTreeHouse/memberslistxml/?xml=1&p=1", requested)
+
+	steamid.SetHTTPClient(nil)
+	require.NotNil(t, steamid.GetHTTP())
+	require.NotSame(t, client, steamid.GetHTTP())
+}
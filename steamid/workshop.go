@@ -0,0 +1,80 @@
+//go:build !steamid_nonet
+
+package steamid
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// publishedFileResultOK is the result value of a published file that was found.
+const publishedFileResultOK = 1
+
+// publishedFileDetailsResponse is the response of the ISteamRemoteStorage/GetPublishedFileDetails
+// endpoint.
+type publishedFileDetailsResponse struct {
+	Response struct {
+		PublishedFileDetails []struct {
+			PublishedFileID string  `json:"publishedfileid"`
+			Result          int     `json:"result"`
+			Creator         SteamID `json:"creator"`
+		} `json:"publishedfiledetails"`
+	} `json:"response"`
+}
+
+// parsePublishedFileID returns the id of a published file from its id or a workshop or shared
+// file url, such as https://steamcommunity.com/sharedfiles/filedetails/?id=123456789.
+func parsePublishedFileID(query string) (uint64, bool) {
+	query = strings.TrimSpace(query)
+
+	if fileID, errParse := strconv.ParseUint(query, 10, 64); errParse == nil {
+		return fileID, fileID != 0
+	}
+
+	if !strings.Contains(query, "://") {
+		query = "https://" + query
+	}
+
+	parsed, errParse := url.Parse(query)
+	if errParse != nil || !strings.HasSuffix(strings.TrimRight(parsed.Path, "/"), "/filedetails") {
+		return 0, false
+	}
+
+	fileID, errParse := strconv.ParseUint(parsed.Query().Get("id"), 10, 64)
+
+	return fileID, errParse == nil && fileID != 0
+}
+
+// PublishedFileAuthor returns the id of the creator of a workshop item or other published file,
+// such as a screenshot or guide. The query is the file id or its url, e.g.
+// https://steamcommunity.com/sharedfiles/filedetails/?id=123456789. No api key is required, but
+// only public files are found; ErrFileNotFound is returned for files that are private, removed
+// or do not exist.
+func (c *Client) PublishedFileAuthor(ctx context.Context, query string) (SteamID, error) {
+	fileID, isFileID := parsePublishedFileID(query)
+	if !isFileID {
+		return SteamID{}, resolveError(query, ErrInvalidQueryValue)
+	}
+
+	values := url.Values{"itemcount": {"1"}, "publishedfileids[0]": {strconv.FormatUint(fileID, 10)}}
+
+	var resp publishedFileDetailsResponse
+	if errPost := c.post(ctx, "/ISteamRemoteStorage/GetPublishedFileDetails/v1/", values, &resp); errPost != nil {
+		return SteamID{}, errPost
+	}
+
+	details := resp.Response.PublishedFileDetails
+	if len(details) == 0 || details[0].Result != publishedFileResultOK || !details[0].Creator.Valid() {
+		return SteamID{}, resolveError(query, ErrFileNotFound)
+	}
+
+	return details[0].Creator, nil
+}
+
+// PublishedFileAuthor returns the id of the creator of a published file. See
+// Client.PublishedFileAuthor.
+func PublishedFileAuthor(ctx context.Context, query string) (SteamID, error) {
+	return defaultClient().PublishedFileAuthor(ctx, query)
+}
@@ -0,0 +1,51 @@
+//go:build !steamid_nonet
+
+package steamid_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPublishedFileAuthor(t *testing.T) {
+	t.Parallel()
+
+	client := newTestAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/ISteamRemoteStorage/GetPublishedFileDetails/v1/", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "1", r.PostForm.Get("itemcount"))
+
+		details := map[string]any{"publishedfileid": r.PostForm.Get("publishedfileids[0]"), "result": 9}
+		if r.PostForm.Get("publishedfileids[0]") == "123456789" {
+			details = map[string]any{"publishedfileid": "123456789", "result": 1, "creator": "76561198132612090"}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"response": map[string]any{
+			"result": 1, "resultcount": 1, "publishedfiledetails": []any{details},
+		}})
+	})
+
+	for _, query := range []string{
+		"123456789", "https://steamcommunity.com/sharedfiles/filedetails/?id=123456789",
+		"steamcommunity.com/workshop/filedetails/?id=123456789&searchtext=", " 123456789 ",
+	} {
+		author, errAuthor := client.PublishedFileAuthor(context.Background(), query)
+		require.NoError(t, errAuthor, query)
+		require.Equal(t, steamid.New(76561198132612090), author, query)
+	}
+
+	_, errMissing := client.PublishedFileAuthor(context.Background(), "987654321")
+	require.ErrorIs(t, errMissing, steamid.ErrFileNotFound)
+	require.ErrorIs(t, errMissing, steamid.ErrResolve)
+
+	for _, query := range []string{"", "0", "https://steamcommunity.com/id/SQUIRRELLY", "https://steamcommunity.com/sharedfiles/filedetails/"} {
+		_, errInvalid := client.PublishedFileAuthor(context.Background(), query)
+		require.ErrorIs(t, errInvalid, steamid.ErrInvalidQueryValue, query)
+	}
+}
@@ -0,0 +1,45 @@
+//go:build !steamid_noyaml
+
+package steamid
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for steam ids. Quoted and unquoted
+// scalars of any format are accepted, including integers and unquoted steam3 ids such as
+// [U:1:22202], which yaml parses as a flow sequence.
+func (t *SteamID) UnmarshalYAML(node *yaml.Node) error {
+	value := node.Value
+
+	switch {
+	case node.Kind == yaml.ScalarNode:
+	case node.Kind == yaml.SequenceNode && node.Style&yaml.FlowStyle != 0 && len(node.Content) == 1 &&
+		node.Content[0].Kind == yaml.ScalarNode:
+		value = "[" + node.Content[0].Value + "]"
+	default:
+		return parseError(value, fmt.Errorf("%w: line %d", ErrInvalidSID, node.Line))
+	}
+
+	sid := New(value)
+	if !sid.Valid() {
+		return parseError(value, ErrInvalidSID)
+	}
+
+	*t = sid
+
+	return nil
+}
+
+// YAMLNumber is a SteamID marshalled to yaml as an unquoted steam64 integer, rather than the quoted
+// string of SteamID, for configs that prefer numbers. It is unmarshalled like a SteamID.
+type YAMLNumber struct {
+	SteamID
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (t YAMLNumber) MarshalYAML() (any, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: t.String()}, nil
+}
@@ -0,0 +1,76 @@
+//go:build !steamid_noyaml
+
+package steamid_test
+
+import (
+	"testing"
+
+	"github.com/leighmacdonald/steamid/v4/steamid"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAML(t *testing.T) {
+	t.Parallel()
+
+	type testFormats struct {
+		Quoted steamid.SteamID `yaml:"quoted"`
+	}
+
+	var out testFormats
+	require.NoError(t, yaml.Unmarshal([]byte(`{"quoted":"76561197970669109"}`), &out))
+
+	expected := steamid.New(76561197970669109)
+	require.Equal(t, expected, out.Quoted, "Quoted value invalid")
+
+	body, errMarshal := yaml.Marshal(&expected)
+	require.NoError(t, errMarshal)
+	require.Equal(t, []byte("\"76561197970669109\"\n"), body)
+
+	type testGIDResp struct {
+		GID steamid.SteamID `json:"gid"`
+	}
+
+	var r testGIDResp
+	require.NoError(t, yaml.Unmarshal([]byte(`{"gid":"103582791441572968"}`), &r))
+	expectedGID := steamid.New(103582791441572968)
+
+	require.Equal(t, expectedGID.Int64(), r.GID.Int64())
+
+	for _, input := range []string{"sid: 76561198132612090", "sid: 172346362", "sid: STEAM_0:0:86173181",
+		"sid: '[U:1:172346362]'", "sid: [U:1:172346362]", "sid: [ U:1:172346362 ]"} {
+		var decoded struct {
+			SID steamid.SteamID `yaml:"sid"`
+		}
+
+		require.NoError(t, yaml.Unmarshal([]byte(input), &decoded), input)
+		require.Equal(t, steamid.New(76561198132612090), decoded.SID, input)
+	}
+
+	for _, input := range []string{"sid: [U:1:1, U:1:2]", "sid: {a: 1}", "sid: invalid", "sid: [U:1:0]"} {
+		var decoded struct {
+			SID steamid.SteamID `yaml:"sid"`
+		}
+
+		require.ErrorIs(t, yaml.Unmarshal([]byte(input), &decoded), steamid.ErrInvalidSID, input)
+	}
+}
+
+func TestYAMLNumber(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Owner steamid.YAMLNumber `yaml:"owner"`
+	}
+
+	body, errMarshal := yaml.Marshal(config{Owner: steamid.YAMLNumber{SteamID: steamid.New(76561198132612090)}})
+	require.NoError(t, errMarshal)
+	require.Equal(t, "owner: 76561198132612090\n", string(body))
+
+	var decoded config
+	require.NoError(t, yaml.Unmarshal(body, &decoded))
+	require.Equal(t, steamid.New(76561198132612090), decoded.Owner.SteamID)
+
+	require.NoError(t, yaml.Unmarshal([]byte("owner: '[U:1:172346362]'"), &decoded))
+	require.Equal(t, steamid.New(76561198132612090), decoded.Owner.SteamID)
+}